@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkCheckerToV2 ...
+//
+// It returns the checker itself if it implements the models.LinkCheckerV2
+// interface. Otherwise, it wraps the checker by the LinkCheckerV1ToV2 adapter.
+func LinkCheckerToV2(checker models.LinkChecker) models.LinkCheckerV2 {
+	if checkerV2, ok := checker.(models.LinkCheckerV2); ok {
+		return checkerV2
+	}
+
+	return LinkCheckerV1ToV2{LinkChecker: checker}
+}
+
+// LinkCheckerV1ToV2 ...
+//
+// It allows to use a models.LinkChecker as a models.LinkCheckerV2.
+// The link is converted by the models.RichLink.SourcedLink() method.
+type LinkCheckerV1ToV2 struct {
+	LinkChecker models.LinkChecker
+}
+
+// CheckRichLink ...
+func (adapter LinkCheckerV1ToV2) CheckRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) bool {
+	return adapter.LinkChecker.CheckLink(ctx, link.SourcedLink())
+}
+
+// LinkCheckerV2ToV1 ...
+//
+// It allows to use a models.LinkCheckerV2 as a models.LinkChecker.
+// The link is converted by the models.NewRichLink() function.
+type LinkCheckerV2ToV1 struct {
+	LinkCheckerV2 models.LinkCheckerV2
+}
+
+// CheckLink ...
+func (adapter LinkCheckerV2ToV1) CheckLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) bool {
+	return adapter.LinkCheckerV2.CheckRichLink(ctx, models.NewRichLink(link))
+}
@@ -0,0 +1,95 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestLinkCheckerToV2(test *testing.T) {
+	type richChecker struct {
+		*MockLinkChecker
+		*MockLinkCheckerV2
+	}
+
+	for _, data := range []struct {
+		name    string
+		checker models.LinkChecker
+		want    func(checker models.LinkChecker) models.LinkCheckerV2
+	}{
+		{
+			name:    "with the first version of the checker",
+			checker: new(MockLinkChecker),
+			want: func(checker models.LinkChecker) models.LinkCheckerV2 {
+				return LinkCheckerV1ToV2{LinkChecker: checker}
+			},
+		},
+		{
+			name: "with both versions of the checker",
+			checker: richChecker{
+				MockLinkChecker:   new(MockLinkChecker),
+				MockLinkCheckerV2: new(MockLinkCheckerV2),
+			},
+			want: func(checker models.LinkChecker) models.LinkCheckerV2 {
+				return checker.(models.LinkCheckerV2)
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := LinkCheckerToV2(data.checker)
+
+			assert.Equal(test, data.want(data.checker), got)
+		})
+	}
+}
+
+func TestLinkCheckerV1ToV2_CheckRichLink(test *testing.T) {
+	checker := new(MockLinkChecker)
+	checker.
+		On("CheckLink", context.Background(), models.SourcedLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Depth:      1,
+		}).
+		Return(true)
+
+	adapter := LinkCheckerV1ToV2{LinkChecker: checker}
+	got := adapter.CheckRichLink(context.Background(), models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+		Attributes: models.Attributes{}.
+			Set(models.ContentTypeAttribute, "text/html").
+			Set(models.HTMLMetadataAttribute, models.HTMLMetadata{
+				TagName:       "a",
+				AttributeName: "href",
+			}),
+	})
+
+	mock.AssertExpectationsForObjects(test, checker)
+	assert.True(test, got)
+}
+
+func TestLinkCheckerV2ToV1_CheckLink(test *testing.T) {
+	checker := new(MockLinkCheckerV2)
+	checker.
+		On("CheckRichLink", context.Background(), models.RichLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Depth:      1,
+		}).
+		Return(false)
+
+	adapter := LinkCheckerV2ToV1{LinkCheckerV2: checker}
+	got := adapter.CheckLink(context.Background(), models.SourcedLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+	})
+
+	mock.AssertExpectationsForObjects(test, checker)
+	assert.False(test, got)
+}
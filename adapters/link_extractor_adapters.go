@@ -0,0 +1,82 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkExtractorToV2 ...
+//
+// It returns the extractor itself if it implements the models.LinkExtractorV2
+// interface. Otherwise, it wraps the extractor by the LinkExtractorV1ToV2
+// adapter.
+func LinkExtractorToV2(extractor models.LinkExtractor) models.LinkExtractorV2 {
+	if extractorV2, ok := extractor.(models.LinkExtractorV2); ok {
+		return extractorV2
+	}
+
+	return LinkExtractorV1ToV2{LinkExtractor: extractor}
+}
+
+// LinkExtractorV1ToV2 ...
+//
+// It allows to use a models.LinkExtractor as a models.LinkExtractorV2.
+// The extracted links have no attributes, and the extracted page
+// is the specified link itself.
+type LinkExtractorV1ToV2 struct {
+	LinkExtractor models.LinkExtractor
+}
+
+// ExtractRichLinks ...
+func (adapter LinkExtractorV1ToV2) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	extractedLinks, err :=
+		adapter.LinkExtractor.ExtractLinks(ctx, threadID, link.Link)
+	if err != nil {
+		return models.RichLink{}, nil, err
+	}
+
+	var richLinks []models.RichLink
+	for _, extractedLink := range extractedLinks {
+		richLinks = append(richLinks, models.RichLink{
+			SourceLink: link.Link,
+			Link:       extractedLink,
+			Depth:      link.Depth + 1,
+		})
+	}
+
+	return link, richLinks, nil
+}
+
+// LinkExtractorV2ToV1 ...
+//
+// It allows to use a models.LinkExtractorV2 as a models.LinkExtractor.
+// The link passed to the inner extractor has no source, depth and attributes,
+// and the extracted page and the attributes of the extracted links are lost.
+type LinkExtractorV2ToV1 struct {
+	LinkExtractorV2 models.LinkExtractorV2
+}
+
+// ExtractLinks ...
+func (adapter LinkExtractorV2ToV1) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) ([]string, error) {
+	_, richLinks, err := adapter.LinkExtractorV2.
+		ExtractRichLinks(ctx, threadID, models.RichLink{Link: link})
+	if err != nil {
+		return nil, err
+	}
+
+	var extractedLinks []string
+	for _, richLink := range richLinks {
+		extractedLinks = append(extractedLinks, richLink.Link)
+	}
+
+	return extractedLinks, nil
+}
@@ -0,0 +1,248 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestLinkExtractorToV2(test *testing.T) {
+	type richLinkExtractor struct {
+		*MockLinkExtractor
+		*MockLinkExtractorV2
+	}
+
+	for _, data := range []struct {
+		name      string
+		extractor models.LinkExtractor
+		want      func(extractor models.LinkExtractor) models.LinkExtractorV2
+	}{
+		{
+			name:      "with the first version of the extractor",
+			extractor: new(MockLinkExtractor),
+			want: func(extractor models.LinkExtractor) models.LinkExtractorV2 {
+				return LinkExtractorV1ToV2{LinkExtractor: extractor}
+			},
+		},
+		{
+			name: "with both versions of the extractor",
+			extractor: richLinkExtractor{
+				MockLinkExtractor:   new(MockLinkExtractor),
+				MockLinkExtractorV2: new(MockLinkExtractorV2),
+			},
+			want: func(extractor models.LinkExtractor) models.LinkExtractorV2 {
+				return extractor.(models.LinkExtractorV2)
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := LinkExtractorToV2(data.extractor)
+
+			assert.Equal(test, data.want(data.extractor), got)
+		})
+	}
+}
+
+func TestLinkExtractorV1ToV2_ExtractRichLinks(test *testing.T) {
+	type fields struct {
+		LinkExtractor models.LinkExtractor
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     models.RichLink
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantPage  models.RichLink
+		wantLinks []models.RichLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/source",
+					Link:       "http://example.com/",
+					Depth:      2,
+				},
+			},
+			wantPage: models.RichLink{
+				SourceLink: "http://example.com/source",
+				Link:       "http://example.com/",
+				Depth:      2,
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      3,
+				},
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/2",
+					Depth:      3,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage:  models.RichLink{},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			adapter := LinkExtractorV1ToV2{
+				LinkExtractor: data.fields.LinkExtractor,
+			}
+			gotPage, gotLinks, gotErr := adapter.ExtractRichLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			mock.AssertExpectationsForObjects(test, data.fields.LinkExtractor)
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestLinkExtractorV2ToV1_ExtractLinks(test *testing.T) {
+	type fields struct {
+		LinkExtractorV2 models.LinkExtractorV2
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				LinkExtractorV2: func() models.LinkExtractorV2 {
+					extractor := new(MockLinkExtractorV2)
+					extractor.
+						On(
+							"ExtractRichLinks",
+							context.Background(),
+							23,
+							models.RichLink{Link: "http://example.com/"},
+						).
+						Return(
+							models.RichLink{
+								Link: "http://example.com/",
+								Attributes: models.Attributes{}.
+									Set(models.ContentTypeAttribute, "text/html"),
+							},
+							[]models.RichLink{
+								{
+									Link: "http://example.com/1",
+									Attributes: models.Attributes{}.Set(
+										models.HTMLMetadataAttribute,
+										models.HTMLMetadata{TagName: "a"},
+									),
+								},
+								{Link: "http://example.com/2"},
+							},
+							nil,
+						)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				LinkExtractorV2: func() models.LinkExtractorV2 {
+					extractor := new(MockLinkExtractorV2)
+					extractor.
+						On(
+							"ExtractRichLinks",
+							context.Background(),
+							23,
+							models.RichLink{Link: "http://example.com/"},
+						).
+						Return(models.RichLink{}, nil, iotest.ErrTimeout)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			adapter := LinkExtractorV2ToV1{
+				LinkExtractorV2: data.fields.LinkExtractorV2,
+			}
+			gotLinks, gotErr := adapter.ExtractLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			mock.AssertExpectationsForObjects(test, data.fields.LinkExtractorV2)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkHandlerToV2 ...
+//
+// It returns the handler itself if it implements the models.LinkHandlerV2
+// interface. Otherwise, it wraps the handler by the LinkHandlerV1ToV2 adapter.
+func LinkHandlerToV2(handler models.LinkHandler) models.LinkHandlerV2 {
+	if handlerV2, ok := handler.(models.LinkHandlerV2); ok {
+		return handlerV2
+	}
+
+	return LinkHandlerV1ToV2{LinkHandler: handler}
+}
+
+// LinkHandlerV1ToV2 ...
+//
+// It allows to use a models.LinkHandler as a models.LinkHandlerV2.
+// The link is converted by the models.RichLink.SourcedLink() method.
+type LinkHandlerV1ToV2 struct {
+	LinkHandler models.LinkHandler
+}
+
+// HandleRichLink ...
+func (adapter LinkHandlerV1ToV2) HandleRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) {
+	adapter.LinkHandler.HandleLink(ctx, link.SourcedLink())
+}
+
+// LinkHandlerV2ToV1 ...
+//
+// It allows to use a models.LinkHandlerV2 as a models.LinkHandler.
+// The link is converted by the models.NewRichLink() function.
+type LinkHandlerV2ToV1 struct {
+	LinkHandlerV2 models.LinkHandlerV2
+}
+
+// HandleLink ...
+func (adapter LinkHandlerV2ToV1) HandleLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) {
+	adapter.LinkHandlerV2.HandleRichLink(ctx, models.NewRichLink(link))
+}
@@ -0,0 +1,88 @@
+package adapters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestLinkHandlerToV2(test *testing.T) {
+	type richHandler struct {
+		*MockLinkHandler
+		*MockLinkHandlerV2
+	}
+
+	for _, data := range []struct {
+		name    string
+		handler models.LinkHandler
+		want    func(handler models.LinkHandler) models.LinkHandlerV2
+	}{
+		{
+			name:    "with the first version of the handler",
+			handler: new(MockLinkHandler),
+			want: func(handler models.LinkHandler) models.LinkHandlerV2 {
+				return LinkHandlerV1ToV2{LinkHandler: handler}
+			},
+		},
+		{
+			name: "with both versions of the handler",
+			handler: richHandler{
+				MockLinkHandler:   new(MockLinkHandler),
+				MockLinkHandlerV2: new(MockLinkHandlerV2),
+			},
+			want: func(handler models.LinkHandler) models.LinkHandlerV2 {
+				return handler.(models.LinkHandlerV2)
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := LinkHandlerToV2(data.handler)
+
+			assert.Equal(test, data.want(data.handler), got)
+		})
+	}
+}
+
+func TestLinkHandlerV1ToV2_HandleRichLink(test *testing.T) {
+	handler := new(MockLinkHandler)
+	handler.
+		On("HandleLink", context.Background(), models.SourcedLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Depth:      1,
+		}).
+		Return()
+
+	adapter := LinkHandlerV1ToV2{LinkHandler: handler}
+	adapter.HandleRichLink(context.Background(), models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+		Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+	})
+
+	mock.AssertExpectationsForObjects(test, handler)
+}
+
+func TestLinkHandlerV2ToV1_HandleLink(test *testing.T) {
+	handler := new(MockLinkHandlerV2)
+	handler.
+		On("HandleRichLink", context.Background(), models.RichLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Depth:      1,
+		}).
+		Return()
+
+	adapter := LinkHandlerV2ToV1{LinkHandlerV2: handler}
+	adapter.HandleLink(context.Background(), models.SourcedLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+	})
+
+	mock.AssertExpectationsForObjects(test, handler)
+}
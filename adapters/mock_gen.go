@@ -0,0 +1,65 @@
+package adapters
+
+import (
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+//go:generate mockery --name=LinkExtractor --inpackage --case=underscore --testonly
+
+// LinkExtractor ...
+//
+// It's used only for mock generating.
+//
+type LinkExtractor interface {
+	models.LinkExtractor
+}
+
+//go:generate mockery --name=LinkExtractorV2 --inpackage --case=underscore --testonly
+
+// LinkExtractorV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkExtractorV2 interface {
+	models.LinkExtractorV2
+}
+
+//go:generate mockery --name=LinkChecker --inpackage --case=underscore --testonly
+
+// LinkChecker ...
+//
+// It's used only for mock generating.
+//
+type LinkChecker interface {
+	models.LinkChecker
+}
+
+//go:generate mockery --name=LinkCheckerV2 --inpackage --case=underscore --testonly
+
+// LinkCheckerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkCheckerV2 interface {
+	models.LinkCheckerV2
+}
+
+//go:generate mockery --name=LinkHandler --inpackage --case=underscore --testonly
+
+// LinkHandler ...
+//
+// It's used only for mock generating.
+//
+type LinkHandler interface {
+	models.LinkHandler
+}
+
+//go:generate mockery --name=LinkHandlerV2 --inpackage --case=underscore --testonly
+
+// LinkHandlerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkHandlerV2 interface {
+	models.LinkHandlerV2
+}
@@ -0,0 +1,26 @@
+package brokenlinks
+
+import (
+	"github.com/thewizardplusplus/go-crawler/models"
+	httputils "github.com/thewizardplusplus/go-http-utils"
+)
+
+//go:generate mockery --name=HTTPClient --inpackage --case=underscore --testonly
+
+// HTTPClient ...
+//
+// It's used only for mock generating.
+//
+type HTTPClient interface {
+	httputils.HTTPClient
+}
+
+//go:generate mockery --name=LinkChecker --inpackage --case=underscore --testonly
+
+// LinkChecker ...
+//
+// It's used only for mock generating.
+//
+type LinkChecker interface {
+	models.LinkChecker
+}
@@ -0,0 +1,58 @@
+package brokenlinks
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/extractors"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// PageStatusHandler ...
+//
+// It records the statuses of the crawled pages to the register. It should be
+// used both as the page handler and as the error handler of the crawling,
+// since the pages with the unaccepted status codes (see the StatusPolicy type
+// of the extractors package) and the failed ones don't reach the page handler.
+// The redirect chains and the latencies aren't recorded for the pages.
+type PageStatusHandler struct {
+	StatusRegister *StatusRegister
+}
+
+// HandleRichLink ...
+//
+// The pages without the status code attribute are ignored.
+func (handler PageStatusHandler) HandleRichLink(
+	ctx context.Context,
+	page models.RichLink,
+) {
+	statusCode, ok := page.Attributes.Int(models.StatusCodeAttribute)
+	if !ok {
+		return
+	}
+
+	handler.StatusRegister.RecordStatus(LinkStatus{
+		Link:       page.Link,
+		StatusCode: statusCode,
+	})
+}
+
+// HandleError ...
+//
+// Only the fetching and the status errors are recorded, since the other ones
+// either don't relate to the page itself or don't mean that it's broken.
+func (handler PageStatusHandler) HandleError(err models.CrawlingError) {
+	if err.Kind != models.FetchError && err.Kind != models.StatusError {
+		return
+	}
+
+	status := LinkStatus{Link: err.Link.Link}
+	if statusCodeErr, ok :=
+		errors.Cause(err.Err).(extractors.StatusCodeError); ok {
+		status.StatusCode = statusCodeErr.StatusCode
+	} else {
+		status.Err = err.Err
+	}
+
+	handler.StatusRegister.RecordStatus(status)
+}
@@ -0,0 +1,133 @@
+package brokenlinks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/extractors"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestPageStatusHandler_HandleRichLink(test *testing.T) {
+	for _, data := range []struct {
+		name             string
+		page             models.RichLink
+		wantLinkStatuses []LinkStatus
+	}{
+		{
+			name: "with a status code",
+			page: models.RichLink{
+				SourceLink: "http://example.com/",
+				Link:       "http://example.com/test",
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, http.StatusNotFound),
+			},
+			wantLinkStatuses: []LinkStatus{
+				{
+					Link:       "http://example.com/test",
+					StatusCode: http.StatusNotFound,
+				},
+			},
+		},
+		{
+			name: "without a status code",
+			page: models.RichLink{
+				SourceLink: "http://example.com/",
+				Link:       "http://example.com/test",
+			},
+			wantLinkStatuses: nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := NewStatusRegister()
+			handler := PageStatusHandler{
+				StatusRegister: register,
+			}
+			handler.HandleRichLink(context.Background(), data.page)
+
+			assert.Equal(
+				test,
+				data.wantLinkStatuses,
+				register.Report().LinkStatuses,
+			)
+		})
+	}
+}
+
+func TestPageStatusHandler_HandleError(test *testing.T) {
+	for _, data := range []struct {
+		name             string
+		err              models.CrawlingError
+		wantLinkStatuses []LinkStatus
+	}{
+		{
+			name: "with a status error",
+			err: models.CrawlingError{
+				Kind: models.StatusError,
+				Link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+				ThreadID: 23,
+				Err: errors.Wrap(
+					extractors.StatusCodeError{StatusCode: http.StatusNotFound},
+					"unable to accept the response",
+				),
+			},
+			wantLinkStatuses: []LinkStatus{
+				{
+					Link:       "http://example.com/test",
+					StatusCode: http.StatusNotFound,
+				},
+			},
+		},
+		{
+			name: "with a fetch error",
+			err: models.CrawlingError{
+				Kind: models.FetchError,
+				Link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+				ThreadID: 23,
+				Err:      iotest.ErrTimeout,
+			},
+			wantLinkStatuses: []LinkStatus{
+				{
+					Link: "http://example.com/test",
+					Err:  iotest.ErrTimeout,
+				},
+			},
+		},
+		{
+			name: "with an other error",
+			err: models.CrawlingError{
+				Kind: models.SitemapError,
+				Link: models.SourcedLink{
+					Link: "http://example.com/test",
+				},
+				ThreadID: 23,
+				Err:      iotest.ErrTimeout,
+			},
+			wantLinkStatuses: nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := NewStatusRegister()
+			handler := PageStatusHandler{
+				StatusRegister: register,
+			}
+			handler.HandleError(data.err)
+
+			assert.Equal(
+				test,
+				data.wantLinkStatuses,
+				register.Report().LinkStatuses,
+			)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package brokenlinks
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// StatusHandler ...
+//
+// It should be used as the handler of the extracted links. It loads
+// the status of each unique external link once (without crawling of it)
+// and records it to the register along with all the source links of the link.
+// The statuses of the internal links are recorded from the crawling itself
+// (see the PageStatusHandler type), so they aren't requested twice.
+type StatusHandler struct {
+	StatusLoader StatusLoader
+	// optional; the links passing it are considered internal, i.e., crawled;
+	// by default, all links are considered internal
+	InternalLinkChecker models.LinkChecker
+	StatusRegister      *StatusRegister
+}
+
+// HandleLink ...
+func (handler StatusHandler) HandleLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) {
+	if !handler.StatusRegister.RegisterSourceLink(link) {
+		return
+	}
+
+	if handler.InternalLinkChecker == nil ||
+		handler.InternalLinkChecker.CheckLink(ctx, link) {
+		return
+	}
+
+	status := handler.StatusLoader.LoadStatus(ctx, link.Link)
+	handler.StatusRegister.RecordStatus(status)
+}
@@ -0,0 +1,143 @@
+package brokenlinks
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestStatusHandler_HandleLink(test *testing.T) {
+	type fields struct {
+		HTTPClient          HTTPClient
+		InternalLinkChecker models.LinkChecker
+	}
+	type args struct {
+		ctx  context.Context
+		link models.SourcedLink
+	}
+
+	for _, data := range []struct {
+		name             string
+		fields           fields
+		args             args
+		wantLinkStatuses []LinkStatus
+	}{
+		{
+			name: "with an internal link",
+			fields: fields{
+				HTTPClient: new(MockHTTPClient),
+				InternalLinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkChecker)
+					checker.
+						On("CheckLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/a",
+						}).
+						Return(true).
+						Once()
+
+					return checker
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/a",
+				},
+			},
+			wantLinkStatuses: nil,
+		},
+		{
+			name: "without an internal link checker",
+			fields: fields{
+				HTTPClient:          new(MockHTTPClient),
+				InternalLinkChecker: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.org/a",
+				},
+			},
+			wantLinkStatuses: nil,
+		},
+		{
+			name: "with an external link",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.org/a")).
+						Return(
+							newRedirectedResponse(
+								http.StatusNotFound,
+								"http://example.org/a",
+								"http://example.org/b",
+							),
+							nil,
+						).
+						Once()
+
+					return httpClient
+				}(),
+				InternalLinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkChecker)
+					checker.
+						On("CheckLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.org/a",
+						}).
+						Return(false).
+						Once()
+
+					return checker
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.org/a",
+				},
+			},
+			wantLinkStatuses: []LinkStatus{
+				{
+					Link:          "http://example.org/a",
+					StatusCode:    http.StatusNotFound,
+					RedirectChain: []string{"http://example.org/b"},
+				},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := NewStatusRegister()
+			handler := StatusHandler{
+				StatusLoader: StatusLoader{
+					HTTPClient: data.fields.HTTPClient,
+				},
+				InternalLinkChecker: data.fields.InternalLinkChecker,
+				StatusRegister:      register,
+			}
+			// the repeated handling of the same link should be skipped
+			handler.HandleLink(data.args.ctx, data.args.link)
+			handler.HandleLink(data.args.ctx, data.args.link)
+
+			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
+			if data.fields.InternalLinkChecker != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.InternalLinkChecker)
+			}
+
+			gotLinkStatuses := register.Report().LinkStatuses
+			for index := range gotLinkStatuses {
+				gotLinkStatuses[index].Latency = 0
+			}
+			assert.Equal(test, data.wantLinkStatuses, gotLinkStatuses)
+		})
+	}
+}
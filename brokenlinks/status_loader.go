@@ -0,0 +1,107 @@
+package brokenlinks
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	httputils "github.com/thewizardplusplus/go-http-utils"
+)
+
+// LinkStatus ...
+type LinkStatus struct {
+	Link string
+	// status code of the last response; it's zero on an error
+	StatusCode int
+	// links to which the link has been redirected, in the order of redirects
+	RedirectChain []string
+	// total duration of all requests, including the redirects
+	Latency time.Duration
+	Err     error
+}
+
+// IsBroken ...
+//
+// The link is broken if it has not been loaded or its final status code
+// is 4xx or 5xx.
+func (status LinkStatus) IsBroken() bool {
+	return status.Err != nil || status.StatusCode >= http.StatusBadRequest
+}
+
+// StatusLoader ...
+//
+// It uses the HEAD requests and falls back to the GET ones if the HEAD method
+// isn't supported by a server. The redirects are followed by the HTTP client
+// as usual (e.g., the http.Client type limits their count), and the redirect
+// chain is restored from the final response.
+type StatusLoader struct {
+	HTTPClient httputils.HTTPClient
+}
+
+// LoadStatus ...
+func (loader StatusLoader) LoadStatus(
+	ctx context.Context,
+	link string,
+) (status LinkStatus) {
+	status = LinkStatus{Link: link}
+	startTime := time.Now()
+	defer func() { status.Latency = time.Since(startTime) }()
+
+	response, err := loader.sendRequest(ctx, http.MethodHead, link)
+	if err != nil {
+		status.Err = errors.Wrap(err, "unable to send the HEAD request")
+		return status
+	}
+
+	if response.StatusCode == http.StatusMethodNotAllowed ||
+		response.StatusCode == http.StatusNotImplemented {
+		response, err = loader.sendRequest(ctx, http.MethodGet, link)
+		if err != nil {
+			status.Err = errors.Wrap(err, "unable to send the GET request")
+			return status
+		}
+	}
+
+	status.StatusCode = response.StatusCode
+	status.RedirectChain = makeRedirectChain(response)
+	return status
+}
+
+func (loader StatusLoader) sendRequest(
+	ctx context.Context,
+	method string,
+	link string,
+) (*http.Response, error) {
+	request, err := http.NewRequest(method, link, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the request")
+	}
+	request = request.WithContext(ctx)
+
+	response, err := loader.HTTPClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to send the request")
+	}
+	defer response.Body.Close() // nolint: errcheck
+
+	// the body is drained to allow reusing of the connection
+	io.Copy(ioutil.Discard, response.Body) // nolint: errcheck, gosec
+
+	return response, nil
+}
+
+// the http.Client type sets the redirect response that caused a request
+// to the http.Request.Response field, so the chain is restored backward
+// from the request of the final response
+func makeRedirectChain(response *http.Response) []string {
+	var redirectChain []string
+	for request := response.Request; request != nil && request.Response != nil; {
+		redirectChain = append([]string{request.URL.String()}, redirectChain...)
+		request = request.Response.Request
+	}
+
+	return redirectChain
+}
@@ -0,0 +1,243 @@
+package brokenlinks
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLinkStatus_IsBroken(test *testing.T) {
+	for _, data := range []struct {
+		name   string
+		status LinkStatus
+		want   assert.BoolAssertionFunc
+	}{
+		{
+			name:   "with a success status",
+			status: LinkStatus{StatusCode: http.StatusOK},
+			want:   assert.False,
+		},
+		{
+			name:   "with a redirect status",
+			status: LinkStatus{StatusCode: http.StatusMovedPermanently},
+			want:   assert.False,
+		},
+		{
+			name:   "with a client error status",
+			status: LinkStatus{StatusCode: http.StatusNotFound},
+			want:   assert.True,
+		},
+		{
+			name:   "with a server error status",
+			status: LinkStatus{StatusCode: http.StatusBadGateway},
+			want:   assert.True,
+		},
+		{
+			name:   "with an error",
+			status: LinkStatus{Err: iotest.ErrTimeout},
+			want:   assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			data.want(test, data.status.IsBroken())
+		})
+	}
+}
+
+func TestStatusLoader_LoadStatus(test *testing.T) {
+	type fields struct {
+		HTTPClient HTTPClient
+	}
+	type args struct {
+		ctx  context.Context
+		link string
+	}
+
+	for _, data := range []struct {
+		name       string
+		fields     fields
+		args       args
+		wantStatus LinkStatus
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without redirects",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.com/")).
+						Return(newResponse(http.StatusOK), nil)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantStatus: LinkStatus{
+				Link:       "http://example.com/",
+				StatusCode: http.StatusOK,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the fallback to the GET request",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.com/")).
+						Return(newResponse(http.StatusMethodNotAllowed), nil)
+					httpClient.
+						On("Do", matchRequest(http.MethodGet, "http://example.com/")).
+						Return(newResponse(http.StatusNotFound), nil)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantStatus: LinkStatus{
+				Link:       "http://example.com/",
+				StatusCode: http.StatusNotFound,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with redirects",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					response := newRedirectedResponse(
+						http.StatusOK,
+						"http://example.com/",
+						"https://example.com/",
+						"https://example.com/test",
+					)
+
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.com/")).
+						Return(response, nil)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantStatus: LinkStatus{
+				Link:       "http://example.com/",
+				StatusCode: http.StatusOK,
+				RedirectChain: []string{
+					"https://example.com/",
+					"https://example.com/test",
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the HEAD request sending",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.com/")).
+						Return(nil, iotest.ErrTimeout)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantStatus: LinkStatus{
+				Link: "http://example.com/",
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "error with the GET request sending",
+			fields: fields{
+				HTTPClient: func() HTTPClient {
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", matchRequest(http.MethodHead, "http://example.com/")).
+						Return(newResponse(http.StatusNotImplemented), nil)
+					httpClient.
+						On("Do", matchRequest(http.MethodGet, "http://example.com/")).
+						Return(nil, iotest.ErrTimeout)
+
+					return httpClient
+				}(),
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantStatus: LinkStatus{
+				Link: "http://example.com/",
+			},
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			loader := StatusLoader{
+				HTTPClient: data.fields.HTTPClient,
+			}
+			gotStatus := loader.LoadStatus(data.args.ctx, data.args.link)
+
+			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
+			assert.True(test, gotStatus.Latency > 0)
+			data.wantErr(test, gotStatus.Err)
+
+			gotStatus.Latency, gotStatus.Err = 0, nil
+			assert.Equal(test, data.wantStatus, gotStatus)
+		})
+	}
+}
+
+func matchRequest(method string, link string) interface{} {
+	return mock.MatchedBy(func(request *http.Request) bool {
+		return request.Method == method && request.URL.String() == link
+	})
+}
+
+func newResponse(statusCode int) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+}
+
+// it sets the requests of all the links as the http.Client type does
+// on following of redirects; the first link is the requested one
+func newRedirectedResponse(statusCode int, links ...string) *http.Response {
+	var request *http.Request
+	for _, link := range links {
+		nextRequest := httptest.NewRequest(http.MethodHead, link, nil)
+		if request != nil {
+			nextRequest.Response = &http.Response{Request: request}
+		}
+
+		request = nextRequest
+	}
+
+	response := newResponse(statusCode)
+	response.Request = request
+
+	return response
+}
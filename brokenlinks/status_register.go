@@ -0,0 +1,93 @@
+package brokenlinks
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// Report ...
+type Report struct {
+	// statuses of all checked links sorted by the links
+	LinkStatuses []LinkStatus
+	// statuses of the broken links (sorted by the links)
+	// grouped by the source links that reference them
+	BrokenLinksBySource map[string][]LinkStatus
+}
+
+// StatusRegister ...
+//
+// It's safe for a concurrent usage.
+type StatusRegister struct {
+	locker       sync.Mutex
+	sourceLinks  map[string][]string
+	linkStatuses map[string]LinkStatus
+}
+
+// NewStatusRegister ...
+func NewStatusRegister() *StatusRegister {
+	return &StatusRegister{
+		sourceLinks:  make(map[string][]string),
+		linkStatuses: make(map[string]LinkStatus),
+	}
+}
+
+// RegisterSourceLink ...
+//
+// It returns true if the link hasn't been registered before,
+// i.e., its status should be loaded.
+func (register *StatusRegister) RegisterSourceLink(
+	link models.SourcedLink,
+) (isNew bool) {
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	sourceLinks, ok := register.sourceLinks[link.Link]
+	for _, sourceLink := range sourceLinks {
+		if sourceLink == link.SourceLink {
+			return false
+		}
+	}
+
+	register.sourceLinks[link.Link] = append(sourceLinks, link.SourceLink)
+	return !ok
+}
+
+// RecordStatus ...
+func (register *StatusRegister) RecordStatus(status LinkStatus) {
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	register.linkStatuses[status.Link] = status
+}
+
+// Report ...
+func (register *StatusRegister) Report() Report {
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	var report Report
+	for _, status := range register.linkStatuses {
+		report.LinkStatuses = append(report.LinkStatuses, status)
+	}
+	sort.Slice(report.LinkStatuses, func(i int, j int) bool {
+		return report.LinkStatuses[i].Link < report.LinkStatuses[j].Link
+	})
+
+	for _, status := range report.LinkStatuses {
+		if !status.IsBroken() {
+			continue
+		}
+
+		if report.BrokenLinksBySource == nil {
+			report.BrokenLinksBySource = make(map[string][]LinkStatus)
+		}
+		for _, sourceLink := range register.sourceLinks[status.Link] {
+			report.BrokenLinksBySource[sourceLink] =
+				append(report.BrokenLinksBySource[sourceLink], status)
+		}
+	}
+
+	return report
+}
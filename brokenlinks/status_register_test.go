@@ -0,0 +1,107 @@
+package brokenlinks
+
+import (
+	"net/http"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestStatusRegister_RegisterSourceLink(test *testing.T) {
+	register := NewStatusRegister()
+	for _, data := range []struct {
+		link      models.SourcedLink
+		wantIsNew assert.BoolAssertionFunc
+	}{
+		{
+			link: models.SourcedLink{
+				SourceLink: "http://example.com/1",
+				Link:       "http://example.com/test",
+			},
+			wantIsNew: assert.True,
+		},
+		{
+			link: models.SourcedLink{
+				SourceLink: "http://example.com/2",
+				Link:       "http://example.com/test",
+			},
+			wantIsNew: assert.False,
+		},
+		{
+			link: models.SourcedLink{
+				SourceLink: "http://example.com/1",
+				Link:       "http://example.com/test",
+			},
+			wantIsNew: assert.False,
+		},
+	} {
+		data.wantIsNew(test, register.RegisterSourceLink(data.link))
+	}
+
+	wantSourceLinks := map[string][]string{
+		"http://example.com/test": {"http://example.com/1", "http://example.com/2"},
+	}
+	assert.Equal(test, wantSourceLinks, register.sourceLinks)
+}
+
+func TestStatusRegister_Report(test *testing.T) {
+	for _, data := range []struct {
+		name         string
+		sourcedLinks []models.SourcedLink
+		linkStatuses []LinkStatus
+		wantReport   Report
+	}{
+		{
+			name:         "without statuses",
+			sourcedLinks: nil,
+			linkStatuses: nil,
+			wantReport:   Report{},
+		},
+		{
+			name: "with statuses",
+			sourcedLinks: []models.SourcedLink{
+				{SourceLink: "http://example.com/1", Link: "http://example.com/a"},
+				{SourceLink: "http://example.com/1", Link: "http://example.com/b"},
+				{SourceLink: "http://example.com/1", Link: "http://example.com/c"},
+				{SourceLink: "http://example.com/2", Link: "http://example.com/b"},
+				{SourceLink: "http://example.com/2", Link: "http://example.com/c"},
+			},
+			linkStatuses: []LinkStatus{
+				{Link: "http://example.com/c", Err: iotest.ErrTimeout},
+				{Link: "http://example.com/b", StatusCode: http.StatusNotFound},
+				{Link: "http://example.com/a", StatusCode: http.StatusOK},
+			},
+			wantReport: Report{
+				LinkStatuses: []LinkStatus{
+					{Link: "http://example.com/a", StatusCode: http.StatusOK},
+					{Link: "http://example.com/b", StatusCode: http.StatusNotFound},
+					{Link: "http://example.com/c", Err: iotest.ErrTimeout},
+				},
+				BrokenLinksBySource: map[string][]LinkStatus{
+					"http://example.com/1": {
+						{Link: "http://example.com/b", StatusCode: http.StatusNotFound},
+						{Link: "http://example.com/c", Err: iotest.ErrTimeout},
+					},
+					"http://example.com/2": {
+						{Link: "http://example.com/b", StatusCode: http.StatusNotFound},
+						{Link: "http://example.com/c", Err: iotest.ErrTimeout},
+					},
+				},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := NewStatusRegister()
+			for _, sourcedLink := range data.sourcedLinks {
+				register.RegisterSourceLink(sourcedLink)
+			}
+			for _, linkStatus := range data.linkStatuses {
+				register.RecordStatus(linkStatus)
+			}
+
+			assert.Equal(test, data.wantReport, register.Report())
+		})
+	}
+}
@@ -3,6 +3,7 @@ package checkers
 import (
 	"context"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
@@ -23,3 +24,21 @@ func (checkers CheckerGroup) CheckLink(
 	// to prohibit using an empty group as a filter that passes everything
 	return len(checkers) != 0
 }
+
+// CheckRichLink ...
+//
+// The checkers that implement the models.LinkCheckerV2 interface receive
+// the link with its attributes.
+func (checkers CheckerGroup) CheckRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) bool {
+	for _, checker := range checkers {
+		if !adapters.LinkCheckerToV2(checker).CheckRichLink(ctx, link) {
+			return false
+		}
+	}
+
+	// to prohibit using an empty group as a filter that passes everything
+	return len(checkers) != 0
+}
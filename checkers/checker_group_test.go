@@ -104,3 +104,75 @@ func TestCheckerGroup_CheckLink(test *testing.T) {
 		})
 	}
 }
+
+func TestCheckerGroup_CheckRichLink(test *testing.T) {
+	type richChecker struct {
+		*MockLinkChecker
+		*MockLinkCheckerV2
+	}
+
+	link := models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Attributes: models.Attributes{}.
+			Set(models.ContentTypeAttribute, "text/html"),
+	}
+	makeCheckers := func(isCheckedByV2 bool) (
+		checker *MockLinkChecker,
+		checkerV2 *MockLinkCheckerV2,
+	) {
+		checker = new(MockLinkChecker)
+		checker.
+			On("CheckLink", context.Background(), link.SourcedLink()).
+			Return(true)
+
+		checkerV2 = new(MockLinkCheckerV2)
+		checkerV2.
+			On("CheckRichLink", context.Background(), link).
+			Return(isCheckedByV2)
+
+		return checker, checkerV2
+	}
+
+	for _, data := range []struct {
+		name          string
+		isCheckedByV2 bool
+		want          assert.BoolAssertionFunc
+	}{
+		{
+			name:          "without failed checkings",
+			isCheckedByV2: true,
+			want:          assert.True,
+		},
+		{
+			name:          "with a failed checking",
+			isCheckedByV2: false,
+			want:          assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			checker, checkerV2 := makeCheckers(data.isCheckedByV2)
+			checkers := CheckerGroup{
+				checker,
+				richChecker{
+					MockLinkChecker:   new(MockLinkChecker),
+					MockLinkCheckerV2: checkerV2,
+				},
+			}
+			got := checkers.CheckRichLink(context.Background(), link)
+
+			mock.AssertExpectationsForObjects(test, checker, checkerV2)
+			data.want(test, got)
+		})
+	}
+}
+
+func TestCheckerGroup_CheckRichLink_empty(test *testing.T) {
+	got := CheckerGroup(nil).
+		CheckRichLink(context.Background(), models.RichLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+		})
+
+	assert.False(test, got)
+}
@@ -0,0 +1,41 @@
+package checkers
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/adapters"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/stats"
+)
+
+// CountingChecker ...
+type CountingChecker struct {
+	Name           string
+	LinkChecker    models.LinkChecker
+	StatsCollector *stats.Collector
+}
+
+// CheckLink ...
+func (checker CountingChecker) CheckLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) bool {
+	return checker.countResult(checker.LinkChecker.CheckLink(ctx, link))
+}
+
+// CheckRichLink ...
+func (checker CountingChecker) CheckRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) bool {
+	linkChecker := adapters.LinkCheckerToV2(checker.LinkChecker)
+	return checker.countResult(linkChecker.CheckRichLink(ctx, link))
+}
+
+func (checker CountingChecker) countResult(isChecked bool) bool {
+	if !isChecked {
+		checker.StatsCollector.AddRejectedLinkByChecker(checker.Name)
+	}
+
+	return isChecked
+}
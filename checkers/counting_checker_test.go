@@ -0,0 +1,157 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/stats"
+)
+
+func TestCountingChecker_CheckLink(test *testing.T) {
+	type fields struct {
+		Name        string
+		LinkChecker models.LinkChecker
+	}
+	type args struct {
+		ctx  context.Context
+		link models.SourcedLink
+	}
+
+	for _, data := range []struct {
+		name       string
+		fields     fields
+		args       args
+		want       assert.BoolAssertionFunc
+		wantReport stats.Report
+	}{
+		{
+			name: "with a passed link",
+			fields: fields{
+				Name: "checker",
+				LinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkChecker)
+					checker.
+						On("CheckLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+						}).
+						Return(true)
+
+					return checker
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+			},
+			want:       assert.True,
+			wantReport: stats.Report{},
+		},
+		{
+			name: "with a rejected link",
+			fields: fields{
+				Name: "checker",
+				LinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkChecker)
+					checker.
+						On("CheckLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+						}).
+						Return(false)
+
+					return checker
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+			},
+			want: assert.False,
+			wantReport: stats.Report{
+				RejectedLinkCountsByChecker: map[string]int{"checker": 1},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			statsCollector := stats.NewCollector()
+			checker := CountingChecker{
+				Name:           data.fields.Name,
+				LinkChecker:    data.fields.LinkChecker,
+				StatsCollector: statsCollector,
+			}
+			got := checker.CheckLink(data.args.ctx, data.args.link)
+
+			mock.AssertExpectationsForObjects(test, data.fields.LinkChecker)
+			data.want(test, got)
+			assert.Equal(test, data.wantReport, statsCollector.Report())
+		})
+	}
+}
+
+func TestCountingChecker_CheckRichLink(test *testing.T) {
+	type richChecker struct {
+		*MockLinkChecker
+		*MockLinkCheckerV2
+	}
+
+	for _, data := range []struct {
+		name       string
+		isChecked  bool
+		want       assert.BoolAssertionFunc
+		wantReport stats.Report
+	}{
+		{
+			name:       "with a passed link",
+			isChecked:  true,
+			want:       assert.True,
+			wantReport: stats.Report{},
+		},
+		{
+			name:      "with a rejected link",
+			isChecked: false,
+			want:      assert.False,
+			wantReport: stats.Report{
+				RejectedLinkCountsByChecker: map[string]int{"checker": 1},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			link := models.RichLink{
+				SourceLink: "http://example.com/",
+				Link:       "http://example.com/test",
+				Attributes: models.Attributes{}.
+					Set(models.ContentTypeAttribute, "text/html"),
+			}
+
+			linkChecker := new(MockLinkCheckerV2)
+			linkChecker.
+				On("CheckRichLink", context.Background(), link).
+				Return(data.isChecked)
+
+			statsCollector := stats.NewCollector()
+			checker := CountingChecker{
+				Name: "checker",
+				LinkChecker: richChecker{
+					MockLinkChecker:   new(MockLinkChecker),
+					MockLinkCheckerV2: linkChecker,
+				},
+				StatsCollector: statsCollector,
+			}
+			got := checker.CheckRichLink(context.Background(), link)
+
+			mock.AssertExpectationsForObjects(test, linkChecker)
+			data.want(test, got)
+			assert.Equal(test, data.wantReport, statsCollector.Report())
+		})
+	}
+}
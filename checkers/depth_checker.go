@@ -0,0 +1,25 @@
+package checkers
+
+import (
+	"context"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// DepthChecker ...
+//
+// The links that exceed the maximal depth are still passed to a link handler,
+// but they aren't extracted. The checker should be placed before
+// the DuplicateChecker in a checker group; otherwise, a link that was first
+// found too deep will be rejected as a duplicate at a smaller depth.
+type DepthChecker struct {
+	MaximalDepth int
+}
+
+// CheckLink ...
+func (checker DepthChecker) CheckLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) bool {
+	return link.Depth <= checker.MaximalDepth
+}
@@ -0,0 +1,81 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestDepthChecker_CheckLink(test *testing.T) {
+	type fields struct {
+		MaximalDepth int
+	}
+	type args struct {
+		ctx  context.Context
+		link models.SourcedLink
+	}
+
+	for _, data := range []struct {
+		name   string
+		fields fields
+		args   args
+		want   assert.BoolAssertionFunc
+	}{
+		{
+			name: "with a depth less than the maximal one",
+			fields: fields{
+				MaximalDepth: 2,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Depth:      1,
+				},
+			},
+			want: assert.True,
+		},
+		{
+			name: "with a depth equal to the maximal one",
+			fields: fields{
+				MaximalDepth: 2,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Depth:      2,
+				},
+			},
+			want: assert.True,
+		},
+		{
+			name: "with a depth greater than the maximal one",
+			fields: fields{
+				MaximalDepth: 2,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Depth:      3,
+				},
+			},
+			want: assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			checker := DepthChecker{
+				MaximalDepth: data.fields.MaximalDepth,
+			}
+			got := checker.CheckLink(data.args.ctx, data.args.link)
+
+			data.want(test, got)
+		})
+	}
+}
@@ -5,12 +5,11 @@ import (
 
 	"github.com/go-log/log"
 	"github.com/thewizardplusplus/go-crawler/models"
-	"github.com/thewizardplusplus/go-crawler/registers"
 )
 
 // DuplicateChecker ...
 type DuplicateChecker struct {
-	LinkRegister registers.LinkRegister
+	LinkRegister models.LinkRegister
 	Logger       log.Logger
 }
 
@@ -0,0 +1,45 @@
+package checkers
+
+import (
+	"context"
+	"time"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LastModificationChecker ...
+//
+// It uses the sitemap.xml metadata of the links, so they should be extracted
+// by the extractors.SitemapExtractor.ExtractRichLinks() method.
+// The links rejected by the checker are still passed to a link handler,
+// but they aren't extracted.
+type LastModificationChecker struct {
+	MinimalLastModification time.Time
+	// reject the links without the known modification time
+	RejectUnknown bool
+}
+
+// CheckLink ...
+//
+// The links without the attributes have no metadata, so their modification
+// time is unknown.
+func (checker LastModificationChecker) CheckLink(
+	ctx context.Context,
+	link models.SourcedLink,
+) bool {
+	return checker.CheckRichLink(ctx, models.NewRichLink(link))
+}
+
+// CheckRichLink ...
+func (checker LastModificationChecker) CheckRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) bool {
+	value, _ := link.Attributes.Value(models.SitemapMetadataAttribute)
+	metadata, _ := value.(models.SitemapMetadata)
+	if metadata.LastModification.IsZero() {
+		return !checker.RejectUnknown
+	}
+
+	return !metadata.LastModification.Before(checker.MinimalLastModification)
+}
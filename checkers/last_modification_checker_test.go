@@ -0,0 +1,161 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestLastModificationChecker_CheckRichLink(test *testing.T) {
+	type fields struct {
+		MinimalLastModification time.Time
+		RejectUnknown           bool
+	}
+	type args struct {
+		ctx  context.Context
+		link models.RichLink
+	}
+
+	for _, data := range []struct {
+		name   string
+		fields fields
+		args   args
+		want   assert.BoolAssertionFunc
+	}{
+		{
+			name: "with a fresh link",
+			fields: fields{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Attributes: models.Attributes{}.Set(
+						models.SitemapMetadataAttribute,
+						models.SitemapMetadata{
+							LastModification: time.Date(2006, time.January, 3, 0, 0, 0, 0, time.UTC),
+						},
+					),
+				},
+			},
+			want: assert.True,
+		},
+		{
+			name: "with a link modified exactly at the cutoff",
+			fields: fields{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Attributes: models.Attributes{}.Set(
+						models.SitemapMetadataAttribute,
+						models.SitemapMetadata{
+							LastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+						},
+					),
+				},
+			},
+			want: assert.True,
+		},
+		{
+			name: "with a stale link",
+			fields: fields{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Attributes: models.Attributes{}.Set(
+						models.SitemapMetadataAttribute,
+						models.SitemapMetadata{
+							LastModification: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+						},
+					),
+				},
+			},
+			want: assert.False,
+		},
+		{
+			name: "with an unknown modification time and without rejecting",
+			fields: fields{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				RejectUnknown:           false,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+			},
+			want: assert.True,
+		},
+		{
+			name: "with an unknown modification time and with rejecting",
+			fields: fields{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				RejectUnknown:           true,
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+			},
+			want: assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			checker := LastModificationChecker{
+				MinimalLastModification: data.fields.MinimalLastModification,
+				RejectUnknown:           data.fields.RejectUnknown,
+			}
+			got := checker.CheckRichLink(data.args.ctx, data.args.link)
+
+			data.want(test, got)
+		})
+	}
+}
+
+func TestLastModificationChecker_CheckLink(test *testing.T) {
+	for _, data := range []struct {
+		name          string
+		rejectUnknown bool
+		want          assert.BoolAssertionFunc
+	}{
+		{
+			name:          "without rejecting",
+			rejectUnknown: false,
+			want:          assert.True,
+		},
+		{
+			name:          "with rejecting",
+			rejectUnknown: true,
+			want:          assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			checker := LastModificationChecker{
+				MinimalLastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				RejectUnknown:           data.rejectUnknown,
+			}
+			got := checker.CheckLink(context.Background(), models.SourcedLink{
+				SourceLink: "http://example.com/",
+				Link:       "http://example.com/test",
+			})
+
+			data.want(test, got)
+		})
+	}
+}
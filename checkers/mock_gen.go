@@ -16,6 +16,16 @@ type LinkChecker interface {
 	models.LinkChecker
 }
 
+//go:generate mockery --name=LinkCheckerV2 --inpackage --case=underscore --testonly
+
+// LinkCheckerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkCheckerV2 interface {
+	models.LinkCheckerV2
+}
+
 //go:generate mockery --name=Logger --inpackage --case=underscore --testonly
 
 // Logger ...
@@ -35,3 +45,13 @@ type Logger interface {
 type HTTPClient interface {
 	httputils.HTTPClient
 }
+
+//go:generate mockery --name=ErrorHandler --inpackage --case=underscore --testonly
+
+// ErrorHandler ...
+//
+// It's used only for mock generating.
+//
+type ErrorHandler interface {
+	models.ErrorHandler
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-log/log"
 	"github.com/thewizardplusplus/go-crawler/models"
 	"github.com/thewizardplusplus/go-crawler/registers"
+	robotstxtutils "github.com/thewizardplusplus/go-crawler/robots-txt-utils"
 )
 
 // RobotsTXTChecker ...
@@ -14,6 +15,7 @@ type RobotsTXTChecker struct {
 	UserAgent         string
 	RobotsTXTRegister registers.RobotsTXTRegister
 	Logger            log.Logger
+	ErrorHandler      models.ErrorHandler // optional
 }
 
 // CheckLink ...
@@ -27,21 +29,50 @@ func (checker RobotsTXTChecker) CheckLink(
 	if err != nil {
 		const logMessage = "%s: unable to parse link %q: %s"
 		checker.Logger.Logf(logMessage, logPrefix, link.Link, err)
+		checker.handleError(models.ParseError, link, err)
 
 		return false
 	}
 
-	robotsTXTData, err :=
-		checker.RobotsTXTRegister.RegisterRobotsTXT(ctx, link.Link)
+	result, err :=
+		checker.RobotsTXTRegister.RegisterRobotsTXTResult(ctx, link.Link)
 	if err != nil {
 		const logMessage = "%s: " +
 			"unable to register the robots.txt link for link %q: " +
 			"%s"
 		checker.Logger.Logf(logMessage, logPrefix, link.Link, err)
+		checker.handleError(models.RobotsTXTError, link, err)
 
 		return false
 	}
 
-	group := robotsTXTData.FindGroup(checker.UserAgent)
-	return group.Test(parsedLink.Path)
+	// the allowing of all links is the regular case for a missing robots.txt file
+	if result.Failure != nil && result.Outcome != registers.AllowAllOutcome {
+		const logMessage = "%s: robots.txt file for link %q is unavailable " +
+			"(the %q outcome is applied): %s"
+		checker.Logger.Logf(
+			logMessage,
+			logPrefix,
+			link.Link,
+			result.Outcome,
+			result.Failure,
+		)
+		checker.handleError(models.RobotsTXTError, link, *result.Failure)
+	}
+
+	matchingPath := robotstxtutils.MakeMatchingPath(parsedLink)
+	return result.RuleSet.TestPath(checker.UserAgent, matchingPath)
+}
+
+func (checker RobotsTXTChecker) handleError(
+	kind models.ErrorKind,
+	link models.SourcedLink,
+	err error,
+) {
+	models.HandleError(checker.ErrorHandler, models.CrawlingError{
+		Kind:     kind,
+		Link:     link,
+		ThreadID: models.UnknownThreadID,
+		Err:      err,
+	})
 }
@@ -22,6 +22,7 @@ func TestRobotsTXTChecker_CheckLink(test *testing.T) {
 		UserAgent         string
 		RobotsTXTRegister registers.RobotsTXTRegister
 		Logger            log.Logger
+		ErrorHandler      models.ErrorHandler
 	}
 	type args struct {
 		ctx  context.Context
@@ -193,6 +194,144 @@ func TestRobotsTXTChecker_CheckLink(test *testing.T) {
 			},
 			wantOk: assert.False,
 		},
+		{
+			name: "success with a link disallowed by a query",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(`
+							User-agent: *
+							Disallow: /search?q=
+							Disallow: /*.php$
+						`)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					register := registers.NewRobotsTXTRegister(httpClient)
+					return register
+				}(),
+				Logger: new(MockLogger),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/search?q=test",
+				},
+			},
+			wantOk: assert.False,
+		},
+		{
+			name: "success with an unavailable robots.txt file",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					register := registers.NewRobotsTXTRegister(httpClient)
+					return register
+				}(),
+				Logger: new(MockLogger),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/post/23",
+				},
+			},
+			wantOk: assert.True,
+		},
+		{
+			name: "success with an unreachable robots.txt file",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					register := registers.NewRobotsTXTRegister(
+						httpClient,
+						registers.WithRobotsTXTFallbackPolicy(
+							registers.RobotsTXTFallbackPolicy{
+								ServerErrorOutcome: registers.DisallowAllOutcome,
+							},
+						),
+					)
+					return register
+				}(),
+				Logger: func() Logger {
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"%s: robots.txt file for link %q is unavailable "+
+								"(the %q outcome is applied): %s",
+							"robots.txt checking",
+							"http://example.com/post/23",
+							registers.DisallowAllOutcome,
+							mock.AnythingOfType("*registers.RobotsTXTFailure"),
+						).
+						Return()
+
+					return logger
+				}(),
+				ErrorHandler: func() ErrorHandler {
+					errMatcher := mock.MatchedBy(func(err models.CrawlingError) bool {
+						wantLink := models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/post/23",
+						}
+						failure, ok := err.Err.(registers.RobotsTXTFailure)
+						return err.Kind == models.RobotsTXTError &&
+							err.Link == wantLink &&
+							err.ThreadID == models.UnknownThreadID &&
+							ok &&
+							failure.Kind == registers.ServerErrorFailure
+					})
+
+					handler := new(MockErrorHandler)
+					handler.On("HandleError", errMatcher).Return()
+
+					return handler
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/post/23",
+				},
+			},
+			wantOk: assert.False,
+		},
 		{
 			name: "error with link registering",
 			fields: fields{
@@ -212,6 +351,7 @@ func TestRobotsTXTChecker_CheckLink(test *testing.T) {
 					errMatcher := mock.MatchedBy(func(err error) bool {
 						wantErrMessage := "unable to load the robots.txt data: " +
 							"unable to send the request: " +
+							"robots.txt network error: " +
 							"timeout"
 						return err.Error() == wantErrMessage
 					})
@@ -239,16 +379,80 @@ func TestRobotsTXTChecker_CheckLink(test *testing.T) {
 			},
 			wantOk: assert.False,
 		},
+		{
+			name: "error with link registering and an error handler",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(nil, iotest.ErrTimeout)
+
+					register := registers.NewRobotsTXTRegister(httpClient)
+					return register
+				}(),
+				Logger: func() Logger {
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"%s: unable to register the robots.txt link for link %q: %s",
+							"robots.txt checking",
+							"http://example.com/post/23",
+							mock.AnythingOfType("*errors.withStack"),
+						).
+						Return()
+
+					return logger
+				}(),
+				ErrorHandler: func() ErrorHandler {
+					errMatcher := mock.MatchedBy(func(err models.CrawlingError) bool {
+						wantLink := models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/post/23",
+						}
+						wantErrMessage := "unable to load the robots.txt data: " +
+							"unable to send the request: " +
+							"robots.txt network error: " +
+							"timeout"
+						return err.Kind == models.RobotsTXTError &&
+							err.Link == wantLink &&
+							err.ThreadID == models.UnknownThreadID &&
+							err.Err.Error() == wantErrMessage
+					})
+
+					handler := new(MockErrorHandler)
+					handler.On("HandleError", errMatcher).Return()
+
+					return handler
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.SourcedLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/post/23",
+				},
+			},
+			wantOk: assert.False,
+		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			checker := RobotsTXTChecker{
 				UserAgent:         data.fields.UserAgent,
 				RobotsTXTRegister: data.fields.RobotsTXTRegister,
 				Logger:            data.fields.Logger,
+				ErrorHandler:      data.fields.ErrorHandler,
 			}
 			got := checker.CheckLink(data.args.ctx, data.args.link)
 
 			mock.AssertExpectationsForObjects(test, data.fields.Logger)
+			if data.fields.ErrorHandler != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.ErrorHandler)
+			}
 			data.wantOk(test, got)
 		})
 	}
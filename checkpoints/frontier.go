@@ -0,0 +1,94 @@
+package checkpoints
+
+import (
+	"sync"
+
+	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// Frontier ...
+//
+// It wraps an inner frontier and records the pushed and processed links
+// to the journal.
+type Frontier struct {
+	journal      *Journal
+	linkFrontier models.LinkFrontier
+	logger       log.Logger
+
+	// the pending links restored from the journal are already recorded there,
+	// so their repeated pushing shouldn't be recorded
+	restoredLinkLocker sync.Mutex
+	restoredLinkCounts map[linkKey]int
+}
+
+// NewFrontier ...
+func NewFrontier(
+	journal *Journal,
+	linkFrontier models.LinkFrontier,
+	logger log.Logger,
+) *Frontier {
+	restoredLinkCounts := make(map[linkKey]int)
+	for _, link := range journal.PendingLinks() {
+		restoredLinkCounts[makeLinkKey(link)]++
+	}
+
+	return &Frontier{
+		journal:      journal,
+		linkFrontier: linkFrontier,
+		logger:       logger,
+
+		restoredLinkCounts: restoredLinkCounts,
+	}
+}
+
+// PushLink ...
+func (frontier *Frontier) PushLink(link models.RichLink) {
+	if !frontier.consumeRestoredLink(link) {
+		frontier.writeRecord(pushRecord, link)
+	}
+
+	frontier.linkFrontier.PushLink(link)
+}
+
+// PopLink ...
+func (frontier *Frontier) PopLink() (link models.RichLink, ok bool) {
+	return frontier.linkFrontier.PopLink()
+}
+
+// AcknowledgeLink ...
+func (frontier *Frontier) AcknowledgeLink(link models.RichLink) {
+	frontier.writeRecord(ackRecord, link)
+}
+
+// Close ...
+func (frontier *Frontier) Close() {
+	frontier.linkFrontier.Close()
+
+	if err := frontier.journal.Flush(); err != nil {
+		frontier.logger.Logf("unable to flush the journal: %s", err)
+	}
+}
+
+func (frontier *Frontier) consumeRestoredLink(link models.RichLink) bool {
+	frontier.restoredLinkLocker.Lock()
+	defer frontier.restoredLinkLocker.Unlock()
+
+	key := makeLinkKey(link)
+	if frontier.restoredLinkCounts[key] == 0 {
+		return false
+	}
+
+	frontier.restoredLinkCounts[key]--
+	return true
+}
+
+func (frontier *Frontier) writeRecord(
+	kind recordKind,
+	link models.RichLink,
+) {
+	if err := frontier.journal.writeRecord(kind, link); err != nil {
+		const logMessage = "unable to write the %s record for link %q: %s"
+		frontier.logger.Logf(logMessage, kind, link.Link, err)
+	}
+}
@@ -0,0 +1,75 @@
+package checkpoints
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/go-crawler/frontiers"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestFrontier(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-crawler-test")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	filename := filepath.Join(directory, "journal")
+	content := `{"kind":"push","link":{"Link":"http://example.com/1"}}
+{"kind":"push","link":{"Link":"http://example.com/2"}}
+`
+	err = ioutil.WriteFile(filename, []byte(content), 0600)
+	require.NoError(test, err)
+
+	journal, err := OpenJournal(filename, 0)
+	require.NoError(test, err)
+
+	frontier := NewFrontier(journal, frontiers.NewBreadthFirstFrontier(), nil)
+	for _, link := range journal.PendingLinks() {
+		frontier.PushLink(link)
+	}
+	frontier.PushLink(models.RichLink{Link: "http://example.com/2"})
+	frontier.AcknowledgeLink(models.RichLink{Link: "http://example.com/1"})
+	frontier.Close()
+	require.NoError(test, journal.Close())
+
+	var gotLinks []models.RichLink
+	for {
+		link, ok := frontier.PopLink()
+		if !ok {
+			break
+		}
+
+		gotLinks = append(gotLinks, link)
+	}
+
+	wantLinks := []models.RichLink{
+		{Link: "http://example.com/1"},
+		{Link: "http://example.com/2"},
+		{Link: "http://example.com/2"},
+	}
+	assert.Equal(test, wantLinks, gotLinks)
+
+	gotContent, err := ioutil.ReadFile(filename)
+	require.NoError(test, err)
+
+	var wantContent []byte
+	for _, record := range []record{
+		{Kind: visitRecord, Link: models.RichLink{Link: "http://example.com/1"}},
+		{Kind: visitRecord, Link: models.RichLink{Link: "http://example.com/2"}},
+		{Kind: pushRecord, Link: models.RichLink{Link: "http://example.com/1"}},
+		{Kind: pushRecord, Link: models.RichLink{Link: "http://example.com/2"}},
+		{Kind: pushRecord, Link: models.RichLink{Link: "http://example.com/2"}},
+		{Kind: ackRecord, Link: models.RichLink{Link: "http://example.com/1"}},
+	} {
+		data, err := json.Marshal(record)
+		require.NoError(test, err)
+
+		wantContent = append(wantContent, append(data, '\n')...)
+	}
+	assert.Equal(test, string(wantContent), string(gotContent))
+}
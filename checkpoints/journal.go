@@ -0,0 +1,314 @@
+package checkpoints
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// the maximal size of a single record in the journal file
+const maximalRecordSize = 1024 * 1024
+
+type recordKind string
+
+const (
+	visitRecord recordKind = "visit"
+	pushRecord  recordKind = "push"
+	ackRecord   recordKind = "ack"
+)
+
+type record struct {
+	Kind recordKind      `json:"kind"`
+	Link models.RichLink `json:"link"`
+}
+
+// it identifies a link in the frontier; the whole models.RichLink structure
+// isn't used for that, because its attributes aren't comparable, and
+// their time.Time values differ after the JSON round-trip (in locations)
+type linkKey struct {
+	SourceLink string
+	Link       string
+	Depth      int
+}
+
+func makeLinkKey(link models.RichLink) linkKey {
+	return linkKey{
+		SourceLink: link.SourceLink,
+		Link:       link.Link,
+		Depth:      link.Depth,
+	}
+}
+
+// Journal ...
+//
+// It's an append-only log of the crawling state. On opening, the existing
+// journal file is replayed and compacted, so the state can be resumed.
+type Journal struct {
+	visitedLinks []string
+	pendingLinks []models.RichLink
+
+	locker sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+
+	stoppingNotifier chan struct{}
+	stoppedNotifier  chan struct{}
+}
+
+// OpenJournal ...
+//
+// The journal is flushed to the file every flushing interval;
+// a non-positive interval disables the periodic flushing.
+func OpenJournal(
+	filename string,
+	flushingInterval time.Duration,
+) (*Journal, error) {
+	records, err := readRecords(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the journal")
+	}
+
+	visitedLinks, pendingLinks := replayRecords(records)
+	if err := compactRecords(filename, visitedLinks, pendingLinks); err != nil {
+		return nil, errors.Wrap(err, "unable to compact the journal")
+	}
+
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to open the journal file")
+	}
+
+	journal := &Journal{
+		visitedLinks: visitedLinks,
+		pendingLinks: pendingLinks,
+
+		file:   file,
+		writer: bufio.NewWriter(file),
+
+		stoppingNotifier: make(chan struct{}),
+		stoppedNotifier:  make(chan struct{}),
+	}
+	go journal.flushPeriodically(flushingInterval)
+
+	return journal, nil
+}
+
+// VisitedLinks ...
+//
+// It returns the links that have been pushed to the frontier
+// before the journal opening.
+func (journal *Journal) VisitedLinks() []string {
+	return journal.visitedLinks
+}
+
+// PendingLinks ...
+//
+// It returns the links that have been pushed to the frontier,
+// but haven't been processed completely before the journal opening.
+func (journal *Journal) PendingLinks() []models.RichLink {
+	return journal.pendingLinks
+}
+
+// RestoreLinkRegister ...
+func (journal *Journal) RestoreLinkRegister(
+	linkRegister models.LinkRegister,
+) error {
+	for _, link := range journal.visitedLinks {
+		if _, err := linkRegister.RegisterLink(link); err != nil {
+			return errors.Wrapf(err, "unable to register link %q", link)
+		}
+	}
+
+	return nil
+}
+
+// Flush ...
+func (journal *Journal) Flush() error {
+	journal.locker.Lock()
+	defer journal.locker.Unlock()
+
+	if err := journal.writer.Flush(); err != nil {
+		return errors.Wrap(err, "unable to flush the journal")
+	}
+
+	return nil
+}
+
+// Close ...
+func (journal *Journal) Close() error {
+	close(journal.stoppingNotifier)
+	<-journal.stoppedNotifier
+
+	if err := journal.Flush(); err != nil {
+		journal.file.Close() // nolint: errcheck, gosec
+		return err
+	}
+
+	if err := journal.file.Close(); err != nil {
+		return errors.Wrap(err, "unable to close the journal file")
+	}
+
+	return nil
+}
+
+func (journal *Journal) writeRecord(
+	kind recordKind,
+	link models.RichLink,
+) error {
+	data, err := json.Marshal(record{Kind: kind, Link: link})
+	if err != nil {
+		return errors.Wrap(err, "unable to marshal the record")
+	}
+
+	journal.locker.Lock()
+	defer journal.locker.Unlock()
+
+	if _, err := journal.writer.Write(append(data, '\n')); err != nil {
+		return errors.Wrap(err, "unable to write the record")
+	}
+
+	return nil
+}
+
+func (journal *Journal) flushPeriodically(flushingInterval time.Duration) {
+	defer close(journal.stoppedNotifier)
+
+	if flushingInterval <= 0 {
+		<-journal.stoppingNotifier
+		return
+	}
+
+	ticker := time.NewTicker(flushingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// the error will be repeated on the next flushing or on the closing
+			journal.Flush() // nolint: errcheck, gosec
+		case <-journal.stoppingNotifier:
+			return
+		}
+	}
+}
+
+func readRecords(filename string) ([]record, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrap(err, "unable to open the journal file")
+	}
+	defer file.Close() // nolint: errcheck
+
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maximalRecordSize)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "unable to scan the journal file")
+	}
+
+	var records []record
+	for index, line := range lines {
+		var record record
+		if err := json.Unmarshal(line, &record); err != nil {
+			// the last record may be incomplete due to an abnormal termination
+			if index == len(lines)-1 {
+				break
+			}
+
+			return nil, errors.Wrapf(err, "unable to unmarshal record #%d", index)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func replayRecords(records []record) (
+	visitedLinks []string,
+	pendingLinks []models.RichLink,
+) {
+	visitedLinkSet := make(map[string]struct{})
+	var pushedLinks []models.RichLink
+	pendingLinkCounts := make(map[linkKey]int)
+	for _, record := range records {
+		if _, ok := visitedLinkSet[record.Link.Link]; !ok {
+			visitedLinkSet[record.Link.Link] = struct{}{}
+			visitedLinks = append(visitedLinks, record.Link.Link)
+		}
+
+		switch record.Kind {
+		case pushRecord:
+			pushedLinks = append(pushedLinks, record.Link)
+			pendingLinkCounts[makeLinkKey(record.Link)]++
+		case ackRecord:
+			pendingLinkCounts[makeLinkKey(record.Link)]--
+		}
+	}
+
+	for _, link := range pushedLinks {
+		key := makeLinkKey(link)
+		if pendingLinkCounts[key] > 0 {
+			pendingLinks = append(pendingLinks, link)
+			pendingLinkCounts[key]--
+		}
+	}
+
+	return visitedLinks, pendingLinks
+}
+
+func compactRecords(
+	filename string,
+	visitedLinks []string,
+	pendingLinks []models.RichLink,
+) error {
+	temporaryFilename := filename + ".tmp"
+	file, err := os.Create(temporaryFilename)
+	if err != nil {
+		return errors.Wrap(err, "unable to create the temporary journal file")
+	}
+	defer file.Close() // nolint: errcheck
+
+	var compactedRecords []record
+	for _, link := range visitedLinks {
+		visitedLink := models.RichLink{Link: link}
+		compactedRecords =
+			append(compactedRecords, record{Kind: visitRecord, Link: visitedLink})
+	}
+	for _, link := range pendingLinks {
+		compactedRecords =
+			append(compactedRecords, record{Kind: pushRecord, Link: link})
+	}
+
+	writer := bufio.NewWriter(file)
+	encoder := json.NewEncoder(writer)
+	for _, compactedRecord := range compactedRecords {
+		if err := encoder.Encode(compactedRecord); err != nil {
+			return errors.Wrap(err, "unable to write the record")
+		}
+	}
+
+	if err := writer.Flush(); err != nil {
+		return errors.Wrap(err, "unable to flush the temporary journal file")
+	}
+	if err := file.Sync(); err != nil {
+		return errors.Wrap(err, "unable to sync the temporary journal file")
+	}
+	if err := os.Rename(temporaryFilename, filename); err != nil {
+		return errors.Wrap(err, "unable to replace the journal file")
+	}
+
+	return nil
+}
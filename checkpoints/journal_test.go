@@ -0,0 +1,215 @@
+package checkpoints
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thewizardplusplus/go-crawler/frontiers"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestOpenJournal(test *testing.T) {
+	for _, data := range []struct {
+		name             string
+		content          string
+		wantVisitedLinks []string
+		wantPendingLinks []models.RichLink
+		wantErr          assert.ErrorAssertionFunc
+	}{
+		{
+			name:             "success without records",
+			content:          "",
+			wantVisitedLinks: nil,
+			wantPendingLinks: nil,
+			wantErr:          assert.NoError,
+		},
+		{
+			name: "success with records",
+			content: `{"kind":"visit","link":{"Link":"http://example.com/1"}}
+{"kind":"push","link":{"Link":"http://example.com/2","Depth":1}}
+{"kind":"push","link":{"Link":"http://example.com/3","Depth":1}}
+{"kind":"ack","link":{"Link":"http://example.com/2","Depth":1}}
+{"kind":"push","link":{"Link":"http://example.com/4","Depth":2}}
+`,
+			wantVisitedLinks: []string{
+				"http://example.com/1",
+				"http://example.com/2",
+				"http://example.com/3",
+				"http://example.com/4",
+			},
+			wantPendingLinks: []models.RichLink{
+				{Link: "http://example.com/3", Depth: 1},
+				{Link: "http://example.com/4", Depth: 2},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an incomplete last record",
+			content: `{"kind":"push","link":{"Link":"http://example.com/1"}}
+{"kind":"push","link":{"Link":"http://exa`,
+			wantVisitedLinks: []string{"http://example.com/1"},
+			wantPendingLinks: []models.RichLink{
+				{Link: "http://example.com/1"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with an incorrect record",
+			content: `{"kind":"push","link":{"Link":"http://exa
+{"kind":"push","link":{"Link":"http://example.com/1"}}
+`,
+			wantVisitedLinks: nil,
+			wantPendingLinks: nil,
+			wantErr:          assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			directory, err := ioutil.TempDir("", "go-crawler-test")
+			require.NoError(test, err)
+			defer os.RemoveAll(directory)
+
+			filename := filepath.Join(directory, "journal")
+			err = ioutil.WriteFile(filename, []byte(data.content), 0600)
+			require.NoError(test, err)
+
+			journal, err := OpenJournal(filename, 0)
+			if journal != nil {
+				assert.Equal(test, data.wantVisitedLinks, journal.VisitedLinks())
+				assert.Equal(test, data.wantPendingLinks, journal.PendingLinks())
+				assert.NoError(test, journal.Close())
+			}
+			data.wantErr(test, err)
+		})
+	}
+}
+
+func TestJournal_resuming(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-crawler-test")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	filename := filepath.Join(directory, "journal")
+	journal, err := OpenJournal(filename, 0)
+	require.NoError(test, err)
+
+	frontier := NewFrontier(journal, frontiers.NewBreadthFirstFrontier(), nil)
+	frontier.PushLink(models.RichLink{Link: "http://example.com/1"})
+	frontier.AcknowledgeLink(models.RichLink{Link: "http://example.com/1"})
+	frontier.PushLink(models.RichLink{
+		SourceLink: "http://example.com/1",
+		Link:       "http://example.com/2",
+		Depth:      1,
+	})
+	require.NoError(test, journal.Close())
+
+	journal, err = OpenJournal(filename, 0)
+	require.NoError(test, err)
+	defer journal.Close() // nolint: errcheck
+
+	wantPendingLinks := []models.RichLink{
+		{
+			SourceLink: "http://example.com/1",
+			Link:       "http://example.com/2",
+			Depth:      1,
+		},
+	}
+	assert.Equal(test, wantPendingLinks, journal.PendingLinks())
+
+	linkRegister := registers.NewLinkRegister(urlutils.DoNotSanitizeLink)
+	require.NoError(test, journal.RestoreLinkRegister(linkRegister))
+
+	for _, link := range []string{"http://example.com/1", "http://example.com/2"} {
+		wasRegistered, err := linkRegister.RegisterLink(link)
+		assert.False(test, wasRegistered)
+		assert.NoError(test, err)
+	}
+}
+
+func TestJournal_resuming_withTimeOffset(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-crawler-test")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	// the time.Time values with the same non-UTC offset aren't comparable
+	// after the JSON round-trip, because their locations differ
+	location := time.FixedZone("", 5*60*60+30*60)
+	lastModification := time.Date(2006, time.January, 2, 15, 4, 5, 0, location)
+	link := models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/1",
+		Depth:      1,
+		Attributes: models.Attributes{}.Set(
+			models.SitemapMetadataAttribute,
+			models.SitemapMetadata{LastModification: lastModification},
+		),
+	}
+
+	filename := filepath.Join(directory, "journal")
+	journal, err := OpenJournal(filename, 0)
+	require.NoError(test, err)
+
+	frontier := NewFrontier(journal, frontiers.NewBreadthFirstFrontier(), nil)
+	frontier.PushLink(link)
+	frontier.PushLink(models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/2",
+		Depth:      1,
+	})
+	frontier.AcknowledgeLink(link)
+	require.NoError(test, journal.Close())
+
+	journal, err = OpenJournal(filename, 0)
+	require.NoError(test, err)
+	defer journal.Close() // nolint: errcheck
+
+	wantPendingLinks := []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/2",
+			Depth:      1,
+		},
+	}
+	assert.Equal(test, wantPendingLinks, journal.PendingLinks())
+}
+
+func TestJournal_resuming_withAttributes(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-crawler-test")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	lastModification := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	link := models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/1",
+		Depth:      1,
+		Attributes: models.Attributes{}.
+			Set(models.StatusCodeAttribute, http.StatusOK).
+			Set(models.ContentTypeAttribute, "text/html").
+			Set(models.SitemapMetadataAttribute, models.SitemapMetadata{
+				LastModification: lastModification,
+				Priority:         0.5,
+			}),
+	}
+
+	filename := filepath.Join(directory, "journal")
+	journal, err := OpenJournal(filename, 0)
+	require.NoError(test, err)
+
+	frontier := NewFrontier(journal, frontiers.NewBreadthFirstFrontier(), nil)
+	frontier.PushLink(link)
+	require.NoError(test, journal.Close())
+
+	journal, err = OpenJournal(filename, 0)
+	require.NoError(test, err)
+	defer journal.Close() // nolint: errcheck
+
+	assert.Equal(test, []models.RichLink{link}, journal.PendingLinks())
+}
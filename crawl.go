@@ -3,11 +3,14 @@ package crawler
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/adapters"
+	"github.com/thewizardplusplus/go-crawler/frontiers"
 	"github.com/thewizardplusplus/go-crawler/handlers"
 	"github.com/thewizardplusplus/go-crawler/models"
-	syncutils "github.com/thewizardplusplus/go-sync-utils"
+	"github.com/thewizardplusplus/go-crawler/stats"
 )
 
 // ConcurrencyConfig ...
@@ -22,6 +25,28 @@ type CrawlDependencies struct {
 	LinkChecker   models.LinkChecker
 	LinkHandler   models.LinkHandler
 	Logger        log.Logger
+
+	// optional; they are used instead of the LinkExtractor, LinkChecker
+	// and LinkHandler fields correspondingly, so the extracted links
+	// are passed to the checker and the handler with their attributes;
+	// without them, the LinkExtractor, LinkChecker and LinkHandler fields
+	// are used as the second versions if they implement the corresponding
+	// interfaces
+	LinkExtractorV2 models.LinkExtractorV2
+	LinkCheckerV2   models.LinkCheckerV2
+	LinkHandlerV2   models.LinkHandlerV2
+
+	// optional; it allows to share the collector with checkers.CountingChecker
+	StatsCollector *stats.Collector
+	// optional; after its exhaustion, the links are handled, but aren't crawled
+	PageBudget *PageBudget
+	// optional; frontiers.ChannelFrontier is used by default
+	LinkFrontier models.LinkFrontier
+	// optional; it receives the errors in addition to the logging
+	ErrorHandler models.ErrorHandler
+	// optional; it receives the extracted pages with their attributes
+	// (e.g. the status codes and the robots directives of the pages)
+	PageHandler models.LinkHandlerV2
 }
 
 // Crawl ...
@@ -30,11 +55,37 @@ func Crawl(
 	concurrencyConfig ConcurrencyConfig,
 	links []string,
 	dependencies CrawlDependencies,
-) {
-	linkChannel := make(chan string, concurrencyConfig.BufferSize)
+) stats.Report {
+	var richLinks []models.RichLink
 	for _, link := range links {
-		// use unbounded sending to avoid a deadlock
-		syncutils.UnboundedSend(linkChannel, link)
+		richLinks = append(richLinks, models.RichLink{Link: link})
+	}
+
+	return CrawlRichLinks(ctx, concurrencyConfig, richLinks, dependencies)
+}
+
+// CrawlRichLinks ...
+//
+// Unlike Crawl(), it keeps the sources, the depths and the attributes
+// of the specified links, so it's suitable for resuming of the crawling
+// from a checkpoint.
+func CrawlRichLinks(
+	ctx context.Context,
+	concurrencyConfig ConcurrencyConfig,
+	links []models.RichLink,
+	dependencies CrawlDependencies,
+) stats.Report {
+	startTime := time.Now()
+	if dependencies.StatsCollector == nil {
+		dependencies.StatsCollector = stats.NewCollector()
+	}
+
+	if dependencies.LinkFrontier == nil {
+		dependencies.LinkFrontier =
+			frontiers.NewChannelFrontier(concurrencyConfig.BufferSize)
+	}
+	for _, link := range links {
+		dependencies.LinkFrontier.PushLink(link)
 	}
 
 	var waiter sync.WaitGroup
@@ -43,7 +94,7 @@ func Crawl(
 	HandleLinksConcurrently(
 		ctx,
 		concurrencyConfig.ConcurrencyFactor,
-		linkChannel,
+		dependencies.LinkFrontier,
 		HandleLinkDependencies{
 			CrawlDependencies: dependencies,
 			Waiter:            &waiter,
@@ -52,7 +103,12 @@ func Crawl(
 
 	waiter.Wait()
 	// it should be called after the waiter.Wait() call
-	close(linkChannel)
+	dependencies.LinkFrontier.Close()
+
+	report := dependencies.StatsCollector.Report()
+	report.Duration = time.Since(startTime)
+
+	return report
 }
 
 // CrawlByConcurrentHandler ...
@@ -62,19 +118,55 @@ func CrawlByConcurrentHandler(
 	handlerConcurrencyConfig ConcurrencyConfig,
 	links []string,
 	dependencies CrawlDependencies,
-) {
+) stats.Report {
 	handlerConcurrencyFactor, handlerBufferSize :=
 		handlerConcurrencyConfig.ConcurrencyFactor,
 		handlerConcurrencyConfig.BufferSize
-	concurrentHandler :=
-		handlers.NewConcurrentHandler(handlerBufferSize, dependencies.LinkHandler)
+	concurrentHandler := handlers.NewConcurrentHandlerV2(
+		handlerBufferSize,
+		dependencies.linkHandlerV2(),
+	)
 	go concurrentHandler.StartConcurrently(ctx, handlerConcurrencyFactor)
 	defer concurrentHandler.Stop()
 
-	Crawl(ctx, concurrencyConfig, links, CrawlDependencies{
-		LinkExtractor: dependencies.LinkExtractor,
-		LinkChecker:   dependencies.LinkChecker,
-		LinkHandler:   concurrentHandler,
-		Logger:        dependencies.Logger,
+	return Crawl(ctx, concurrencyConfig, links, CrawlDependencies{
+		LinkExtractor:  dependencies.LinkExtractor,
+		LinkChecker:    dependencies.LinkChecker,
+		LinkHandler:    concurrentHandler,
+		Logger:         dependencies.Logger,
+		StatsCollector: dependencies.StatsCollector,
+		PageBudget:     dependencies.PageBudget,
+		LinkFrontier:   dependencies.LinkFrontier,
+		ErrorHandler:   dependencies.ErrorHandler,
+
+		LinkExtractorV2: dependencies.LinkExtractorV2,
+		LinkCheckerV2:   dependencies.LinkCheckerV2,
+		LinkHandlerV2:   concurrentHandler,
+
+		PageHandler: dependencies.PageHandler,
 	})
 }
+
+func (dependencies CrawlDependencies) linkExtractorV2() models.LinkExtractorV2 {
+	if dependencies.LinkExtractorV2 != nil {
+		return dependencies.LinkExtractorV2
+	}
+
+	return adapters.LinkExtractorToV2(dependencies.LinkExtractor)
+}
+
+func (dependencies CrawlDependencies) linkCheckerV2() models.LinkCheckerV2 {
+	if dependencies.LinkCheckerV2 != nil {
+		return dependencies.LinkCheckerV2
+	}
+
+	return adapters.LinkCheckerToV2(dependencies.LinkChecker)
+}
+
+func (dependencies CrawlDependencies) linkHandlerV2() models.LinkHandlerV2 {
+	if dependencies.LinkHandlerV2 != nil {
+		return dependencies.LinkHandlerV2
+	}
+
+	return adapters.LinkHandlerToV2(dependencies.LinkHandler)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/frontiers"
 	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/stats"
 )
 
 func TestCrawl(test *testing.T) {
@@ -17,8 +20,9 @@ func TestCrawl(test *testing.T) {
 	}
 
 	for _, data := range []struct {
-		name string
-		args args
+		name       string
+		args       args
+		wantReport stats.Report
 	}{
 		{
 			name: "success with fewer links than the buffer size",
@@ -69,12 +73,14 @@ func TestCrawl(test *testing.T) {
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return(true)
 						checker.
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return(true)
 
@@ -86,12 +92,14 @@ func TestCrawl(test *testing.T) {
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return()
 						handler.
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return()
 
@@ -100,6 +108,12 @@ func TestCrawl(test *testing.T) {
 					Logger: new(MockLogger),
 				},
 			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 3,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   2,
+			},
 		},
 		{
 			name: "success without a buffer",
@@ -150,12 +164,14 @@ func TestCrawl(test *testing.T) {
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return(true)
 						checker.
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return(true)
 
@@ -167,12 +183,14 @@ func TestCrawl(test *testing.T) {
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return()
 						handler.
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return()
 
@@ -181,10 +199,200 @@ func TestCrawl(test *testing.T) {
 					Logger: new(MockLogger),
 				},
 			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 3,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   2,
+			},
+		},
+		{
+			name: "success with a breadth-first frontier",
+			args: args{
+				ctx: context.Background(),
+				concurrencyConfig: ConcurrencyConfig{
+					ConcurrencyFactor: 10,
+					BufferSize:        1000,
+				},
+				links: []string{"http://example.com/"},
+				dependencies: CrawlDependencies{
+					LinkExtractor: func() models.LinkExtractor {
+						threadIDChecker := mock.MatchedBy(func(threadID int) bool {
+							return threadID >= 0 && threadID < 10
+						})
+
+						extractor := new(MockLinkExtractor)
+						extractor.
+							On(
+								"ExtractLinks",
+								context.Background(),
+								threadIDChecker,
+								"http://example.com/",
+							).
+							Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+						extractor.
+							On(
+								"ExtractLinks",
+								context.Background(),
+								threadIDChecker,
+								"http://example.com/1",
+							).
+							Return(nil, nil)
+						extractor.
+							On(
+								"ExtractLinks",
+								context.Background(),
+								threadIDChecker,
+								"http://example.com/2",
+							).
+							Return(nil, nil)
+
+						return extractor
+					}(),
+					LinkChecker: func() models.LinkChecker {
+						checker := new(MockLinkChecker)
+						checker.
+							On("CheckLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/1",
+								Depth:      1,
+							}).
+							Return(true)
+						checker.
+							On("CheckLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/2",
+								Depth:      1,
+							}).
+							Return(true)
+
+						return checker
+					}(),
+					LinkHandler: func() models.LinkHandler {
+						handler := new(MockLinkHandler)
+						handler.
+							On("HandleLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/1",
+								Depth:      1,
+							}).
+							Return()
+						handler.
+							On("HandleLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/2",
+								Depth:      1,
+							}).
+							Return()
+
+						return handler
+					}(),
+					Logger:       new(MockLogger),
+					LinkFrontier: frontiers.NewBreadthFirstFrontier(),
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 3,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   2,
+			},
+		},
+		{
+			name: "success with a page budget",
+			args: args{
+				ctx: context.Background(),
+				concurrencyConfig: ConcurrencyConfig{
+					ConcurrencyFactor: 10,
+					BufferSize:        1000,
+				},
+				links: []string{"http://example.com/"},
+				dependencies: CrawlDependencies{
+					LinkExtractor: func() models.LinkExtractor {
+						threadIDChecker := mock.MatchedBy(func(threadID int) bool {
+							return threadID >= 0 && threadID < 10
+						})
+
+						extractor := new(MockLinkExtractor)
+						extractor.
+							On(
+								"ExtractLinks",
+								context.Background(),
+								threadIDChecker,
+								"http://example.com/",
+							).
+							Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+						extractor.
+							On(
+								"ExtractLinks",
+								context.Background(),
+								threadIDChecker,
+								"http://example.com/1",
+							).
+							Return([]string{"http://example.com/1/1"}, nil)
+
+						return extractor
+					}(),
+					LinkChecker: func() models.LinkChecker {
+						checker := new(MockLinkChecker)
+						checker.
+							On("CheckLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/1",
+								Depth:      1,
+							}).
+							Return(true)
+						checker.
+							On("CheckLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/2",
+								Depth:      1,
+							}).
+							Return(false)
+
+						return checker
+					}(),
+					LinkHandler: func() models.LinkHandler {
+						handler := new(MockLinkHandler)
+						handler.
+							On("HandleLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/1",
+								Depth:      1,
+							}).
+							Return()
+						handler.
+							On("HandleLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/2",
+								Depth:      1,
+							}).
+							Return()
+						handler.
+							On("HandleLink", context.Background(), models.SourcedLink{
+								SourceLink: "http://example.com/1",
+								Link:       "http://example.com/1/1",
+								Depth:      2,
+							}).
+							Return()
+
+						return handler
+					}(),
+					Logger:     new(MockLogger),
+					PageBudget: NewPageBudget(2),
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 2,
+				ExtractedLinkCount: 3,
+				HandledLinkCount:   3,
+				CheckedLinkCount:   1,
+				RejectedLinkCount:  1,
+			},
 		},
 	} {
 		test.Run(data.name, func(t *testing.T) {
-			Crawl(
+			gotReport := Crawl(
 				data.args.ctx,
 				data.args.concurrencyConfig,
 				data.args.links,
@@ -198,6 +406,11 @@ func TestCrawl(test *testing.T) {
 				data.args.dependencies.LinkHandler,
 				data.args.dependencies.Logger,
 			)
+
+			assert.True(test, gotReport.Duration > 0)
+			// the duration isn't reproducible, so reset it before the comparison
+			gotReport.Duration = 0
+			assert.Equal(test, data.wantReport, gotReport)
 		})
 	}
 }
@@ -212,8 +425,9 @@ func TestCrawlByConcurrentHandler(test *testing.T) {
 	}
 
 	for _, data := range []struct {
-		name string
-		args args
+		name       string
+		args       args
+		wantReport stats.Report
 	}{
 		{
 			name: "success",
@@ -268,12 +482,14 @@ func TestCrawlByConcurrentHandler(test *testing.T) {
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return(true)
 						checker.
 							On("CheckLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return(true)
 
@@ -285,12 +501,14 @@ func TestCrawlByConcurrentHandler(test *testing.T) {
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/1",
+								Depth:      1,
 							}).
 							Return()
 						handler.
 							On("HandleLink", context.Background(), models.SourcedLink{
 								SourceLink: "http://example.com/",
 								Link:       "http://example.com/2",
+								Depth:      1,
 							}).
 							Return()
 
@@ -299,10 +517,16 @@ func TestCrawlByConcurrentHandler(test *testing.T) {
 					Logger: new(MockLogger),
 				},
 			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 3,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   2,
+			},
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
-			CrawlByConcurrentHandler(
+			gotReport := CrawlByConcurrentHandler(
 				data.args.ctx,
 				data.args.concurrencyConfig,
 				data.args.handlerConcurrencyConfig,
@@ -317,6 +541,11 @@ func TestCrawlByConcurrentHandler(test *testing.T) {
 				data.args.dependencies.LinkHandler,
 				data.args.dependencies.Logger,
 			)
+
+			assert.True(test, gotReport.Duration > 0)
+			// the duration isn't reproducible, so reset it before the comparison
+			gotReport.Duration = 0
+			assert.Equal(test, data.wantReport, gotReport)
 		})
 	}
 }
@@ -0,0 +1,73 @@
+package extractors
+
+import (
+	"math"
+	"math/rand"
+	"time"
+)
+
+// BackoffStrategy ...
+type BackoffStrategy interface {
+	// the repeat is counted from zero
+	Delay(repeat int) time.Duration
+}
+
+// RandomHandler ...
+//
+// It should return a pseudo-random number in the range [0.0, 1.0).
+type RandomHandler func() float64
+
+// ConstantBackoff ...
+type ConstantBackoff struct {
+	Interval time.Duration
+}
+
+// Delay ...
+func (backoff ConstantBackoff) Delay(repeat int) time.Duration {
+	return backoff.Interval
+}
+
+// ExponentialBackoff ...
+type ExponentialBackoff struct {
+	InitialInterval time.Duration
+	Multiplier      float64
+	// optional; a non-positive value means no limit
+	MaximalInterval time.Duration
+}
+
+// Delay ...
+func (backoff ExponentialBackoff) Delay(repeat int) time.Duration {
+	delay := float64(backoff.InitialInterval) *
+		math.Pow(backoff.Multiplier, float64(repeat))
+	if backoff.MaximalInterval > 0 &&
+		delay > float64(backoff.MaximalInterval) {
+		return backoff.MaximalInterval
+	}
+	// prevent an overflow
+	if delay > math.MaxInt64 {
+		return math.MaxInt64
+	}
+
+	return time.Duration(delay)
+}
+
+// JitteredBackoff ...
+//
+// It uses the "full jitter", i.e., it randomizes the delay of the inner
+// strategy in the range [0, delay).
+type JitteredBackoff struct {
+	BackoffStrategy BackoffStrategy
+	// optional; rand.Float64() is used by default
+	RandomHandler RandomHandler
+}
+
+// Delay ...
+func (backoff JitteredBackoff) Delay(repeat int) time.Duration {
+	randomHandler := backoff.RandomHandler
+	if randomHandler == nil {
+		randomHandler = rand.Float64
+	}
+
+	delay := backoff.BackoffStrategy.Delay(repeat)
+	return time.Duration(float64(delay) * randomHandler())
+}
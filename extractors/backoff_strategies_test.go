@@ -0,0 +1,88 @@
+package extractors
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantBackoff_Delay(test *testing.T) {
+	backoff := ConstantBackoff{Interval: 100 * time.Millisecond}
+	for repeat := 0; repeat < 3; repeat++ {
+		assert.Equal(test, 100*time.Millisecond, backoff.Delay(repeat))
+	}
+}
+
+func TestExponentialBackoff_Delay(test *testing.T) {
+	for _, data := range []struct {
+		name       string
+		backoff    ExponentialBackoff
+		wantDelays []time.Duration
+	}{
+		{
+			name: "without the maximal interval",
+			backoff: ExponentialBackoff{
+				InitialInterval: 100 * time.Millisecond,
+				Multiplier:      2,
+			},
+			wantDelays: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+				800 * time.Millisecond,
+			},
+		},
+		{
+			name: "with the maximal interval",
+			backoff: ExponentialBackoff{
+				InitialInterval: 100 * time.Millisecond,
+				Multiplier:      2,
+				MaximalInterval: 300 * time.Millisecond,
+			},
+			wantDelays: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				300 * time.Millisecond,
+				300 * time.Millisecond,
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			var gotDelays []time.Duration
+			for repeat := range data.wantDelays {
+				gotDelays = append(gotDelays, data.backoff.Delay(repeat))
+			}
+
+			assert.Equal(test, data.wantDelays, gotDelays)
+		})
+	}
+
+	test.Run("with an overflow", func(test *testing.T) {
+		backoff := ExponentialBackoff{InitialInterval: time.Second, Multiplier: 10}
+		assert.Equal(test, time.Duration(math.MaxInt64), backoff.Delay(100))
+	})
+}
+
+func TestJitteredBackoff_Delay(test *testing.T) {
+	test.Run("with a random handler", func(test *testing.T) {
+		backoff := JitteredBackoff{
+			BackoffStrategy: ConstantBackoff{Interval: 100 * time.Millisecond},
+			RandomHandler:   func() float64 { return 0.25 },
+		}
+		assert.Equal(test, 25*time.Millisecond, backoff.Delay(0))
+	})
+
+	test.Run("without a random handler", func(test *testing.T) {
+		backoff := JitteredBackoff{
+			BackoffStrategy: ConstantBackoff{Interval: 100 * time.Millisecond},
+		}
+		for repeat := 0; repeat < 10; repeat++ {
+			delay := backoff.Delay(repeat)
+
+			assert.True(test, delay >= 0)
+			assert.True(test, delay < 100*time.Millisecond)
+		}
+	})
+}
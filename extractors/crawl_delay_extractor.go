@@ -0,0 +1,79 @@
+package extractors
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers"
+)
+
+// CrawlDelayExtractor ...
+//
+// It schedules requests to each host like the SchedulingExtractor,
+// but also honors the Crawl-delay directive from the robots.txt file.
+// The minimal delay of the host scheduler is used if it's greater.
+type CrawlDelayExtractor struct {
+	UserAgent         string
+	RobotsTXTRegister registers.RobotsTXTRegister
+	HostScheduler     *HostScheduler
+	LinkExtractor     models.LinkExtractor
+	Logger            log.Logger
+}
+
+// ExtractLinks ...
+func (extractor CrawlDelayExtractor) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) ([]string, error) {
+	schedulingExtractor := SchedulingExtractor{
+		HostScheduler: extractor.HostScheduler,
+		LinkExtractor: extractor.LinkExtractor,
+	}
+
+	crawlDelay := extractor.loadCrawlDelay(ctx, link)
+	return schedulingExtractor.extractLinksWithDelay(
+		ctx,
+		threadID,
+		link,
+		crawlDelay,
+	)
+}
+
+// ExtractRichLinks ...
+func (extractor CrawlDelayExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	schedulingExtractor := SchedulingExtractor{
+		HostScheduler: extractor.HostScheduler,
+		LinkExtractor: extractor.LinkExtractor,
+	}
+
+	crawlDelay := extractor.loadCrawlDelay(ctx, link.Link)
+	return schedulingExtractor.extractRichLinksWithDelay(
+		ctx,
+		threadID,
+		link,
+		crawlDelay,
+	)
+}
+
+func (extractor CrawlDelayExtractor) loadCrawlDelay(
+	ctx context.Context,
+	link string,
+) time.Duration {
+	ruleSet, err := extractor.RobotsTXTRegister.RegisterRobotsTXT(ctx, link)
+	if err != nil {
+		const logMessage = "unable to register the robots.txt link for link %q " +
+			"(the minimal delay is used): %s"
+		extractor.Logger.Logf(logMessage, link, err)
+
+		return 0
+	}
+
+	return ruleSet.FindCrawlDelay(extractor.UserAgent)
+}
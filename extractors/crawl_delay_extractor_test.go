@@ -0,0 +1,283 @@
+package extractors
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/go-log/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers"
+)
+
+func TestCrawlDelayExtractor_ExtractLinks(test *testing.T) {
+	type fields struct {
+		UserAgent         string
+		RobotsTXTRegister registers.RobotsTXTRegister
+		HostScheduler     *HostScheduler
+		LinkExtractor     models.LinkExtractor
+		Logger            log.Logger
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name            string
+		fields          fields
+		args            args
+		wantLinks       []string
+		wantMinDuration time.Duration
+		wantErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the crawl delay",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(`
+							User-agent: go-crawler
+							Crawl-delay: 0.1
+						`)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil).Times(1)
+
+					return registers.NewRobotsTXTRegister(httpClient)
+				}(),
+				HostScheduler: NewHostScheduler(0, 10),
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil).
+						Times(2)
+
+					return extractor
+				}(),
+				Logger: new(MockLogger),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks:       []string{"http://example.com/1", "http://example.com/2"},
+			wantMinDuration: 100 * time.Millisecond,
+			wantErr:         assert.NoError,
+		},
+		{
+			name: "success with the minimal delay greater than the crawl delay",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(`
+							User-agent: go-crawler
+							Crawl-delay: 0.01
+						`)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil).Times(1)
+
+					return registers.NewRobotsTXTRegister(httpClient)
+				}(),
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil).
+						Times(2)
+
+					return extractor
+				}(),
+				Logger: new(MockLogger),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks:       []string{"http://example.com/1", "http://example.com/2"},
+			wantMinDuration: 100 * time.Millisecond,
+			wantErr:         assert.NoError,
+		},
+		{
+			name: "success with an error on robots.txt registering",
+			fields: fields{
+				UserAgent: "go-crawler",
+				RobotsTXTRegister: func() registers.RobotsTXTRegister {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(nil, iotest.ErrTimeout).Times(2)
+
+					return registers.NewRobotsTXTRegister(httpClient)
+				}(),
+				HostScheduler: NewHostScheduler(0, 10),
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil).
+						Times(2)
+
+					return extractor
+				}(),
+				Logger: func() log.Logger {
+					err := errors.New(
+						"unable to load the robots.txt data: " +
+							"unable to send the request: " +
+							"robots.txt network error: " +
+							iotest.ErrTimeout.Error(),
+					)
+
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"unable to register the robots.txt link for link %q "+
+								"(the minimal delay is used): %s",
+							"http://example.com/",
+							mock.MatchedBy(func(gotErr error) bool {
+								return gotErr.Error() == err.Error()
+							}),
+						).
+						Return().
+						Times(2)
+
+					return logger
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks:       []string{"http://example.com/1", "http://example.com/2"},
+			wantMinDuration: 0,
+			wantErr:         assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := CrawlDelayExtractor{
+				UserAgent:         data.fields.UserAgent,
+				RobotsTXTRegister: data.fields.RobotsTXTRegister,
+				HostScheduler:     data.fields.HostScheduler,
+				LinkExtractor:     data.fields.LinkExtractor,
+				Logger:            data.fields.Logger,
+			}
+
+			// the extracting is performed twice
+			// to check the delay between the extractions
+			startTime := time.Now()
+			for repeat := 0; repeat < 2; repeat++ {
+				gotLinks, gotErr :=
+					extractor.ExtractLinks(data.args.ctx, data.args.threadID, data.args.link)
+
+				assert.Equal(test, data.wantLinks, gotLinks)
+				data.wantErr(test, gotErr)
+			}
+
+			mock.AssertExpectationsForObjects(
+				test,
+				data.fields.LinkExtractor,
+				data.fields.Logger,
+			)
+			assert.True(test, time.Since(startTime) >= data.wantMinDuration)
+		})
+	}
+}
+
+func TestCrawlDelayExtractor_ExtractRichLinks(test *testing.T) {
+	request, _ :=
+		http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+	request = request.WithContext(context.Background())
+
+	response := &http.Response{
+		StatusCode: http.StatusOK,
+		Body: ioutil.NopCloser(strings.NewReader(`
+			User-agent: go-crawler
+			Crawl-delay: 0.1
+		`)),
+	}
+
+	httpClient := new(MockHTTPClient)
+	httpClient.On("Do", request).Return(response, nil).Times(1)
+
+	page := models.RichLink{
+		Link:       "http://example.com/",
+		Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+	}
+	richLinks := []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Depth:      1,
+			Attributes: models.Attributes{}.
+				Set(models.HTMLMetadataAttribute, models.HTMLMetadata{TagName: "a"}),
+		},
+	}
+	linkExtractor := new(MockRichLinkExtractor)
+	linkExtractor.
+		On(
+			"ExtractRichLinks",
+			context.Background(),
+			23,
+			models.RichLink{Link: "http://example.com/"},
+		).
+		Return(page, richLinks, nil).
+		Times(2)
+
+	logger := new(MockLogger)
+	extractor := CrawlDelayExtractor{
+		UserAgent:         "go-crawler",
+		RobotsTXTRegister: registers.NewRobotsTXTRegister(httpClient),
+		HostScheduler:     NewHostScheduler(0, 10),
+		LinkExtractor:     linkExtractor,
+		Logger:            logger,
+	}
+
+	// the extracting is performed twice
+	// to check the delay between the extractions
+	startTime := time.Now()
+	for repeat := 0; repeat < 2; repeat++ {
+		gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+			context.Background(),
+			23,
+			models.RichLink{Link: "http://example.com/"},
+		)
+
+		assert.Equal(test, page, gotPage)
+		assert.Equal(test, richLinks, gotLinks)
+		assert.NoError(test, gotErr)
+	}
+
+	mock.AssertExpectationsForObjects(test, httpClient, linkExtractor, logger)
+	assert.True(test, time.Since(startTime) >= 100*time.Millisecond)
+}
@@ -3,21 +3,47 @@ package extractors
 import (
 	"bytes"
 	"context"
+	"io"
 	"io/ioutil"
+	"mime"
 	"net/http"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/extractors/transformers"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
 	"github.com/thewizardplusplus/go-crawler/models"
 	htmlselector "github.com/thewizardplusplus/go-html-selector"
-	"github.com/thewizardplusplus/go-html-selector/builders"
 	httputils "github.com/thewizardplusplus/go-http-utils"
 )
 
+// DefaultHTMLTypes ...
+var DefaultHTMLTypes = []string{ // nolint: gochecknoglobals
+	"text/html",
+	"application/xhtml+xml",
+}
+
 // DefaultExtractor ...
+//
+// If the MIME registry is specified, the extraction is routed by the content
+// type of the response before reading of its body: the HTML types
+// and a missing content type are processed by the extractor itself,
+// the already received response of the registered types is passed
+// to the corresponding link extractors, and the links of the other types
+// are skipped.
 type DefaultExtractor struct {
 	HTTPClient      httputils.HTTPClient
 	Filters         htmlselector.OptimizedFilterGroup
 	LinkTransformer models.LinkTransformer
+	// optional; all status codes are accepted by default
+	StatusPolicy *StatusPolicy
+	// a zero value means no limit of the response size
+	SizeLimit ioutils.SizeLimit
+	// optional; the links of all content types are processed as HTML by default
+	MIMERegistry MIMERegistry
+	// optional; DefaultHTMLTypes are used by default;
+	// these types are never delegated to the MIME registry
+	HTMLTypes []string
 }
 
 // ExtractLinks ...
@@ -26,64 +52,297 @@ func (extractor DefaultExtractor) ExtractLinks(
 	threadID int,
 	link string,
 ) ([]string, error) {
-	data, response, err := extractor.loadData(ctx, link)
+	response, err := extractor.sendRequest(ctx, link)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to send the request")
+	}
+	defer response.Body.Close() // nolint: errcheck
+
+	if linkExtractor, isRouted := extractor.routeResponse(response); isRouted {
+		return extractor.extractRoutedLinks(
+			ctx,
+			threadID,
+			link,
+			response,
+			linkExtractor,
+		)
+	}
+
+	// without the link transformer, the response data isn't needed entirely,
+	// so the links are selected directly from the response stream
+	if extractor.LinkTransformer == nil {
+		links, err := extractor.readLinks(response)
+		if err != nil {
+			return nil, errors.Wrap(err, "unable to read the links")
+		}
+
+		return transformers.MakeRawLinks(links), nil
+	}
+
+	data, err := extractor.readData(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the data")
+	}
+
+	links, err := extractor.selectLinks(bytes.NewReader(data))
+	if err != nil {
+		// the data is already read, so only the parsing can fail
+		return nil,
+			errors.Wrap(ParsingError{Err: err}, "unable to select the links")
+	}
+
+	transformedLinks, err := extractor.LinkTransformer.
+		TransformLinks(transformers.MakeRawLinks(links), response, data)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to transform the links")
+	}
+
+	return transformedLinks, nil
+}
+
+// ExtractRichLinks ...
+//
+// Unlike the ExtractLinks method, it returns the HTML metadata of the links
+// in their attributes. The attributes of the extracted page contain
+// the status code and the content type of the response, the time
+// of the extraction and the attributes set by the link transformer
+// (see the models.HTMLPageTransformer interface).
+func (extractor DefaultExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	response, err := extractor.sendRequest(ctx, link.Link)
+	if err != nil {
+		return models.RichLink{}, nil,
+			errors.Wrap(err, "unable to send the request")
+	}
+	defer response.Body.Close() // nolint: errcheck
+
+	page, links, err :=
+		extractor.extractPageLinks(ctx, threadID, makePage(link, response), response)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to load the data")
+		return models.RichLink{}, nil, err
+	}
+
+	var richLinks []models.RichLink
+	for _, htmlLink := range links {
+		var attributes models.Attributes
+		// the metadata is lost for the links changed by the link transformer
+		if htmlLink.Metadata != (models.HTMLMetadata{}) {
+			attributes =
+				attributes.Set(models.HTMLMetadataAttribute, htmlLink.Metadata)
+		}
+
+		richLinks = append(richLinks, models.RichLink{
+			SourceLink: link.Link,
+			Link:       htmlLink.Link,
+			Depth:      link.Depth + 1,
+			Attributes: attributes,
+		})
+	}
+
+	return page, richLinks, nil
+}
+
+func (extractor DefaultExtractor) extractPageLinks(
+	ctx context.Context,
+	threadID int,
+	page models.RichLink,
+	response *http.Response,
+) (models.RichLink, []models.HTMLLink, error) {
+	if linkExtractor, isRouted := extractor.routeResponse(response); isRouted {
+		links, err := extractor.extractRoutedLinks(
+			ctx,
+			threadID,
+			page.Link,
+			response,
+			linkExtractor,
+		)
+		if err != nil {
+			return models.RichLink{}, nil, err
+		}
+
+		var htmlLinks []models.HTMLLink
+		for _, link := range links {
+			htmlLinks = append(htmlLinks, models.HTMLLink{Link: link})
+		}
+
+		return page, htmlLinks, nil
 	}
 
-	links := extractor.selectLinks(data)
-	if extractor.LinkTransformer != nil {
-		transformedLinks, err :=
-			extractor.LinkTransformer.TransformLinks(links, response, data)
+	if extractor.LinkTransformer == nil {
+		links, err := extractor.readLinks(response)
 		if err != nil {
-			return nil, errors.Wrap(err, "unable to transform the links")
+			return models.RichLink{}, nil,
+				errors.Wrap(err, "unable to read the links")
 		}
 
-		links = transformedLinks
+		return page, links, nil
+	}
+
+	data, err := extractor.readData(response)
+	if err != nil {
+		return models.RichLink{}, nil, errors.Wrap(err, "unable to read the data")
+	}
+
+	links, err := extractor.selectLinks(bytes.NewReader(data))
+	if err != nil {
+		// the data is already read, so only the parsing can fail
+		return models.RichLink{}, nil,
+			errors.Wrap(ParsingError{Err: err}, "unable to select the links")
+	}
+
+	page, transformedLinks, err := transformers.TransformHTMLPage(
+		extractor.LinkTransformer,
+		page,
+		links,
+		response,
+		data,
+	)
+	if err != nil {
+		return models.RichLink{}, nil,
+			errors.Wrap(err, "unable to transform the links")
+	}
+
+	return page, transformedLinks, nil
+}
+
+// the link extractor is nil if the response should be skipped
+func (extractor DefaultExtractor) extractRoutedLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+	response *http.Response,
+	linkExtractor models.ResponseLinkExtractor,
+) ([]string, error) {
+	if linkExtractor == nil {
+		return nil, nil
+	}
+
+	// the link extractor shouldn't close the response body,
+	// so the limited body is passed without a closer
+	limitedResponse := *response
+	limitedResponse.Body = ioutil.NopCloser(
+		ioutils.LimitReader(response.Body, extractor.SizeLimit),
+	)
+
+	links, err := linkExtractor.
+		ExtractResponseLinks(ctx, threadID, link, &limitedResponse)
+	if err != nil {
+		const message = "unable to extract the links of content type %q"
+		return nil, errors.Wrapf(err, message, response.Header.Get("Content-Type"))
 	}
 
 	return links, nil
 }
 
-func (extractor DefaultExtractor) loadData(
+func (extractor DefaultExtractor) sendRequest(
 	ctx context.Context,
 	link string,
-) ([]byte, *http.Response, error) {
+) (*http.Response, error) {
 	request, err := http.NewRequest(http.MethodGet, link, nil)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to create the request")
+		return nil, errors.Wrap(err, "unable to create the request")
 	}
 	request = request.WithContext(ctx)
 
 	response, err := extractor.HTTPClient.Do(request)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to send the request")
+		return nil, errors.Wrap(err, "unable to send the request")
+	}
+
+	if extractor.StatusPolicy != nil {
+		if err := extractor.StatusPolicy.CheckResponse(response); err != nil {
+			response.Body.Close() // nolint: errcheck, gosec
+			return nil, errors.Wrap(err, "unable to accept the response")
+		}
 	}
-	defer response.Body.Close() // nolint: errcheck
 
-	data, err := ioutil.ReadAll(response.Body)
+	return response, nil
+}
+
+func (extractor DefaultExtractor) readData(
+	response *http.Response,
+) ([]byte, error) {
+	data, err := ioutils.ReadAll(response.Body, extractor.SizeLimit)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the response")
+	}
+
+	return data, nil
+}
+
+func (extractor DefaultExtractor) readLinks(
+	response *http.Response,
+) ([]models.HTMLLink, error) {
+	responseReader := ioutils.LimitReader(response.Body, extractor.SizeLimit)
+	links, err := extractor.selectLinks(responseReader)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "unable to read the response")
+		return nil, errors.Wrap(err, "unable to select the links")
 	}
 
-	return data, response, nil
+	return links, nil
 }
 
-func (extractor DefaultExtractor) selectLinks(data []byte) []string {
-	var builder builders.FlattenBuilder
-	htmlselector.SelectTags( // nolint: errcheck, gosec
-		bytes.NewReader(data),
-		extractor.Filters,
-		&builder,
+// it decides by the headers only, so the response body isn't read
+// before the routing; the returned link extractor is nil
+// for the unregistered types
+func (extractor DefaultExtractor) routeResponse(
+	response *http.Response,
+) (linkExtractor models.ResponseLinkExtractor, isRouted bool) {
+	if extractor.MIMERegistry == nil {
+		return nil, false
+	}
+
+	contentType := response.Header.Get("Content-Type")
+	mimeType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		// a missing or an incorrect content type is processed as HTML
+		return nil, false
+	}
+
+	htmlTypes := extractor.HTMLTypes
+	if htmlTypes == nil {
+		htmlTypes = DefaultHTMLTypes
+	}
+	for _, htmlType := range htmlTypes {
+		if mimeType == htmlType {
+			return nil, false
+		}
+	}
+
+	linkExtractor, _ = extractor.MIMERegistry.Lookup(contentType)
+	return linkExtractor, true
+}
+
+func (extractor DefaultExtractor) selectLinks(
+	reader io.Reader,
+) ([]models.HTMLLink, error) {
+	builder := newHTMLLinkBuilder(extractor.Filters)
+	err := htmlselector.SelectTags(
+		reader,
+		builder.selectionFilters(),
+		builder,
 		htmlselector.SkipEmptyTags(),
 		htmlselector.SkipEmptyAttributes(),
 	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to select the tags")
+	}
+
+	return builder.htmlLinks(), nil
+}
 
-	var links []string
-	for _, attributeValue := range builder.AttributeValues() {
-		link := string(attributeValue)
-		links = append(links, link)
+// it sets the attributes of the page from the response metadata
+func makePage(link models.RichLink, response *http.Response) models.RichLink {
+	attributes := link.Attributes.
+		Set(models.StatusCodeAttribute, response.StatusCode).
+		Set(models.ExtractionTimeAttribute, time.Now())
+	if contentType := response.Header.Get("Content-Type"); contentType != "" {
+		attributes = attributes.Set(models.ContentTypeAttribute, contentType)
 	}
 
-	return links
+	link.Attributes = attributes
+	return link
 }
@@ -9,9 +9,12 @@ import (
 	"strings"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
 	"github.com/thewizardplusplus/go-crawler/models"
 	htmlselector "github.com/thewizardplusplus/go-html-selector"
 	httputils "github.com/thewizardplusplus/go-http-utils"
@@ -22,6 +25,7 @@ func TestDefaultExtractor_ExtractLinks(test *testing.T) {
 		HTTPClient      httputils.HTTPClient
 		Filters         htmlselector.OptimizedFilterGroup
 		LinkTransformer models.LinkTransformer
+		SizeLimit       ioutils.SizeLimit
 	}
 	type args struct {
 		ctx      context.Context
@@ -134,38 +138,541 @@ func TestDefaultExtractor_ExtractLinks(test *testing.T) {
 			},
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with truncating of the response",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Body: ioutil.NopCloser(strings.NewReader(
+							`<a href="http://example.com/1">1</a>` +
+								`<a href="http://example.com/2">2</a>`,
+						)),
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				SizeLimit: ioutils.SizeLimit{MaximalSize: 40, Truncate: true},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with loading of the data",
+			fields: fields{
+				HTTPClient: new(MockHTTPClient),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     ":",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+		{
+			name: "error with a too large response without transformation",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Body: ioutil.NopCloser(strings.NewReader(
+							`<a href="http://example.com/1">1</a>` +
+								`<a href="http://example.com/2">2</a>`,
+						)),
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				SizeLimit: ioutils.SizeLimit{MaximalSize: 40},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				wantErr := ioutils.TooLargeError{MaximalSize: 40}
+				return assert.Equal(test, wantErr, errors.Cause(err), msgAndArgs...)
+			},
+		},
+		{
+			name: "error with transformation of the links",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					responseContent := `
+						<ul>
+							<li><a href="http://example.com/1">1</a></li>
+							<li><a href="http://example.com/2">2</a></li>
+						</ul>
+					`
+					response := &http.Response{
+						Body:    ioutil.NopCloser(strings.NewReader(responseContent)),
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				LinkTransformer: func() models.LinkTransformer {
+					links := []string{"http://example.com/1", "http://example.com/2"}
+
+					responseContent := `
+						<ul>
+							<li><a href="http://example.com/1">1</a></li>
+							<li><a href="http://example.com/2">2</a></li>
+						</ul>
+					`
+					response := &http.Response{
+						Body:    ioutil.NopCloser(strings.NewReader(responseContent)),
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+					ioutil.ReadAll(response.Body) // nolint: errcheck
+
+					linkTransformer := new(MockLinkTransformer)
+					linkTransformer.
+						On("TransformLinks", links, response, []byte(responseContent)).
+						Return(nil, iotest.ErrTimeout)
+
+					return linkTransformer
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := DefaultExtractor{
+				HTTPClient:      data.fields.HTTPClient,
+				Filters:         data.fields.Filters,
+				LinkTransformer: data.fields.LinkTransformer,
+				SizeLimit:       data.fields.SizeLimit,
+			}
+			gotLinks, gotErr := extractor.ExtractLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
+			if data.fields.LinkTransformer != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.LinkTransformer)
+			}
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestDefaultExtractor_ExtractRichLinks(test *testing.T) {
+	type fields struct {
+		HTTPClient      httputils.HTTPClient
+		Filters         htmlselector.OptimizedFilterGroup
+		LinkTransformer models.LinkTransformer
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     models.RichLink
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantPage  models.RichLink
+		wantLinks []models.RichLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without transformation of the links",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": {"text/html"}},
+						Body: ioutil.NopCloser(strings.NewReader(`
+							<ul>
+								<li><a href="http://example.com/1">one</a></li>
+								<li><a href="http://example.com/2" rel="nofollow">two</a></li>
+							</ul>
+						`)),
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/source",
+					Link:       "http://example.com/",
+					Depth:      2,
+				},
+			},
+			wantPage: models.RichLink{
+				SourceLink: "http://example.com/source",
+				Link:       "http://example.com/",
+				Depth:      2,
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, http.StatusOK).
+					Set(models.ContentTypeAttribute, "text/html"),
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      3,
+					Attributes: models.Attributes{}.
+						Set(models.HTMLMetadataAttribute, models.HTMLMetadata{
+							TagName:       "a",
+							AttributeName: "href",
+						}),
+				},
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/2",
+					Depth:      3,
+					Attributes: models.Attributes{}.
+						Set(models.HTMLMetadataAttribute, models.HTMLMetadata{
+							TagName:       "a",
+							AttributeName: "href",
+							Rel:           "nofollow",
+						}),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with transformation of the links",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					responseContent := `
+						<ul>
+							<li><a href="http://example.com/1">one</a></li>
+							<li><a href="http://example.com/2">two</a></li>
+						</ul>
+					`
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(responseContent)),
+						Request:    httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				LinkTransformer: func() models.LinkTransformer {
+					links := []string{"http://example.com/1", "http://example.com/2"}
+					transformedLinks := []string{
+						"http://example.com/1",
+						"http://example.com/transformed/2",
+					}
+
+					responseContent := `
+						<ul>
+							<li><a href="http://example.com/1">one</a></li>
+							<li><a href="http://example.com/2">two</a></li>
+						</ul>
+					`
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader(responseContent)),
+						Request:    httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+					ioutil.ReadAll(response.Body) // nolint: errcheck
+
+					linkTransformer := new(MockLinkTransformer)
+					linkTransformer.
+						On("TransformLinks", links, response, []byte(responseContent)).
+						Return(transformedLinks, nil)
+
+					return linkTransformer
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage: models.RichLink{
+				Link: "http://example.com/",
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, http.StatusOK),
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+					Attributes: models.Attributes{}.
+						Set(models.HTMLMetadataAttribute, models.HTMLMetadata{
+							TagName:       "a",
+							AttributeName: "href",
+						}),
+				},
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/transformed/2",
+					Depth:      1,
+				},
+			},
+			wantErr: assert.NoError,
+		},
 		{
 			name: "error with loading of the data",
 			fields: fields{
-				HTTPClient: new(MockHTTPClient),
-				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
-					"a": {"href"},
-				}),
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(nil, iotest.ErrTimeout)
+
+					return httpClient
+				}(),
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage:  models.RichLink{},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := DefaultExtractor{
+				HTTPClient:      data.fields.HTTPClient,
+				Filters:         data.fields.Filters,
+				LinkTransformer: data.fields.LinkTransformer,
+			}
+			startTime := time.Now()
+			gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			if gotPage.Attributes.Len() != 0 {
+				extractionTime, ok :=
+					gotPage.Attributes.Time(models.ExtractionTimeAttribute)
+				assert.True(test, ok)
+				assert.WithinDuration(test, startTime, extractionTime, time.Second)
+
+				gotPage.Attributes =
+					gotPage.Attributes.Delete(models.ExtractionTimeAttribute)
+			}
+
+			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
+			if data.fields.LinkTransformer != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.LinkTransformer)
+			}
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestDefaultExtractor_ExtractLinks_withMIMERegistry(test *testing.T) {
+	type fields struct {
+		HTTPClient    httputils.HTTPClient
+		LinkExtractor models.ResponseLinkExtractor
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with an HTML type",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Header: http.Header{
+							"Content-Type": {"application/xhtml+xml; charset=utf-8"},
+						},
+						Body: ioutil.NopCloser(strings.NewReader(
+							`<a href="http://example.com/1">1</a>` +
+								`<a href="http://example.com/2">2</a>`,
+						)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				LinkExtractor: new(MockResponseLinkExtractor),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success without the content type",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Body: ioutil.NopCloser(strings.NewReader(
+							`<a href="http://example.com/1">1</a>` +
+								`<a href="http://example.com/2">2</a>`,
+						)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				LinkExtractor: new(MockResponseLinkExtractor),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success with a registered type",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Header: http.Header{"Content-Type": {"application/rss+xml"}},
+						Body:   ioutil.NopCloser(strings.NewReader("<rss />")),
+					}
+
+					// the link isn't requested again by the registered link extractor
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil).Once()
+
+					return httpClient
+				}(),
+				LinkExtractor: func() models.ResponseLinkExtractor {
+					extractor := new(MockResponseLinkExtractor)
+					extractor.
+						On(
+							"ExtractResponseLinks",
+							context.Background(),
+							23,
+							"http://example.com/",
+							mock.MatchedBy(isResponseWithBody("<rss />")),
+						).
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+
+					return extractor
+				}(),
 			},
 			args: args{
 				ctx:      context.Background(),
 				threadID: 23,
-				link:     ":",
+				link:     "http://example.com/",
 			},
-			wantLinks: nil,
-			wantErr:   assert.Error,
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
 		},
 		{
-			name: "error with transformation of the links",
+			name: "success with an unregistered type",
 			fields: fields{
 				HTTPClient: func() httputils.HTTPClient {
 					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
 					request = request.WithContext(context.Background())
 
-					responseContent := `
-						<ul>
-							<li><a href="http://example.com/1">1</a></li>
-							<li><a href="http://example.com/2">2</a></li>
-						</ul>
-					`
 					response := &http.Response{
-						Body:    ioutil.NopCloser(strings.NewReader(responseContent)),
-						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+						Header: http.Header{"Content-Type": {"application/pdf"}},
+						// the body isn't read
+						Body: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader(""))),
 					}
 
 					httpClient := new(MockHTTPClient)
@@ -173,30 +680,46 @@ func TestDefaultExtractor_ExtractLinks(test *testing.T) {
 
 					return httpClient
 				}(),
-				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
-					"a": {"href"},
-				}),
-				LinkTransformer: func() models.LinkTransformer {
-					links := []string{"http://example.com/1", "http://example.com/2"}
+				LinkExtractor: new(MockResponseLinkExtractor),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with the registered link extractor",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
 
-					responseContent := `
-						<ul>
-							<li><a href="http://example.com/1">1</a></li>
-							<li><a href="http://example.com/2">2</a></li>
-						</ul>
-					`
 					response := &http.Response{
-						Body:    ioutil.NopCloser(strings.NewReader(responseContent)),
-						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+						Header: http.Header{"Content-Type": {"application/rss+xml"}},
+						Body:   ioutil.NopCloser(strings.NewReader("")),
 					}
-					ioutil.ReadAll(response.Body) // nolint: errcheck
 
-					linkTransformer := new(MockLinkTransformer)
-					linkTransformer.
-						On("TransformLinks", links, response, []byte(responseContent)).
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				LinkExtractor: func() models.ResponseLinkExtractor {
+					extractor := new(MockResponseLinkExtractor)
+					extractor.
+						On(
+							"ExtractResponseLinks",
+							context.Background(),
+							23,
+							"http://example.com/",
+							mock.AnythingOfType("*http.Response"),
+						).
 						Return(nil, iotest.ErrTimeout)
 
-					return linkTransformer
+					return extractor
 				}(),
 			},
 			args: args{
@@ -210,9 +733,13 @@ func TestDefaultExtractor_ExtractLinks(test *testing.T) {
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			extractor := DefaultExtractor{
-				HTTPClient:      data.fields.HTTPClient,
-				Filters:         data.fields.Filters,
-				LinkTransformer: data.fields.LinkTransformer,
+				HTTPClient: data.fields.HTTPClient,
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				MIMERegistry: MIMERegistry{
+					"application/rss+xml": data.fields.LinkExtractor,
+				},
 			}
 			gotLinks, gotErr := extractor.ExtractLinks(
 				data.args.ctx,
@@ -220,19 +747,168 @@ func TestDefaultExtractor_ExtractLinks(test *testing.T) {
 				data.args.link,
 			)
 
-			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
-			if data.fields.LinkTransformer != nil {
-				mock.AssertExpectationsForObjects(test, data.fields.LinkTransformer)
+			mock.AssertExpectationsForObjects(
+				test,
+				data.fields.HTTPClient,
+				data.fields.LinkExtractor,
+			)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestDefaultExtractor_ExtractRichLinks_withMIMERegistry(test *testing.T) {
+	type fields struct {
+		HTTPClient    httputils.HTTPClient
+		LinkExtractor models.ResponseLinkExtractor
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     models.RichLink
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantPage  models.RichLink
+		wantLinks []models.RichLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with a registered type",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Type": {"application/rss+xml"}},
+						Body:       ioutil.NopCloser(strings.NewReader("<rss />")),
+					}
+
+					// the link isn't requested again by the registered link extractor
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil).Once()
+
+					return httpClient
+				}(),
+				LinkExtractor: func() models.ResponseLinkExtractor {
+					extractor := new(MockResponseLinkExtractor)
+					extractor.
+						On(
+							"ExtractResponseLinks",
+							context.Background(),
+							23,
+							"http://example.com/",
+							mock.MatchedBy(isResponseWithBody("<rss />")),
+						).
+						Return([]string{"http://example.com/1"}, nil)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/", Depth: 2},
+			},
+			wantPage: models.RichLink{
+				Link:  "http://example.com/",
+				Depth: 2,
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, http.StatusOK).
+					Set(models.ContentTypeAttribute, "application/rss+xml"),
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      3,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an unregistered type",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						Header: http.Header{"Content-Type": {"application/pdf"}},
+						// the body isn't read
+						Body: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader(""))),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				LinkExtractor: new(MockResponseLinkExtractor),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/", Depth: 2},
+			},
+			wantPage: models.RichLink{
+				Link:  "http://example.com/",
+				Depth: 2,
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, 0).
+					Set(models.ContentTypeAttribute, "application/pdf"),
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := DefaultExtractor{
+				HTTPClient: data.fields.HTTPClient,
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+				MIMERegistry: MIMERegistry{
+					"application/rss+xml": data.fields.LinkExtractor,
+				},
 			}
+			startTime := time.Now()
+			gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			extractionTime, ok :=
+				gotPage.Attributes.Time(models.ExtractionTimeAttribute)
+			assert.True(test, ok)
+			assert.WithinDuration(test, startTime, extractionTime, time.Second)
+
+			gotPage.Attributes =
+				gotPage.Attributes.Delete(models.ExtractionTimeAttribute)
+
+			mock.AssertExpectationsForObjects(
+				test,
+				data.fields.HTTPClient,
+				data.fields.LinkExtractor,
+			)
+			assert.Equal(test, data.wantPage, gotPage)
 			assert.Equal(test, data.wantLinks, gotLinks)
 			data.wantErr(test, gotErr)
 		})
 	}
 }
 
-func TestDefaultExtractor_loadData(test *testing.T) {
+func TestDefaultExtractor_sendRequest(test *testing.T) {
 	type fields struct {
-		HTTPClient httputils.HTTPClient
+		HTTPClient   httputils.HTTPClient
+		StatusPolicy *StatusPolicy
 	}
 	type args struct {
 		ctx  context.Context
@@ -243,7 +919,6 @@ func TestDefaultExtractor_loadData(test *testing.T) {
 		name         string
 		fields       fields
 		args         args
-		wantData     []byte
 		wantResponse *http.Response
 		wantErr      assert.ErrorAssertionFunc
 	}{
@@ -268,15 +943,38 @@ func TestDefaultExtractor_loadData(test *testing.T) {
 				ctx:  context.Background(),
 				link: "http://example.com/",
 			},
-			wantData: []byte("data"),
-			wantResponse: func() *http.Response {
-				data := strings.NewReader("data")
-				data.Seek(0, io.SeekEnd) // nolint: errcheck
-
-				return &http.Response{
-					Body: ioutil.NopCloser(data),
-				}
-			}(),
+			wantResponse: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader("data")),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an accepted status code",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader("data")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				StatusPolicy: &DefaultStatusPolicy,
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/",
+			},
+			wantResponse: &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       ioutil.NopCloser(strings.NewReader("data")),
+			},
 			wantErr: assert.NoError,
 		},
 		{
@@ -288,7 +986,6 @@ func TestDefaultExtractor_loadData(test *testing.T) {
 				ctx:  context.Background(),
 				link: ":",
 			},
-			wantData:     nil,
 			wantResponse: nil,
 			wantErr:      assert.Error,
 		},
@@ -309,19 +1006,19 @@ func TestDefaultExtractor_loadData(test *testing.T) {
 				ctx:  context.Background(),
 				link: "http://example.com/",
 			},
-			wantData:     nil,
 			wantResponse: nil,
 			wantErr:      assert.Error,
 		},
 		{
-			name: "error with request reading",
+			name: "error with an unaccepted status code",
 			fields: fields{
 				HTTPClient: func() httputils.HTTPClient {
 					request, _ := http.NewRequest(http.MethodGet, "http://example.com/", nil)
 					request = request.WithContext(context.Background())
 
 					response := &http.Response{
-						Body: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader("data"))),
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader("data")),
 					}
 
 					httpClient := new(MockHTTPClient)
@@ -329,44 +1026,112 @@ func TestDefaultExtractor_loadData(test *testing.T) {
 
 					return httpClient
 				}(),
+				StatusPolicy: &DefaultStatusPolicy,
 			},
 			args: args{
 				ctx:  context.Background(),
 				link: "http://example.com/",
 			},
-			wantData:     nil,
 			wantResponse: nil,
-			wantErr:      assert.Error,
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				wantErr := StatusCodeError{StatusCode: http.StatusNotFound}
+				return assert.Equal(test, wantErr, errors.Cause(err), msgAndArgs...)
+			},
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			extractor := DefaultExtractor{
-				HTTPClient: data.fields.HTTPClient,
+				HTTPClient:   data.fields.HTTPClient,
+				StatusPolicy: data.fields.StatusPolicy,
 			}
-			gotData, gotResponse, gotErr :=
-				extractor.loadData(data.args.ctx, data.args.link)
+			gotResponse, gotErr :=
+				extractor.sendRequest(data.args.ctx, data.args.link)
 
 			mock.AssertExpectationsForObjects(test, data.fields.HTTPClient)
-			assert.Equal(test, data.wantData, gotData)
 			assert.Equal(test, data.wantResponse, gotResponse)
 			data.wantErr(test, gotErr)
 		})
 	}
 }
 
+func TestDefaultExtractor_readData(test *testing.T) {
+	for _, data := range []struct {
+		name      string
+		sizeLimit ioutils.SizeLimit
+		response  *http.Response
+		wantData  []byte
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader("data")),
+			},
+			wantData: []byte("data"),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:      "success with truncating of the response",
+			sizeLimit: ioutils.SizeLimit{MaximalSize: 2, Truncate: true},
+			response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader("data")),
+			},
+			wantData: []byte("da"),
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with response reading",
+			response: &http.Response{
+				Body: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader("data"))),
+			},
+			wantData: nil,
+			wantErr:  assert.Error,
+		},
+		{
+			name:      "error with a too large response",
+			sizeLimit: ioutils.SizeLimit{MaximalSize: 2},
+			response: &http.Response{
+				Body: ioutil.NopCloser(strings.NewReader("data")),
+			},
+			wantData: nil,
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				wantErr := ioutils.TooLargeError{MaximalSize: 2}
+				return assert.Equal(test, wantErr, errors.Cause(err), msgAndArgs...)
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := DefaultExtractor{SizeLimit: data.sizeLimit}
+			gotData, gotErr := extractor.readData(data.response)
+
+			assert.Equal(test, data.wantData, gotData)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
 func TestDefaultExtractor_selectLinks(test *testing.T) {
 	type fields struct {
 		Filters htmlselector.OptimizedFilterGroup
 	}
 	type args struct {
-		data []byte
+		reader io.Reader
 	}
 
 	for _, data := range []struct {
 		name      string
 		fields    fields
 		args      args
-		wantLinks []string
+		wantLinks []models.HTMLLink
+		wantErr   assert.ErrorAssertionFunc
 	}{
 		{
 			name: "without links",
@@ -376,9 +1141,10 @@ func TestDefaultExtractor_selectLinks(test *testing.T) {
 				}),
 			},
 			args: args{
-				data: []byte(""),
+				reader: strings.NewReader(""),
 			},
 			wantLinks: nil,
+			wantErr:   assert.NoError,
 		},
 		{
 			name: "with links",
@@ -388,23 +1154,118 @@ func TestDefaultExtractor_selectLinks(test *testing.T) {
 				}),
 			},
 			args: args{
-				data: []byte(`
+				reader: strings.NewReader(`
 					<ul>
 						<li><a href="http://example.com/1">1</a></li>
 						<li><a href="http://example.com/2">2</a></li>
 					</ul>
 				`),
 			},
-			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantLinks: []models.HTMLLink{
+				{
+					Link: "http://example.com/1",
+					Metadata: models.HTMLMetadata{
+						TagName:       "a",
+						AttributeName: "href",
+					},
+				},
+				{
+					Link: "http://example.com/2",
+					Metadata: models.HTMLMetadata{
+						TagName:       "a",
+						AttributeName: "href",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "with links and the rel attributes",
+			fields: fields{
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a":    {"href"},
+					"link": {"href", "rel"},
+				}),
+			},
+			args: args{
+				reader: strings.NewReader(`
+					<link rel="alternate" href="http://example.com/1" />
+					<ul>
+						<li><a href="http://example.com/2" rel=" nofollow ">2</a></li>
+						<li><a rel="" href="http://example.com/3">3</a></li>
+						<li><a rel="nofollow">4</a></li>
+					</ul>
+				`),
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link: "alternate",
+					Metadata: models.HTMLMetadata{
+						TagName:       "link",
+						AttributeName: "rel",
+						Rel:           "alternate",
+					},
+				},
+				{
+					Link: "http://example.com/1",
+					Metadata: models.HTMLMetadata{
+						TagName:       "link",
+						AttributeName: "href",
+						Rel:           "alternate",
+					},
+				},
+				{
+					Link: "http://example.com/2",
+					Metadata: models.HTMLMetadata{
+						TagName:       "a",
+						AttributeName: "href",
+						Rel:           "nofollow",
+					},
+				},
+				{
+					Link: "http://example.com/3",
+					Metadata: models.HTMLMetadata{
+						TagName:       "a",
+						AttributeName: "href",
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				Filters: htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+					"a": {"href"},
+				}),
+			},
+			args: args{
+				reader: iotest.TimeoutReader(strings.NewReader(`
+					<ul>
+						<li><a href="http://example.com/1">1</a></li>
+						<li><a href="http://example.com/2">2</a></li>
+					</ul>
+				`)),
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			extractor := DefaultExtractor{
 				Filters: data.fields.Filters,
 			}
-			gotLinks := extractor.selectLinks(data.args.data)
+			gotLinks, gotErr := extractor.selectLinks(data.args.reader)
 
 			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
 		})
 	}
 }
+
+func isResponseWithBody(body string) func(response *http.Response) bool {
+	return func(response *http.Response) bool {
+		data, err := ioutil.ReadAll(response.Body)
+		return err == nil && string(data) == body
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
@@ -35,14 +36,33 @@ func (extractor *DelayingExtractor) ExtractLinks(
 	threadID int,
 	link string,
 ) ([]string, error) {
-	if lastExtractionTime, ok := extractor.timestamps.Load(threadID); ok {
-		expiredTime := time.Since(lastExtractionTime.(time.Time))
-		// negative or zero delays should be ignored by the sleeper
-		extractor.sleeper(extractor.minimalDelay - expiredTime)
-	}
+	extractor.delay(threadID)
 
 	links, err := extractor.linkExtractor.ExtractLinks(ctx, threadID, link)
 	extractor.timestamps.Store(threadID, time.Now())
 
 	return links, err
 }
+
+// ExtractRichLinks ...
+func (extractor *DelayingExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	extractor.delay(threadID)
+
+	page, links, err := adapters.LinkExtractorToV2(extractor.linkExtractor).
+		ExtractRichLinks(ctx, threadID, link)
+	extractor.timestamps.Store(threadID, time.Now())
+
+	return page, links, err
+}
+
+func (extractor *DelayingExtractor) delay(threadID int) {
+	if lastExtractionTime, ok := extractor.timestamps.Load(threadID); ok {
+		expiredTime := time.Since(lastExtractionTime.(time.Time))
+		// negative or zero delays should be ignored by the sleeper
+		extractor.sleeper(extractor.minimalDelay - expiredTime)
+	}
+}
@@ -153,3 +153,62 @@ func TestDelayingExtractor_ExtractLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestDelayingExtractor_ExtractRichLinks(test *testing.T) {
+	sleeper := new(MockSleeper)
+	sleeper.
+		On("Sleep", mock.MatchedBy(func(duration time.Duration) bool {
+			return duration <= 100*time.Millisecond
+		})).
+		Return()
+
+	linkExtractor := new(MockRichLinkExtractor)
+	linkExtractor.
+		On(
+			"ExtractRichLinks",
+			context.Background(),
+			23,
+			models.RichLink{Link: "http://example.com/"},
+		).
+		Return(
+			models.RichLink{
+				Link:       "http://example.com/",
+				Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+			},
+			[]models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+			},
+			nil,
+		)
+
+	extractor := NewDelayingExtractor(
+		100*time.Millisecond,
+		sleeper.Sleep,
+		linkExtractor,
+	)
+	extractor.timestamps.Store(23, time.Now())
+
+	gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+		context.Background(),
+		23,
+		models.RichLink{Link: "http://example.com/"},
+	)
+
+	mock.AssertExpectationsForObjects(test, sleeper, linkExtractor)
+	assert.Equal(test, models.RichLink{
+		Link:       "http://example.com/",
+		Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+	}, gotPage)
+	assert.Equal(test, []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Depth:      1,
+		},
+	}, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,53 @@
+package extractors
+
+import (
+	"github.com/pkg/errors"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// ParsingError ...
+//
+// It marks the error of parsing of the already loaded data. It intentionally
+// doesn't implement the Cause() method, so the errors.Cause() function
+// stops on it.
+type ParsingError struct {
+	Err error
+}
+
+// Error ...
+func (err ParsingError) Error() string {
+	return err.Err.Error()
+}
+
+// Unwrap ...
+//
+// It's required for the errors package of the standard library.
+func (err ParsingError) Unwrap() error {
+	return err.Err
+}
+
+// IsRetryable ...
+//
+// The same data will be parsed the same way on the repeated loading,
+// so it always returns false.
+func (err ParsingError) IsRetryable() bool {
+	return false
+}
+
+// ClassifyError ...
+//
+// It decides by the cause of the error (see errors.Cause()); the errors
+// of the unknown causes are considered as the fetching ones.
+func ClassifyError(err error) models.ErrorKind {
+	switch errors.Cause(err).(type) {
+	case StatusCodeError:
+		return models.StatusError
+	case ioutils.TooLargeError:
+		return models.SizeError
+	case ParsingError:
+		return models.ParseError
+	default:
+		return models.FetchError
+	}
+}
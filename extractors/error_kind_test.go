@@ -0,0 +1,64 @@
+package extractors
+
+import (
+	"net/http"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestParsingError(test *testing.T) {
+	err := ParsingError{Err: iotest.ErrTimeout}
+
+	assert.EqualError(test, err, iotest.ErrTimeout.Error())
+	assert.Equal(test, iotest.ErrTimeout, err.Unwrap())
+	assert.False(test, err.IsRetryable())
+}
+
+func TestClassifyError(test *testing.T) {
+	for _, data := range []struct {
+		name string
+		err  error
+		want models.ErrorKind
+	}{
+		{
+			name: "with a status code error",
+			err: errors.Wrap(
+				StatusCodeError{StatusCode: http.StatusNotFound},
+				"unable to accept the response",
+			),
+			want: models.StatusError,
+		},
+		{
+			name: "with a too large error",
+			err: errors.Wrap(
+				ioutils.TooLargeError{MaximalSize: 23},
+				"unable to read the response",
+			),
+			want: models.SizeError,
+		},
+		{
+			name: "with a parsing error",
+			err: errors.Wrap(
+				ParsingError{Err: iotest.ErrTimeout},
+				"unable to select the links",
+			),
+			want: models.ParseError,
+		},
+		{
+			name: "with an other error",
+			err:  errors.Wrap(iotest.ErrTimeout, "unable to send the request"),
+			want: models.FetchError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := ClassifyError(data.err)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
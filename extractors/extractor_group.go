@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
@@ -22,6 +23,64 @@ func (extractors ExtractorGroup) ExtractLinks(
 	threadID int,
 	link string,
 ) ([]string, error) {
+	linkGroups := make([][]string, len(extractors.LinkExtractors))
+	extractors.extractConcurrently(link, func(index int) error {
+		links, err :=
+			extractors.LinkExtractors[index].ExtractLinks(ctx, threadID, link)
+		if err != nil {
+			return err
+		}
+
+		linkGroups[index] = links
+		return nil
+	})
+
+	var totalLinks []string
+	for _, linkGroup := range linkGroups {
+		totalLinks = append(totalLinks, linkGroup...)
+	}
+
+	return totalLinks, nil
+}
+
+// ExtractRichLinks ...
+//
+// The attributes of the pages returned by the link extractors are merged
+// in the order of the link extractors.
+func (extractors ExtractorGroup) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	pages := make([]models.RichLink, len(extractors.LinkExtractors))
+	linkGroups := make([][]models.RichLink, len(extractors.LinkExtractors))
+	extractors.extractConcurrently(link.Link, func(index int) error {
+		page, links, err :=
+			adapters.LinkExtractorToV2(extractors.LinkExtractors[index]).
+				ExtractRichLinks(ctx, threadID, link)
+		if err != nil {
+			return err
+		}
+
+		pages[index], linkGroups[index] = page, links
+		return nil
+	})
+
+	totalPage := link
+	var totalLinks []models.RichLink
+	for index, linkGroup := range linkGroups {
+		totalPage.Attributes = totalPage.Attributes.Merge(pages[index].Attributes)
+		totalLinks = append(totalLinks, linkGroup...)
+	}
+
+	return totalPage, totalLinks, nil
+}
+
+// the failed extractions are only logged
+func (extractors ExtractorGroup) extractConcurrently(
+	link string,
+	extract func(index int) error,
+) {
 	var waiter sync.WaitGroup
 	waiter.Add(len(extractors.LinkExtractors))
 
@@ -30,30 +89,17 @@ func (extractors ExtractorGroup) ExtractLinks(
 		logPrefix = fmt.Sprintf("%s: ", extractors.Name)
 	}
 
-	linkGroups := make([][]string, len(extractors.LinkExtractors))
-	for index, extractor := range extractors.LinkExtractors {
-		go func(index int, extractor models.LinkExtractor) {
+	for index := range extractors.LinkExtractors {
+		go func(index int) {
 			defer waiter.Done()
 
-			links, err := extractor.ExtractLinks(ctx, threadID, link)
-			if err != nil {
+			if err := extract(index); err != nil {
 				const logMessage = "%sunable to extract links for link %q " +
 					"via extractor #%d: %s"
 				extractors.Logger.Logf(logMessage, logPrefix, link, index, err)
-
-				return
 			}
-
-			linkGroups[index] = links
-		}(index, extractor)
+		}(index)
 	}
 
 	waiter.Wait()
-
-	var totalLinks []string
-	for _, linkGroup := range linkGroups {
-		totalLinks = append(totalLinks, linkGroup...)
-	}
-
-	return totalLinks, nil
 }
@@ -244,3 +244,94 @@ func TestExtractorGroup_ExtractLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestExtractorGroup_ExtractRichLinks(test *testing.T) {
+	richLinkExtractor := new(MockRichLinkExtractor)
+	richLinkExtractor.
+		On(
+			"ExtractRichLinks",
+			context.Background(),
+			23,
+			models.RichLink{Link: "http://example.com/"},
+		).
+		Return(
+			models.RichLink{
+				Link:       "http://example.com/",
+				Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+			},
+			[]models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+					Attributes: models.Attributes{}.
+						Set(models.HTMLMetadataAttribute, models.HTMLMetadata{TagName: "a"}),
+				},
+			},
+			nil,
+		)
+
+	linkExtractor := new(MockLinkExtractor)
+	linkExtractor.
+		On("ExtractLinks", context.Background(), 23, "http://example.com/").
+		Return([]string{"http://example.com/2"}, nil)
+
+	failedLinkExtractor := new(MockLinkExtractor)
+	failedLinkExtractor.
+		On("ExtractLinks", context.Background(), 23, "http://example.com/").
+		Return(nil, iotest.ErrTimeout)
+
+	logger := new(MockLogger)
+	logger.
+		On(
+			"Logf",
+			"%sunable to extract links for link %q via extractor #%d: %s",
+			"test extractors: ",
+			"http://example.com/",
+			2,
+			iotest.ErrTimeout,
+		).
+		Return()
+
+	extractors := ExtractorGroup{
+		Name: "test extractors",
+		LinkExtractors: []models.LinkExtractor{
+			richLinkExtractor,
+			linkExtractor,
+			failedLinkExtractor,
+		},
+		Logger: logger,
+	}
+	gotPage, gotLinks, gotErr := extractors.ExtractRichLinks(
+		context.Background(),
+		23,
+		models.RichLink{Link: "http://example.com/"},
+	)
+
+	mock.AssertExpectationsForObjects(
+		test,
+		richLinkExtractor,
+		linkExtractor,
+		failedLinkExtractor,
+		logger,
+	)
+	assert.Equal(test, models.RichLink{
+		Link:       "http://example.com/",
+		Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+	}, gotPage)
+	assert.Equal(test, []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Depth:      1,
+			Attributes: models.Attributes{}.
+				Set(models.HTMLMetadataAttribute, models.HTMLMetadata{TagName: "a"}),
+		},
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/2",
+			Depth:      1,
+		},
+	}, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,72 @@
+package extractors
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers"
+	"github.com/thewizardplusplus/go-crawler/registers/feeds"
+)
+
+// FeedExtractor ...
+type FeedExtractor struct {
+	FeedRegister registers.FeedRegister
+	Logger       log.Logger
+	ErrorHandler models.ErrorHandler // optional
+}
+
+// ExtractLinks ...
+func (extractor FeedExtractor) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) ([]string, error) {
+	feedData, err := extractor.FeedRegister.RegisterFeed(ctx, threadID, link)
+	return extractor.makeLinks(threadID, link, feedData, err), nil
+}
+
+// ExtractResponseLinks ...
+//
+// It parses the feed from the already received response, so it's suitable
+// for the routing by the content type (see the DefaultExtractor.MIMERegistry
+// field).
+func (extractor FeedExtractor) ExtractResponseLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+	response *http.Response,
+) ([]string, error) {
+	feedData, err :=
+		extractor.FeedRegister.RegisterFeedReader(ctx, link, response.Body)
+	return extractor.makeLinks(threadID, link, feedData, err), nil
+}
+
+// the errors are only logged and handled, so it never fails;
+// the links of the feeds loaded despite the error are still returned
+func (extractor FeedExtractor) makeLinks(
+	threadID int,
+	link string,
+	feedData feeds.Feed,
+	err error,
+) []string {
+	if err != nil {
+		const logMessage = "unable to register the feed links for link %q: %s"
+		extractor.Logger.Logf(logMessage, link, err)
+
+		models.HandleError(extractor.ErrorHandler, models.CrawlingError{
+			Kind:     models.FeedError,
+			Link:     models.SourcedLink{Link: link},
+			ThreadID: threadID,
+			Err:      err,
+		})
+	}
+
+	var links []string
+	for _, item := range feedData.Items {
+		links = append(links, item.Link)
+	}
+
+	return links
+}
@@ -0,0 +1,433 @@
+package extractors
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/go-log/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers"
+)
+
+func TestFeedExtractor_ExtractLinks(test *testing.T) {
+	type fields struct {
+		linkGenerator models.LinkExtractor
+		logger        log.Logger
+		linkLoader    LinkLoader
+		errorHandler  models.ErrorHandler
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without feed links",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, nil)
+
+					return linkGenerator
+				}(),
+				logger:     new(MockLogger),
+				linkLoader: new(MockLinkLoader),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success with links",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					feedLinks := []string{
+						"http://example.com/rss.xml",
+						"http://example.com/atom.xml",
+					}
+
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(feedLinks, nil)
+
+					return linkGenerator
+				}(),
+				logger: new(MockLogger),
+				linkLoader: func() LinkLoader {
+					const responseOne = `
+						<?xml version="1.0" encoding="UTF-8" ?>
+						<rss version="2.0">
+							<channel>
+								<item><link>http://example.com/1</link></item>
+								<item><link>http://example.com/2</link></item>
+							</channel>
+						</rss>
+					`
+					const responseTwo = `
+						<?xml version="1.0" encoding="UTF-8" ?>
+						<feed xmlns="http://www.w3.org/2005/Atom">
+							<entry><link href="http://example.com/3" /></entry>
+							<entry><link href="http://example.com/4" /></entry>
+						</feed>
+					`
+
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte(responseOne), nil)
+					linkLoader.
+						On("LoadLink", "http://example.com/atom.xml", context.Background()).
+						Return([]byte(responseTwo), nil)
+
+					return linkLoader
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{
+				"http://example.com/1",
+				"http://example.com/2",
+				"http://example.com/3",
+				"http://example.com/4",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a failed feed and an error handler",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					feedLinks := []string{
+						"http://example.com/rss.xml",
+						"http://example.com/atom.xml",
+					}
+
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(feedLinks, nil)
+
+					return linkGenerator
+				}(),
+				logger: func() Logger {
+					logger := new(MockLogger)
+					logger.On(
+						"Logf",
+						"unable to load feed link %q: %s",
+						"http://example.com/atom.xml",
+						mock.AnythingOfType("*errors.withStack"),
+					).Return()
+					logger.On(
+						"Logf",
+						"unable to register the feed links for link %q: %s",
+						"http://example.com/",
+						mock.AnythingOfType("*errors.withStack"),
+					).Return()
+
+					return logger
+				}(),
+				linkLoader: func() LinkLoader {
+					const response = `
+						<?xml version="1.0" encoding="UTF-8" ?>
+						<rss version="2.0">
+							<channel>
+								<item><link>http://example.com/1</link></item>
+							</channel>
+						</rss>
+					`
+
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte(response), nil)
+					linkLoader.
+						On("LoadLink", "http://example.com/atom.xml", context.Background()).
+						Return(nil, iotest.ErrTimeout)
+
+					return linkLoader
+				}(),
+				errorHandler: func() ErrorHandler {
+					errorHandler := new(MockErrorHandler)
+					errorHandler.On(
+						"HandleError",
+						mock.MatchedBy(func(gotErr models.CrawlingError) bool {
+							return gotErr.Kind == models.FeedError &&
+								gotErr.Link == models.SourcedLink{Link: "http://example.com/"} &&
+								gotErr.ThreadID == 23 &&
+								errors.Cause(gotErr.Err) == iotest.ErrTimeout
+						}),
+					).Return()
+
+					return errorHandler
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with generation",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout)
+
+					return linkGenerator
+				}(),
+				logger: func() Logger {
+					wantErr :=
+						errors.Wrap(iotest.ErrTimeout, "unable to generate feed links")
+
+					logger := new(MockLogger)
+					logger.On(
+						"Logf",
+						"unable to register the feed links for link %q: %s",
+						"http://example.com/",
+						mock.MatchedBy(func(gotErr error) bool {
+							return gotErr.Error() == wantErr.Error()
+						}),
+					).Return()
+
+					return logger
+				}(),
+				linkLoader: new(MockLinkLoader),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with generation and an error handler",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout)
+
+					return linkGenerator
+				}(),
+				logger: func() Logger {
+					logger := new(MockLogger)
+					logger.On(
+						"Logf",
+						"unable to register the feed links for link %q: %s",
+						"http://example.com/",
+						mock.AnythingOfType("*errors.withStack"),
+					).Return()
+
+					return logger
+				}(),
+				linkLoader: new(MockLinkLoader),
+				errorHandler: func() ErrorHandler {
+					wantErr :=
+						errors.Wrap(iotest.ErrTimeout, "unable to generate feed links")
+
+					errorHandler := new(MockErrorHandler)
+					errorHandler.On(
+						"HandleError",
+						mock.MatchedBy(func(gotErr models.CrawlingError) bool {
+							return gotErr.Kind == models.FeedError &&
+								gotErr.Link == models.SourcedLink{Link: "http://example.com/"} &&
+								gotErr.ThreadID == 23 &&
+								gotErr.Err.Error() == wantErr.Error()
+						}),
+					).Return()
+
+					return errorHandler
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := registers.NewFeedRegister(
+				data.fields.linkGenerator,
+				data.fields.logger,
+				data.fields.linkLoader.LoadLink,
+			)
+			extractor := FeedExtractor{
+				FeedRegister: register,
+				Logger:       data.fields.logger,
+				ErrorHandler: data.fields.errorHandler,
+			}
+			gotLinks, gotErr := extractor.ExtractLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			mock.AssertExpectationsForObjects(
+				test,
+				data.fields.linkGenerator,
+				data.fields.logger,
+				data.fields.linkLoader,
+			)
+			if data.fields.errorHandler != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.errorHandler)
+			}
+			assert.ElementsMatch(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestFeedExtractor_ExtractResponseLinks(test *testing.T) {
+	type fields struct {
+		logger       log.Logger
+		errorHandler models.ErrorHandler
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+		response *http.Response
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				logger: new(MockLogger),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/rss.xml",
+				response: &http.Response{
+					Body: ioutil.NopCloser(strings.NewReader(`
+						<?xml version="1.0" encoding="UTF-8" ?>
+						<rss version="2.0">
+							<channel>
+								<item><link>http://example.com/1</link></item>
+								<item><link>/2</link></item>
+							</channel>
+						</rss>
+					`)),
+				},
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with an error handler",
+			fields: fields{
+				logger: func() Logger {
+					logger := new(MockLogger)
+					logger.On(
+						"Logf",
+						"unable to register the feed links for link %q: %s",
+						"http://example.com/rss.xml",
+						mock.AnythingOfType("*errors.withStack"),
+					).Return()
+
+					return logger
+				}(),
+				errorHandler: func() ErrorHandler {
+					errorHandler := new(MockErrorHandler)
+					errorHandler.On(
+						"HandleError",
+						mock.MatchedBy(func(gotErr models.CrawlingError) bool {
+							return gotErr.Kind == models.FeedError &&
+								gotErr.Link == models.SourcedLink{Link: "http://example.com/rss.xml"} &&
+								gotErr.ThreadID == 23 &&
+								gotErr.Err != nil
+						}),
+					).Return()
+
+					return errorHandler
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/rss.xml",
+				response: &http.Response{
+					Body: ioutil.NopCloser(iotest.TimeoutReader(strings.NewReader("data"))),
+				},
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			// the link generator and the link loader aren't used
+			// for the already received response
+			linkGenerator, linkLoader := new(MockLinkExtractor), new(MockLinkLoader)
+			register := registers.NewFeedRegister(
+				linkGenerator,
+				data.fields.logger,
+				linkLoader.LoadLink,
+			)
+			extractor := FeedExtractor{
+				FeedRegister: register,
+				Logger:       data.fields.logger,
+				ErrorHandler: data.fields.errorHandler,
+			}
+			gotLinks, gotErr := extractor.ExtractResponseLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+				data.args.response,
+			)
+
+			mock.AssertExpectationsForObjects(
+				test,
+				linkGenerator,
+				linkLoader,
+				data.fields.logger,
+			)
+			if data.fields.errorHandler != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.errorHandler)
+			}
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
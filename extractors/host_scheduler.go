@@ -0,0 +1,97 @@
+package extractors
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type hostState struct {
+	// it's used as a semaphore limiting the concurrent requests to the host
+	requestSlots chan struct{}
+
+	locker       sync.Mutex
+	nextTimeSlot time.Time
+}
+
+// HostScheduler ...
+type HostScheduler struct {
+	minimalDelay       time.Duration
+	maximalConcurrency int
+
+	hostStates sync.Map // map[host]*hostState
+}
+
+// NewHostScheduler ...
+func NewHostScheduler(
+	minimalDelay time.Duration,
+	maximalConcurrency int,
+) *HostScheduler {
+	// a non-positive concurrency would block all requests forever
+	if maximalConcurrency < 1 {
+		maximalConcurrency = 1
+	}
+
+	return &HostScheduler{
+		minimalDelay:       minimalDelay,
+		maximalConcurrency: maximalConcurrency,
+	}
+}
+
+// ScheduleRequest ...
+//
+// It waits until a request to the host is allowed. The delay is the minimal
+// one between request starts; the minimal delay of the scheduler is used
+// if it's greater.
+//
+// After the request is completed, the returned release function
+// should be called.
+func (scheduler *HostScheduler) ScheduleRequest(
+	ctx context.Context,
+	host string,
+	delay time.Duration,
+) (
+	release func(),
+	err error,
+) {
+	state := scheduler.loadHostState(host)
+	select {
+	case state.requestSlots <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	releaseSlot := func() { <-state.requestSlots }
+	if delay < scheduler.minimalDelay {
+		delay = scheduler.minimalDelay
+	}
+
+	if err := SleepWithContext(ctx, state.reserveTimeSlot(delay)); err != nil {
+		releaseSlot()
+		return nil, err
+	}
+
+	return releaseSlot, nil
+}
+
+func (scheduler *HostScheduler) loadHostState(host string) *hostState {
+	state, _ := scheduler.hostStates.LoadOrStore(host, &hostState{
+		requestSlots: make(chan struct{}, scheduler.maximalConcurrency),
+	})
+	return state.(*hostState)
+}
+
+// it returns a waiting time until the reserved time slot
+func (state *hostState) reserveTimeSlot(delay time.Duration) time.Duration {
+	state.locker.Lock()
+	defer state.locker.Unlock()
+
+	now := time.Now()
+	timeSlot := state.nextTimeSlot
+	if timeSlot.Before(now) {
+		timeSlot = now
+	}
+
+	state.nextTimeSlot = timeSlot.Add(delay)
+	return timeSlot.Sub(now)
+}
@@ -0,0 +1,162 @@
+package extractors
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHostScheduler(test *testing.T) {
+	for _, data := range []struct {
+		name                   string
+		maximalConcurrency     int
+		wantMaximalConcurrency int
+	}{
+		{
+			name:                   "with a positive concurrency",
+			maximalConcurrency:     23,
+			wantMaximalConcurrency: 23,
+		},
+		{
+			name:                   "with a non-positive concurrency",
+			maximalConcurrency:     0,
+			wantMaximalConcurrency: 1,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := NewHostScheduler(100*time.Millisecond, data.maximalConcurrency)
+
+			require.NotNil(test, got)
+			assert.Equal(test, 100*time.Millisecond, got.minimalDelay)
+			assert.Equal(test, data.wantMaximalConcurrency, got.maximalConcurrency)
+		})
+	}
+}
+
+func TestHostScheduler_ScheduleRequest(test *testing.T) {
+	test.Run("with the minimal delay", func(test *testing.T) {
+		scheduler := NewHostScheduler(50*time.Millisecond, 10)
+
+		startTime := time.Now()
+		for index := 0; index < 3; index++ {
+			release, err :=
+				scheduler.ScheduleRequest(context.Background(), "example.com", 0)
+			require.NoError(test, err)
+
+			release()
+		}
+
+		// the first request isn't delayed
+		assert.True(test, time.Since(startTime) >= 100*time.Millisecond)
+	})
+
+	test.Run("with a delay greater than the minimal one", func(test *testing.T) {
+		scheduler := NewHostScheduler(10*time.Millisecond, 10)
+
+		startTime := time.Now()
+		for index := 0; index < 3; index++ {
+			release, err := scheduler.ScheduleRequest(
+				context.Background(),
+				"example.com",
+				50*time.Millisecond,
+			)
+			require.NoError(test, err)
+
+			release()
+		}
+
+		assert.True(test, time.Since(startTime) >= 100*time.Millisecond)
+	})
+
+	test.Run("with different hosts", func(test *testing.T) {
+		scheduler := NewHostScheduler(time.Second, 10)
+
+		startTime := time.Now()
+		for _, host := range []string{"example1.com", "example2.com"} {
+			release, err :=
+				scheduler.ScheduleRequest(context.Background(), host, 0)
+			require.NoError(test, err)
+
+			release()
+		}
+
+		assert.True(test, time.Since(startTime) < time.Second)
+	})
+
+	test.Run("with the maximal concurrency", func(test *testing.T) {
+		scheduler := NewHostScheduler(0, 2)
+
+		var waiter sync.WaitGroup
+		waiter.Add(10)
+
+		var activeRequestCount, maximalActiveRequestCount int
+		var locker sync.Mutex
+		for index := 0; index < 10; index++ {
+			go func() {
+				defer waiter.Done()
+
+				release, err :=
+					scheduler.ScheduleRequest(context.Background(), "example.com", 0)
+				if !assert.NoError(test, err) {
+					return
+				}
+				defer release()
+
+				locker.Lock()
+				activeRequestCount++
+				if activeRequestCount > maximalActiveRequestCount {
+					maximalActiveRequestCount = activeRequestCount
+				}
+				locker.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				locker.Lock()
+				activeRequestCount--
+				locker.Unlock()
+			}()
+		}
+
+		waiter.Wait()
+
+		assert.Equal(test, 2, maximalActiveRequestCount)
+	})
+
+	test.Run("with cancellation on the delay", func(test *testing.T) {
+		scheduler := NewHostScheduler(time.Hour, 10)
+		release, err :=
+			scheduler.ScheduleRequest(context.Background(), "example.com", 0)
+		require.NoError(test, err)
+		release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		release, err = scheduler.ScheduleRequest(ctx, "example.com", 0)
+
+		assert.Nil(test, release)
+		assert.Equal(test, context.DeadlineExceeded, err)
+		// the request slot should be released
+		assert.Len(test, scheduler.loadHostState("example.com").requestSlots, 0)
+	})
+
+	test.Run("with cancellation on the concurrency", func(test *testing.T) {
+		scheduler := NewHostScheduler(0, 1)
+		release, err :=
+			scheduler.ScheduleRequest(context.Background(), "example.com", 0)
+		require.NoError(test, err)
+		defer release()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		release2, err := scheduler.ScheduleRequest(ctx, "example.com", 0)
+
+		assert.Nil(test, release2)
+		assert.Equal(test, context.DeadlineExceeded, err)
+	})
+}
@@ -0,0 +1,83 @@
+package extractors
+
+import (
+	"strings"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+	htmlselector "github.com/thewizardplusplus/go-html-selector"
+)
+
+// it collects the selected links with their HTML metadata; the rel attribute
+// is selected in addition to the filtered ones, but it's treated as a link
+// only if it's filtered
+type htmlLinkBuilder struct {
+	filters htmlselector.OptimizedFilterGroup
+
+	links        []models.HTMLLink
+	tagName      string
+	tagLinkIndex int
+	tagRel       string
+}
+
+func newHTMLLinkBuilder(
+	filters htmlselector.OptimizedFilterGroup,
+) *htmlLinkBuilder {
+	return &htmlLinkBuilder{filters: filters}
+}
+
+// it returns the filters for the htmlselector.SelectTags() function
+func (builder *htmlLinkBuilder) selectionFilters() (
+	selectionFilters htmlselector.OptimizedFilterGroup,
+) {
+	selectionFilters = make(htmlselector.OptimizedFilterGroup)
+	for tagName, attributeFilter := range builder.filters {
+		selectionAttributeFilter := map[string]struct{}{"rel": {}}
+		for attributeName := range attributeFilter {
+			selectionAttributeFilter[attributeName] = struct{}{}
+		}
+
+		selectionFilters[tagName] = selectionAttributeFilter
+	}
+
+	return selectionFilters
+}
+
+func (builder *htmlLinkBuilder) AddTag(name []byte) {
+	builder.finishTag()
+
+	builder.tagName = string(name)
+	builder.tagLinkIndex = len(builder.links)
+	builder.tagRel = ""
+}
+
+func (builder *htmlLinkBuilder) AddAttribute(name []byte, value []byte) {
+	attributeName := string(name)
+	if attributeName == "rel" {
+		builder.tagRel = strings.TrimSpace(string(value))
+	}
+
+	if _, ok := builder.filters[builder.tagName][attributeName]; !ok {
+		return
+	}
+
+	builder.links = append(builder.links, models.HTMLLink{
+		Link: string(value),
+		Metadata: models.HTMLMetadata{
+			TagName:       builder.tagName,
+			AttributeName: attributeName,
+		},
+	})
+}
+
+func (builder *htmlLinkBuilder) htmlLinks() []models.HTMLLink {
+	builder.finishTag()
+	return builder.links
+}
+
+// the rel attribute may follow the link attributes,
+// so it's set to the links of the tag only after all its attributes
+func (builder *htmlLinkBuilder) finishTag() {
+	for index := builder.tagLinkIndex; index < len(builder.links); index++ {
+		builder.links[index].Metadata.Rel = builder.tagRel
+	}
+}
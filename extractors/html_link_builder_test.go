@@ -0,0 +1,23 @@
+package extractors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	htmlselector "github.com/thewizardplusplus/go-html-selector"
+)
+
+func TestHTMLLinkBuilder_selectionFilters(test *testing.T) {
+	builder := newHTMLLinkBuilder(htmlselector.OptimizedFilterGroup{
+		"a":    {"href": {}},
+		"link": {"href": {}, "rel": {}},
+	})
+	got := builder.selectionFilters()
+
+	want := htmlselector.OptimizedFilterGroup{
+		"a":    {"href": {}, "rel": {}},
+		"link": {"href": {}, "rel": {}},
+	}
+	assert.Equal(test, want, got)
+	assert.Equal(test, map[string]struct{}{"href": {}}, builder.filters["a"])
+}
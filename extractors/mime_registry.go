@@ -0,0 +1,37 @@
+package extractors
+
+import (
+	"mime"
+	"strings"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// MIMERegistry ...
+//
+// It maps the MIME types to the link extractors. The "type/*" keys
+// are supported as a fallback for all subtypes of the type.
+type MIMERegistry map[string]models.ResponseLinkExtractor
+
+// Lookup ...
+//
+// It accepts a value of the Content-Type header, so it ignores parameters
+// of the MIME type.
+func (registry MIMERegistry) Lookup(
+	contentType string,
+) (extractor models.ResponseLinkExtractor, ok bool) {
+	mimeType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+
+	if extractor, ok := registry[mimeType]; ok {
+		return extractor, true
+	}
+
+	if index := strings.IndexByte(mimeType, '/'); index != -1 {
+		extractor, ok = registry[mimeType[:index]+"/*"]
+	}
+
+	return extractor, ok
+}
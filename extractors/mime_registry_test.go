@@ -0,0 +1,68 @@
+package extractors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestMIMERegistry_Lookup(test *testing.T) {
+	htmlExtractor := new(MockResponseLinkExtractor)
+	feedExtractor := new(MockResponseLinkExtractor)
+	textExtractor := new(MockResponseLinkExtractor)
+	registry := MIMERegistry{
+		"text/html":           htmlExtractor,
+		"application/rss+xml": feedExtractor,
+		"text/*":              textExtractor,
+	}
+
+	for _, data := range []struct {
+		name          string
+		contentType   string
+		wantExtractor models.ResponseLinkExtractor
+		wantOk        assert.BoolAssertionFunc
+	}{
+		{
+			name:          "with an exact match",
+			contentType:   "text/html; charset=utf-8",
+			wantExtractor: htmlExtractor,
+			wantOk:        assert.True,
+		},
+		{
+			name:          "with an exact match in the upper case",
+			contentType:   "Application/RSS+XML",
+			wantExtractor: feedExtractor,
+			wantOk:        assert.True,
+		},
+		{
+			name:          "with a wildcard match",
+			contentType:   "text/plain",
+			wantExtractor: textExtractor,
+			wantOk:        assert.True,
+		},
+		{
+			name:          "without a match",
+			contentType:   "application/pdf",
+			wantExtractor: nil,
+			wantOk:        assert.False,
+		},
+		{
+			name:          "with an incorrect content type",
+			contentType:   "",
+			wantExtractor: nil,
+			wantOk:        assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotExtractor, gotOk := registry.Lookup(data.contentType)
+
+			if data.wantExtractor != nil {
+				assert.Same(test, data.wantExtractor, gotExtractor)
+			} else {
+				assert.Nil(test, gotExtractor)
+			}
+			data.wantOk(test, gotOk)
+		})
+	}
+}
@@ -28,6 +28,27 @@ type LinkExtractor interface {
 	models.LinkExtractor
 }
 
+//go:generate mockery --name=RichLinkExtractor --inpackage --case=underscore --testonly
+
+// RichLinkExtractor ...
+//
+// It's used only for mock generating.
+//
+type RichLinkExtractor interface {
+	models.LinkExtractor
+	models.LinkExtractorV2
+}
+
+//go:generate mockery --name=ResponseLinkExtractor --inpackage --case=underscore --testonly
+
+// ResponseLinkExtractor ...
+//
+// It's used only for mock generating.
+//
+type ResponseLinkExtractor interface {
+	models.ResponseLinkExtractor
+}
+
 //go:generate mockery --name=LinkTransformer --inpackage --case=underscore --testonly
 
 // LinkTransformer ...
@@ -67,3 +88,13 @@ type Sleeper interface {
 type LinkLoader interface {
 	LoadLink(link string, options interface{}) ([]byte, error)
 }
+
+//go:generate mockery --name=ErrorHandler --inpackage --case=underscore --testonly
+
+// ErrorHandler ...
+//
+// It's used only for mock generating.
+//
+type ErrorHandler interface {
+	models.ErrorHandler
+}
@@ -2,22 +2,41 @@ package extractors
 
 import (
 	"context"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-log/log"
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
 // SleepHandler ...
 type SleepHandler func(duration time.Duration)
 
+// ErrorClassifier ...
+//
+// It should return true if the extraction may be repeated after the error.
+type ErrorClassifier func(err error) bool
+
 // RepeatingExtractor ...
+//
+// It doesn't repeat the extraction on the non-retryable errors
+// (see IsRetryableError()). On the 429 and 503 status codes,
+// it waits at least the delay specified in the Retry-After header.
 type RepeatingExtractor struct {
 	LinkExtractor models.LinkExtractor
 	RepeatCount   int
-	RepeatDelay   time.Duration
-	Logger        log.Logger
-	SleepHandler  SleepHandler
+	// it's ignored if the BackoffStrategy field is set
+	RepeatDelay time.Duration
+	Logger      log.Logger
+	// optional; it ignores the context, so SleepWithContext() is used by default
+	SleepHandler SleepHandler
+	// optional; ConstantBackoff with the RepeatDelay field is used by default
+	BackoffStrategy BackoffStrategy
+	// optional; IsRetryableError() is used by default
+	ErrorClassifier ErrorClassifier
 }
 
 // ExtractLinks ...
@@ -27,21 +46,123 @@ func (extractor RepeatingExtractor) ExtractLinks(
 	link string,
 ) ([]string, error) {
 	var links []string
-	for repeat := 0; repeat < extractor.RepeatCount; repeat++ {
-		var err error
+	err := extractor.repeat(ctx, link, func() (err error) {
 		links, err = extractor.LinkExtractor.ExtractLinks(ctx, threadID, link)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// ExtractRichLinks ...
+func (extractor RepeatingExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	linkExtractor := adapters.LinkExtractorToV2(extractor.LinkExtractor)
+
+	var page models.RichLink
+	var links []models.RichLink
+	err := extractor.repeat(ctx, link.Link, func() (err error) {
+		page, links, err = linkExtractor.ExtractRichLinks(ctx, threadID, link)
+		return err
+	})
+	if err != nil {
+		return models.RichLink{}, nil, err
+	}
+
+	return page, links, nil
+}
+
+func (extractor RepeatingExtractor) repeat(
+	ctx context.Context,
+	link string,
+	extract func() error,
+) error {
+	for repeat := 0; repeat < extractor.RepeatCount; repeat++ {
+		err := extract()
 		if err == nil {
 			break
 		}
-		if repeat == extractor.RepeatCount-1 {
-			return nil, err
+		if repeat == extractor.RepeatCount-1 || !extractor.isRetryableError(err) {
+			return err
 		}
 
 		const logMessage = "unable to extract links for link %q (repeat #%d): %s"
 		extractor.Logger.Logf(logMessage, link, repeat, err)
 
-		extractor.SleepHandler(extractor.RepeatDelay)
+		if err := extractor.sleep(ctx, extractor.delay(repeat, err)); err != nil {
+			return errors.Wrap(err, "unable to wait for the repeat")
+		}
 	}
 
-	return links, nil
+	return nil
+}
+
+func (extractor RepeatingExtractor) isRetryableError(err error) bool {
+	if extractor.ErrorClassifier == nil {
+		return IsRetryableError(err)
+	}
+
+	return extractor.ErrorClassifier(err)
+}
+
+func (extractor RepeatingExtractor) delay(
+	repeat int,
+	err error,
+) time.Duration {
+	var delay time.Duration
+	if extractor.BackoffStrategy != nil {
+		delay = extractor.BackoffStrategy.Delay(repeat)
+	} else {
+		delay = extractor.RepeatDelay
+	}
+
+	if retryAfter, ok := parseRetryAfter(err); ok && retryAfter > delay {
+		delay = retryAfter
+	}
+
+	return delay
+}
+
+func (extractor RepeatingExtractor) sleep(
+	ctx context.Context,
+	duration time.Duration,
+) error {
+	if extractor.SleepHandler == nil {
+		return SleepWithContext(ctx, duration)
+	}
+
+	extractor.SleepHandler(duration)
+	return nil
+}
+
+func parseRetryAfter(err error) (time.Duration, bool) {
+	statusCodeErr, ok := errors.Cause(err).(StatusCodeError)
+	if !ok {
+		return 0, false
+	}
+	if statusCodeErr.StatusCode != http.StatusTooManyRequests &&
+		statusCodeErr.StatusCode != http.StatusServiceUnavailable {
+		return 0, false
+	}
+
+	// the header may contain either a delay in seconds or an HTTP date
+	retryAfter := statusCodeErr.Header.Get("Retry-After")
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(retryAfter); err == nil {
+		if delay := time.Until(date); delay > 0 {
+			return delay, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
 }
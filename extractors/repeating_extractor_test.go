@@ -2,11 +2,13 @@ package extractors
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"testing/iotest"
 	"time"
 
 	"github.com/go-log/log"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/go-crawler/models"
@@ -14,11 +16,13 @@ import (
 
 func TestRepeatingExtractor_ExtractLinks(test *testing.T) {
 	type fields struct {
-		LinkExtractor models.LinkExtractor
-		RepeatCount   int
-		RepeatDelay   time.Duration
-		Logger        log.Logger
-		Sleeper       Sleeper
+		LinkExtractor   models.LinkExtractor
+		RepeatCount     int
+		RepeatDelay     time.Duration
+		Logger          log.Logger
+		Sleeper         Sleeper
+		BackoffStrategy BackoffStrategy
+		ErrorClassifier ErrorClassifier
 	}
 	type args struct {
 		ctx      context.Context
@@ -169,6 +173,139 @@ func TestRepeatingExtractor_ExtractLinks(test *testing.T) {
 			wantLinks: nil,
 			wantErr:   assert.Error,
 		},
+		{
+			name: "error with a non-retryable error",
+			fields: fields{
+				LinkExtractor: func() models.LinkExtractor {
+					err := errors.Wrap(
+						StatusCodeError{StatusCode: http.StatusNotFound},
+						"unable to load the data",
+					)
+
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, err).
+						Times(1)
+
+					return extractor
+				}(),
+				RepeatCount: 5,
+				RepeatDelay: 100 * time.Millisecond,
+				Logger:      new(MockLogger),
+				Sleeper:     new(MockSleeper),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+		{
+			name: "success with a backoff strategy and the Retry-After header",
+			fields: fields{
+				LinkExtractor: func() models.LinkExtractor {
+					errs := []error{
+						errors.Wrap(
+							StatusCodeError{
+								StatusCode: http.StatusServiceUnavailable,
+								Header:     http.Header{"Retry-After": {"1"}},
+								Retryable:  true,
+							},
+							"unable to load the data",
+						),
+						iotest.ErrTimeout,
+						nil,
+					}
+
+					var repeat int
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(
+							func(context.Context, int, string) []string {
+								if repeat < 2 {
+									return nil
+								}
+
+								return []string{"http://example.com/1", "http://example.com/2"}
+							},
+							func(context.Context, int, string) error {
+								defer func() { repeat++ }()
+								return errs[repeat]
+							},
+						).
+						Times(3)
+
+					return extractor
+				}(),
+				RepeatCount: 5,
+				RepeatDelay: 0,
+				Logger: func() Logger {
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"unable to extract links for link %q (repeat #%d): %s",
+							"http://example.com/",
+							mock.AnythingOfType("int"),
+							mock.Anything,
+						).
+						Return().
+						Times(2)
+
+					return logger
+				}(),
+				Sleeper: func() Sleeper {
+					sleeper := new(MockSleeper)
+					sleeper.On("Sleep", time.Second).Return().Times(1)
+					sleeper.On("Sleep", 200*time.Millisecond).Return().Times(1)
+
+					return sleeper
+				}(),
+				BackoffStrategy: ExponentialBackoff{
+					InitialInterval: 100 * time.Millisecond,
+					Multiplier:      2,
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with an error classifier",
+			fields: fields{
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout).
+						Times(1)
+
+					return extractor
+				}(),
+				RepeatCount: 5,
+				RepeatDelay: 100 * time.Millisecond,
+				Logger:      new(MockLogger),
+				Sleeper:     new(MockSleeper),
+				ErrorClassifier: func(err error) bool {
+					return err != iotest.ErrTimeout
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			extractor := RepeatingExtractor{
@@ -177,6 +314,9 @@ func TestRepeatingExtractor_ExtractLinks(test *testing.T) {
 				RepeatDelay:   data.fields.RepeatDelay,
 				Logger:        data.fields.Logger,
 				SleepHandler:  data.fields.Sleeper.Sleep,
+
+				BackoffStrategy: data.fields.BackoffStrategy,
+				ErrorClassifier: data.fields.ErrorClassifier,
 			}
 			gotLinks, gotErr := extractor.ExtractLinks(
 				data.args.ctx,
@@ -195,3 +335,221 @@ func TestRepeatingExtractor_ExtractLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestRepeatingExtractor_ExtractLinks_withCancellation(test *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	linkExtractor := new(MockLinkExtractor)
+	linkExtractor.
+		On("ExtractLinks", ctx, 23, "http://example.com/").
+		Return(nil, iotest.ErrTimeout).
+		Run(func(mock.Arguments) { cancel() }).
+		Times(1)
+
+	logger := new(MockLogger)
+	logger.
+		On(
+			"Logf",
+			"unable to extract links for link %q (repeat #%d): %s",
+			"http://example.com/",
+			0,
+			iotest.ErrTimeout,
+		).
+		Return().
+		Times(1)
+
+	extractor := RepeatingExtractor{
+		LinkExtractor: linkExtractor,
+		RepeatCount:   5,
+		RepeatDelay:   time.Hour,
+		Logger:        logger,
+	}
+	gotLinks, gotErr :=
+		extractor.ExtractLinks(ctx, 23, "http://example.com/")
+
+	mock.AssertExpectationsForObjects(test, linkExtractor, logger)
+	assert.Nil(test, gotLinks)
+	assert.Equal(test, context.Canceled, errors.Cause(gotErr))
+}
+
+func Test_parseRetryAfter(test *testing.T) {
+	for _, data := range []struct {
+		name      string
+		err       error
+		wantDelay time.Duration
+		wantOk    assert.BoolAssertionFunc
+	}{
+		{
+			name:      "with an unclassified error",
+			err:       iotest.ErrTimeout,
+			wantDelay: 0,
+			wantOk:    assert.False,
+		},
+		{
+			name: "with an unsupported status code",
+			err: StatusCodeError{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Retry-After": {"23"}},
+			},
+			wantDelay: 0,
+			wantOk:    assert.False,
+		},
+		{
+			name: "with the delay in seconds",
+			err: errors.Wrap(
+				StatusCodeError{
+					StatusCode: http.StatusTooManyRequests,
+					Header:     http.Header{"Retry-After": {"23"}},
+				},
+				"unable to load the data",
+			),
+			wantDelay: 23 * time.Second,
+			wantOk:    assert.True,
+		},
+		{
+			name: "with the date in the past",
+			err: StatusCodeError{
+				StatusCode: http.StatusServiceUnavailable,
+				Header: http.Header{
+					"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"},
+				},
+			},
+			wantDelay: 0,
+			wantOk:    assert.True,
+		},
+		{
+			name: "with an incorrect header",
+			err: StatusCodeError{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": {"incorrect"}},
+			},
+			wantDelay: 0,
+			wantOk:    assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotDelay, gotOk := parseRetryAfter(data.err)
+
+			assert.Equal(test, data.wantDelay, gotDelay)
+			data.wantOk(test, gotOk)
+		})
+	}
+}
+
+func TestRepeatingExtractor_ExtractRichLinks(test *testing.T) {
+	page := models.RichLink{
+		Link:       "http://example.com/",
+		Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+	}
+	richLinks := []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Depth:      1,
+		},
+	}
+
+	for _, data := range []struct {
+		name          string
+		linkExtractor func() *MockRichLinkExtractor
+		logger        func() *MockLogger
+		wantPage      models.RichLink
+		wantLinks     []models.RichLink
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success on the last repeat",
+			linkExtractor: func() *MockRichLinkExtractor {
+				extractor := new(MockRichLinkExtractor)
+				extractor.
+					On(
+						"ExtractRichLinks",
+						context.Background(),
+						23,
+						models.RichLink{Link: "http://example.com/"},
+					).
+					Return(models.RichLink{}, nil, iotest.ErrTimeout).
+					Once()
+				extractor.
+					On(
+						"ExtractRichLinks",
+						context.Background(),
+						23,
+						models.RichLink{Link: "http://example.com/"},
+					).
+					Return(page, richLinks, nil).
+					Once()
+
+				return extractor
+			},
+			logger: func() *MockLogger {
+				logger := new(MockLogger)
+				logger.
+					On(
+						"Logf",
+						"unable to extract links for link %q (repeat #%d): %s",
+						"http://example.com/",
+						0,
+						iotest.ErrTimeout,
+					).
+					Return()
+
+				return logger
+			},
+			wantPage:  page,
+			wantLinks: richLinks,
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error with a non-retryable error",
+			linkExtractor: func() *MockRichLinkExtractor {
+				err := errors.Wrap(
+					StatusCodeError{StatusCode: http.StatusNotFound},
+					"unable to load the data",
+				)
+
+				extractor := new(MockRichLinkExtractor)
+				extractor.
+					On(
+						"ExtractRichLinks",
+						context.Background(),
+						23,
+						models.RichLink{Link: "http://example.com/"},
+					).
+					Return(models.RichLink{}, nil, err).
+					Once()
+
+				return extractor
+			},
+			logger:    func() *MockLogger { return new(MockLogger) },
+			wantPage:  models.RichLink{},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			linkExtractor, logger := data.linkExtractor(), data.logger()
+			sleeper := new(MockSleeper)
+			sleeper.On("Sleep", 100*time.Millisecond).Return().Maybe()
+
+			extractor := RepeatingExtractor{
+				LinkExtractor: linkExtractor,
+				RepeatCount:   2,
+				RepeatDelay:   100 * time.Millisecond,
+				Logger:        logger,
+				SleepHandler:  sleeper.Sleep,
+			}
+			gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+				context.Background(),
+				23,
+				models.RichLink{Link: "http://example.com/"},
+			)
+
+			mock.AssertExpectationsForObjects(test, linkExtractor, logger, sleeper)
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package extractors
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/adapters"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// SchedulingExtractor ...
+//
+// Unlike the DelayingExtractor, it limits requests to each host
+// across all threads.
+type SchedulingExtractor struct {
+	HostScheduler *HostScheduler
+	LinkExtractor models.LinkExtractor
+}
+
+// ExtractLinks ...
+func (extractor SchedulingExtractor) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) ([]string, error) {
+	return extractor.extractLinksWithDelay(ctx, threadID, link, 0)
+}
+
+// ExtractRichLinks ...
+func (extractor SchedulingExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	return extractor.extractRichLinksWithDelay(ctx, threadID, link, 0)
+}
+
+func (extractor SchedulingExtractor) extractLinksWithDelay(
+	ctx context.Context,
+	threadID int,
+	link string,
+	delay time.Duration,
+) ([]string, error) {
+	release, err := extractor.scheduleRequest(ctx, link, delay)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return extractor.LinkExtractor.ExtractLinks(ctx, threadID, link)
+}
+
+func (extractor SchedulingExtractor) extractRichLinksWithDelay(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+	delay time.Duration,
+) (models.RichLink, []models.RichLink, error) {
+	release, err := extractor.scheduleRequest(ctx, link.Link, delay)
+	if err != nil {
+		return models.RichLink{}, nil, err
+	}
+	defer release()
+
+	return adapters.LinkExtractorToV2(extractor.LinkExtractor).
+		ExtractRichLinks(ctx, threadID, link)
+}
+
+func (extractor SchedulingExtractor) scheduleRequest(
+	ctx context.Context,
+	link string,
+	delay time.Duration,
+) (release func(), err error) {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to parse the link")
+	}
+
+	release, err =
+		extractor.HostScheduler.ScheduleRequest(ctx, parsedLink.Host, delay)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to schedule the request")
+	}
+
+	return release, nil
+}
@@ -0,0 +1,191 @@
+package extractors
+
+import (
+	"context"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestSchedulingExtractor_ExtractLinks(test *testing.T) {
+	type fields struct {
+		HostScheduler *HostScheduler
+		LinkExtractor models.LinkExtractor
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "error on link parsing",
+			fields: fields{
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: new(MockLinkExtractor),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     ":",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+		{
+			name: "error on request scheduling",
+			fields: fields{
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: new(MockLinkExtractor),
+			},
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+		{
+			name: "error on link extracting",
+			fields: fields{
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := SchedulingExtractor{
+				HostScheduler: data.fields.HostScheduler,
+				LinkExtractor: data.fields.LinkExtractor,
+			}
+			gotLinks, gotErr :=
+				extractor.ExtractLinks(data.args.ctx, data.args.threadID, data.args.link)
+
+			mock.AssertExpectationsForObjects(test, data.fields.LinkExtractor)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestSchedulingExtractor_ExtractRichLinks(test *testing.T) {
+	for _, data := range []struct {
+		name          string
+		linkExtractor *MockRichLinkExtractor
+		link          models.RichLink
+		wantPage      models.RichLink
+		wantLinks     []models.RichLink
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			linkExtractor: func() *MockRichLinkExtractor {
+				extractor := new(MockRichLinkExtractor)
+				extractor.
+					On(
+						"ExtractRichLinks",
+						context.Background(),
+						23,
+						models.RichLink{Link: "http://example.com/"},
+					).
+					Return(
+						models.RichLink{Link: "http://example.com/"},
+						[]models.RichLink{
+							{
+								SourceLink: "http://example.com/",
+								Link:       "http://example.com/1",
+								Depth:      1,
+							},
+						},
+						nil,
+					)
+
+				return extractor
+			}(),
+			link:     models.RichLink{Link: "http://example.com/"},
+			wantPage: models.RichLink{Link: "http://example.com/"},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:          "error on link parsing",
+			linkExtractor: new(MockRichLinkExtractor),
+			link:          models.RichLink{Link: ":"},
+			wantPage:      models.RichLink{},
+			wantLinks:     nil,
+			wantErr:       assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := SchedulingExtractor{
+				HostScheduler: NewHostScheduler(100*time.Millisecond, 10),
+				LinkExtractor: data.linkExtractor,
+			}
+			gotPage, gotLinks, gotErr :=
+				extractor.ExtractRichLinks(context.Background(), 23, data.link)
+
+			mock.AssertExpectationsForObjects(test, data.linkExtractor)
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
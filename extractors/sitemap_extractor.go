@@ -2,8 +2,11 @@ package extractors
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/go-log/log"
+	"github.com/thewizardplusplus/go-crawler/models"
 	"github.com/thewizardplusplus/go-crawler/registers"
 )
 
@@ -11,6 +14,10 @@ import (
 type SitemapExtractor struct {
 	SitemapRegister registers.SitemapRegister
 	Logger          log.Logger
+	ErrorHandler    models.ErrorHandler // optional
+	// extract the links of the sitemap.xml extensions (images, videos, etc.)
+	// in addition to the page links
+	ExtractExtensionLinks bool
 }
 
 // ExtractLinks ...
@@ -19,19 +26,109 @@ func (extractor SitemapExtractor) ExtractLinks(
 	threadID int,
 	link string,
 ) ([]string, error) {
+	var links []string
+	for _, sitemapLink := range extractor.extractSitemapLinks(
+		ctx,
+		threadID,
+		link,
+	) {
+		links = append(links, sitemapLink.link)
+	}
+
+	return links, nil
+}
+
+// ExtractRichLinks ...
+//
+// Unlike the ExtractLinks method, it returns the metadata of the links
+// from the sitemap.xml files in their attributes. The attributes of the page
+// contain the time of the extraction.
+func (extractor SitemapExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	page := link
+	page.Attributes =
+		page.Attributes.Set(models.ExtractionTimeAttribute, time.Now())
+
+	var richLinks []models.RichLink
+	for _, sitemapLink := range extractor.extractSitemapLinks(
+		ctx,
+		threadID,
+		link.Link,
+	) {
+		richLinks = append(richLinks, models.RichLink{
+			SourceLink: link.Link,
+			Link:       sitemapLink.link,
+			Depth:      link.Depth + 1,
+			Attributes: models.Attributes{}.
+				Set(models.SitemapMetadataAttribute, sitemapLink.metadata),
+		})
+	}
+
+	return page, richLinks, nil
+}
+
+type sitemapLink struct {
+	link     string
+	metadata models.SitemapMetadata
+}
+
+// the errors are only logged and handled, so it never fails
+func (extractor SitemapExtractor) extractSitemapLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) []sitemapLink {
 	sitemapData, err :=
 		extractor.SitemapRegister.RegisterSitemap(ctx, threadID, link)
 	if err != nil {
 		const logMessage = "unable to register the sitemap.xml link for link %q: %s"
 		extractor.Logger.Logf(logMessage, link, err)
 
-		return nil, nil
+		models.HandleError(extractor.ErrorHandler, models.CrawlingError{
+			Kind:     models.SitemapError,
+			Link:     models.SourcedLink{Link: link},
+			ThreadID: threadID,
+			Err:      err,
+		})
+
+		return nil
 	}
 
-	var links []string
+	var links []sitemapLink
 	for _, url := range sitemapData.URL {
-		links = append(links, url.Loc)
+		extensions, _ := extractor.SitemapRegister.LookupExtensions(url.Loc)
+		// an invalid modification time is treated as an unspecified one
+		lastModification, _ := // nolint: gosec
+			registers.ParseSitemapTime(url.LastMod)
+		links = append(links, sitemapLink{
+			link: url.Loc,
+			metadata: models.SitemapMetadata{
+				Kind:             models.SitemapPageLink,
+				LastModification: lastModification,
+				ChangeFrequency:  strings.TrimSpace(url.ChangeFreq),
+				Priority:         url.Priority,
+				News:             extensions.News,
+			},
+		})
+
+		if !extractor.ExtractExtensionLinks {
+			continue
+		}
+
+		for _, extensionLink := range extensions.Links {
+			links = append(links, sitemapLink{
+				link: extensionLink.Link,
+				metadata: models.SitemapMetadata{
+					Kind:     extensionLink.Kind,
+					PageLink: url.Loc,
+					Language: extensionLink.Language,
+				},
+			})
+		}
 	}
 
-	return links, nil
+	return links
 }
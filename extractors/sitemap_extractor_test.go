@@ -20,6 +20,7 @@ func TestSitemapExtractor_ExtractLinks(test *testing.T) {
 		linkGenerator   models.LinkExtractor
 		logger          log.Logger
 		linkLoader      LinkLoader
+		errorHandler    models.ErrorHandler
 	}
 	type args struct {
 		ctx      context.Context
@@ -210,6 +211,56 @@ func TestSitemapExtractor_ExtractLinks(test *testing.T) {
 			wantLinks: nil,
 			wantErr:   assert.NoError,
 		},
+		{
+			name: "error with generation and an error handler",
+			fields: fields{
+				loadingInterval: 5 * time.Second,
+				linkGenerator: func() models.LinkExtractor {
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return(nil, iotest.ErrTimeout)
+
+					return linkGenerator
+				}(),
+				logger: func() Logger {
+					logger := new(MockLogger)
+					logger.On(
+						"Logf",
+						"unable to register the sitemap.xml link for link %q: %s",
+						"http://example.com/",
+						mock.AnythingOfType("*errors.withStack"),
+					).Return()
+
+					return logger
+				}(),
+				linkLoader: new(MockLinkLoader),
+				errorHandler: func() ErrorHandler {
+					wantErr :=
+						errors.Wrap(iotest.ErrTimeout, "unable to generate Sitemap links")
+
+					errorHandler := new(MockErrorHandler)
+					errorHandler.On(
+						"HandleError",
+						mock.MatchedBy(func(gotErr models.CrawlingError) bool {
+							return gotErr.Kind == models.SitemapError &&
+								gotErr.Link == models.SourcedLink{Link: "http://example.com/"} &&
+								gotErr.ThreadID == 23 &&
+								gotErr.Err.Error() == wantErr.Error()
+						}),
+					).Return()
+
+					return errorHandler
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/",
+			},
+			wantLinks: nil,
+			wantErr:   assert.NoError,
+		},
 		{
 			name: "error with loading",
 			fields: fields{
@@ -228,15 +279,13 @@ func TestSitemapExtractor_ExtractLinks(test *testing.T) {
 					return linkGenerator
 				}(),
 				logger: func() Logger {
-					wantErr := iotest.ErrTimeout
-
 					logger := new(MockLogger)
 					logger.On(
 						"Logf",
 						"unable to load Sitemap link %q: %s",
 						"http://example.com/sitemap_1.xml",
 						mock.MatchedBy(func(gotErr error) bool {
-							return gotErr.Error() == wantErr.Error()
+							return errors.Cause(gotErr) == iotest.ErrTimeout
 						}),
 					).Return()
 
@@ -288,6 +337,7 @@ func TestSitemapExtractor_ExtractLinks(test *testing.T) {
 			extractor := SitemapExtractor{
 				SitemapRegister: register,
 				Logger:          data.fields.logger,
+				ErrorHandler:    data.fields.errorHandler,
 			}
 			gotLinks, gotErr := extractor.ExtractLinks(
 				data.args.ctx,
@@ -301,8 +351,210 @@ func TestSitemapExtractor_ExtractLinks(test *testing.T) {
 				data.fields.logger,
 				data.fields.linkLoader,
 			)
+			if data.fields.errorHandler != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.errorHandler)
+			}
 			assert.ElementsMatch(test, data.wantLinks, gotLinks)
 			data.wantErr(test, gotErr)
 		})
 	}
 }
+
+func TestSitemapExtractor_ExtractRichLinks_withExtensionLinks(test *testing.T) {
+	for _, data := range []struct {
+		name                  string
+		extractExtensionLinks bool
+		wantLinks             []string
+		wantMetadata          map[string]models.SitemapMetadata
+	}{
+		{
+			name:                  "without extracting of the extension links",
+			extractExtensionLinks: false,
+			wantLinks:             []string{"http://example.com/1"},
+			wantMetadata: map[string]models.SitemapMetadata{
+				"http://example.com/1": {
+					Kind: models.SitemapPageLink,
+					News: models.SitemapNews{PublicationName: "Example", Title: "Test"},
+				},
+			},
+		},
+		{
+			name:                  "with extracting of the extension links",
+			extractExtensionLinks: true,
+			wantLinks: []string{
+				"http://example.com/1",
+				"http://example.com/1.png",
+				"http://example.com/de/1",
+			},
+			wantMetadata: map[string]models.SitemapMetadata{
+				"http://example.com/1": {
+					Kind: models.SitemapPageLink,
+					News: models.SitemapNews{PublicationName: "Example", Title: "Test"},
+				},
+				"http://example.com/1.png": {
+					Kind:     models.SitemapImageLink,
+					PageLink: "http://example.com/1",
+				},
+				"http://example.com/de/1": {
+					Kind:     models.SitemapAlternateLink,
+					PageLink: "http://example.com/1",
+					Language: "de",
+				},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			linkGenerator := new(MockLinkExtractor)
+			linkGenerator.
+				On("ExtractLinks", context.Background(), 23, "http://example.com/").
+				Return([]string{"http://example.com/sitemap.xml"}, nil)
+
+			const response = `
+				<?xml version="1.0" encoding="UTF-8" ?>
+				<urlset
+					xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+					xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"
+					xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"
+					xmlns:xhtml="http://www.w3.org/1999/xhtml"
+				>
+					<url>
+						<loc>http://example.com/1</loc>
+						<image:image>
+							<image:loc>http://example.com/1.png</image:loc>
+						</image:image>
+						<xhtml:link rel="alternate" hreflang="de" href="http://example.com/de/1" />
+						<news:news>
+							<news:publication>
+								<news:name>Example</news:name>
+							</news:publication>
+							<news:title>Test</news:title>
+						</news:news>
+					</url>
+				</urlset>
+			`
+
+			linkLoader := new(MockLinkLoader)
+			linkLoader.
+				On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+				Return([]byte(response), nil)
+
+			logger := new(MockLogger)
+			extractor := SitemapExtractor{
+				SitemapRegister: registers.NewSitemapRegister(
+					5*time.Second,
+					linkGenerator,
+					logger,
+					linkLoader.LoadLink,
+				),
+				Logger:                logger,
+				ExtractExtensionLinks: data.extractExtensionLinks,
+			}
+			_, gotLinks, gotErr := extractor.ExtractRichLinks(
+				context.Background(),
+				23,
+				models.RichLink{Link: "http://example.com/"},
+			)
+
+			var gotRawLinks []string
+			gotMetadata := make(map[string]models.SitemapMetadata)
+			for _, link := range gotLinks {
+				metadata, _ := link.Attributes.Value(models.SitemapMetadataAttribute)
+
+				gotRawLinks = append(gotRawLinks, link.Link)
+				gotMetadata[link.Link], _ = metadata.(models.SitemapMetadata)
+			}
+
+			mock.AssertExpectationsForObjects(test, linkGenerator, linkLoader, logger)
+			assert.Equal(test, data.wantLinks, gotRawLinks)
+			assert.Equal(test, data.wantMetadata, gotMetadata)
+			assert.NoError(test, gotErr)
+		})
+	}
+}
+
+func TestSitemapExtractor_ExtractRichLinks(test *testing.T) {
+	linkGenerator := new(MockLinkExtractor)
+	linkGenerator.
+		On("ExtractLinks", context.Background(), 23, "http://example.com/").
+		Return([]string{"http://example.com/sitemap.xml"}, nil)
+
+	const response = `
+		<?xml version="1.0" encoding="UTF-8" ?>
+		<urlset
+			xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+			xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"
+		>
+			<url>
+				<loc>http://example.com/1</loc>
+				<lastmod>2006-01-02</lastmod>
+				<image:image>
+					<image:loc>http://example.com/1.png</image:loc>
+				</image:image>
+			</url>
+		</urlset>
+	`
+
+	linkLoader := new(MockLinkLoader)
+	linkLoader.
+		On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+		Return([]byte(response), nil)
+
+	logger := new(MockLogger)
+	extractor := SitemapExtractor{
+		SitemapRegister: registers.NewSitemapRegister(
+			5*time.Second,
+			linkGenerator,
+			logger,
+			linkLoader.LoadLink,
+		),
+		Logger:                logger,
+		ExtractExtensionLinks: true,
+	}
+	startTime := time.Now()
+	gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+		context.Background(),
+		23,
+		models.RichLink{Link: "http://example.com/", Depth: 2},
+	)
+
+	extractionTime, ok :=
+		gotPage.Attributes.Time(models.ExtractionTimeAttribute)
+	assert.True(test, ok)
+	assert.WithinDuration(test, startTime, extractionTime, time.Second)
+
+	gotPage.Attributes = gotPage.Attributes.Delete(models.ExtractionTimeAttribute)
+	assert.Equal(test, models.RichLink{
+		Link:       "http://example.com/",
+		Depth:      2,
+		Attributes: models.Attributes{},
+	}, gotPage)
+
+	mock.AssertExpectationsForObjects(test, linkGenerator, linkLoader, logger)
+	assert.Equal(test, []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Depth:      3,
+			Attributes: models.Attributes{}.Set(
+				models.SitemapMetadataAttribute,
+				models.SitemapMetadata{
+					Kind:             models.SitemapPageLink,
+					LastModification: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+				},
+			),
+		},
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1.png",
+			Depth:      3,
+			Attributes: models.Attributes{}.Set(
+				models.SitemapMetadataAttribute,
+				models.SitemapMetadata{
+					Kind:     models.SitemapImageLink,
+					PageLink: "http://example.com/1",
+				},
+			),
+		},
+	}, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,25 @@
+package extractors
+
+import (
+	"context"
+	"time"
+)
+
+// SleepWithContext ...
+//
+// Unlike time.Sleep(), it's interrupted on the context cancellation.
+func SleepWithContext(ctx context.Context, duration time.Duration) error {
+	if duration <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,64 @@
+package extractors
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSleepWithContext(test *testing.T) {
+	for _, data := range []struct {
+		name            string
+		ctx             func() (context.Context, context.CancelFunc)
+		duration        time.Duration
+		wantMinDuration time.Duration
+		wantMaxDuration time.Duration
+		wantErr         error
+	}{
+		{
+			name: "success",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			duration:        10 * time.Millisecond,
+			wantMinDuration: 10 * time.Millisecond,
+			wantMaxDuration: time.Hour,
+			wantErr:         nil,
+		},
+		{
+			name: "success with a non-positive duration",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithCancel(context.Background())
+			},
+			duration:        -10 * time.Millisecond,
+			wantMinDuration: 0,
+			wantMaxDuration: 10 * time.Millisecond,
+			wantErr:         nil,
+		},
+		{
+			name: "error",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), 10*time.Millisecond)
+			},
+			duration:        time.Hour,
+			wantMinDuration: 0,
+			wantMaxDuration: time.Minute,
+			wantErr:         context.DeadlineExceeded,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			ctx, cancel := data.ctx()
+			defer cancel()
+
+			startTime := time.Now()
+			gotErr := SleepWithContext(ctx, data.duration)
+			gotDuration := time.Since(startTime)
+
+			assert.True(test, gotDuration >= data.wantMinDuration)
+			assert.True(test, gotDuration < data.wantMaxDuration)
+			assert.Equal(test, data.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package extractors
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultStatusPolicy ...
+//
+// It accepts only the 2xx status codes and retries the request timeouts,
+// the rate limiting and the server errors.
+var DefaultStatusPolicy = StatusPolicy{ // nolint: gochecknoglobals
+	AcceptedStatusCodes: []StatusCodeRange{
+		{Minimum: http.StatusOK, Maximum: http.StatusIMUsed},
+	},
+	RetryableStatusCodes: []StatusCodeRange{
+		{Minimum: http.StatusRequestTimeout, Maximum: http.StatusRequestTimeout},
+		{Minimum: http.StatusTooManyRequests, Maximum: http.StatusTooManyRequests},
+		{Minimum: http.StatusInternalServerError, Maximum: 599},
+	},
+}
+
+// StatusCodeRange ...
+//
+// Both its bounds are inclusive.
+type StatusCodeRange struct {
+	Minimum int
+	Maximum int
+}
+
+// Contains ...
+func (statusCodeRange StatusCodeRange) Contains(statusCode int) bool {
+	return statusCode >= statusCodeRange.Minimum &&
+		statusCode <= statusCodeRange.Maximum
+}
+
+// StatusPolicy ...
+type StatusPolicy struct {
+	AcceptedStatusCodes  []StatusCodeRange
+	RetryableStatusCodes []StatusCodeRange
+}
+
+// CheckResponse ...
+//
+// It returns the StatusCodeError if the response status code isn't accepted.
+func (policy StatusPolicy) CheckResponse(response *http.Response) error {
+	if containsStatusCode(policy.AcceptedStatusCodes, response.StatusCode) {
+		return nil
+	}
+
+	return StatusCodeError{
+		StatusCode: response.StatusCode,
+		Header:     response.Header,
+		Retryable: containsStatusCode(
+			policy.RetryableStatusCodes,
+			response.StatusCode,
+		),
+	}
+}
+
+// StatusCodeError ...
+type StatusCodeError struct {
+	StatusCode int
+	Header     http.Header
+	Retryable  bool
+}
+
+// Error ...
+func (err StatusCodeError) Error() string {
+	return fmt.Sprintf("unaccepted status code %d", err.StatusCode)
+}
+
+// IsRetryable ...
+func (err StatusCodeError) IsRetryable() bool {
+	return err.Retryable
+}
+
+// IsRetryableError ...
+//
+// The error is considered retryable, unless its cause (see errors.Cause())
+// implements the IsRetryable() method and the method returns false.
+func IsRetryableError(err error) bool {
+	retryableErr, ok := errors.Cause(err).(interface{ IsRetryable() bool })
+	return !ok || retryableErr.IsRetryable()
+}
+
+func containsStatusCode(ranges []StatusCodeRange, statusCode int) bool {
+	for _, statusCodeRange := range ranges {
+		if statusCodeRange.Contains(statusCode) {
+			return true
+		}
+	}
+
+	return false
+}
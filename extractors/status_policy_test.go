@@ -0,0 +1,114 @@
+package extractors
+
+import (
+	"net/http"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+)
+
+func TestStatusPolicy_CheckResponse(test *testing.T) {
+	for _, data := range []struct {
+		name       string
+		policy     StatusPolicy
+		statusCode int
+		wantErr    error
+	}{
+		{
+			name:       "with an accepted status code",
+			policy:     DefaultStatusPolicy,
+			statusCode: http.StatusNoContent,
+			wantErr:    nil,
+		},
+		{
+			name:       "with a retryable status code",
+			policy:     DefaultStatusPolicy,
+			statusCode: http.StatusServiceUnavailable,
+			wantErr: StatusCodeError{
+				StatusCode: http.StatusServiceUnavailable,
+				Header:     http.Header{"Retry-After": {"23"}},
+				Retryable:  true,
+			},
+		},
+		{
+			name:       "with a non-retryable status code",
+			policy:     DefaultStatusPolicy,
+			statusCode: http.StatusNotFound,
+			wantErr: StatusCodeError{
+				StatusCode: http.StatusNotFound,
+				Header:     http.Header{"Retry-After": {"23"}},
+				Retryable:  false,
+			},
+		},
+		{
+			name:       "with an empty policy",
+			policy:     StatusPolicy{},
+			statusCode: http.StatusOK,
+			wantErr: StatusCodeError{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"Retry-After": {"23"}},
+				Retryable:  false,
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			response := &http.Response{
+				StatusCode: data.statusCode,
+				Header:     http.Header{"Retry-After": {"23"}},
+			}
+			gotErr := data.policy.CheckResponse(response)
+
+			assert.Equal(test, data.wantErr, gotErr)
+		})
+	}
+}
+
+func TestStatusCodeError_Error(test *testing.T) {
+	err := StatusCodeError{StatusCode: http.StatusNotFound}
+	assert.Equal(test, "unaccepted status code 404", err.Error())
+}
+
+func TestIsRetryableError(test *testing.T) {
+	for _, data := range []struct {
+		name string
+		err  error
+		want assert.BoolAssertionFunc
+	}{
+		{
+			name: "with an unclassified error",
+			err:  errors.Wrap(iotest.ErrTimeout, "unable to load the data"),
+			want: assert.True,
+		},
+		{
+			name: "with a retryable error",
+			err: errors.Wrap(
+				StatusCodeError{StatusCode: http.StatusTooManyRequests, Retryable: true},
+				"unable to load the data",
+			),
+			want: assert.True,
+		},
+		{
+			name: "with a non-retryable error",
+			err: errors.Wrap(
+				StatusCodeError{StatusCode: http.StatusNotFound, Retryable: false},
+				"unable to load the data",
+			),
+			want: assert.False,
+		},
+		{
+			name: "with a too large data error",
+			err: errors.Wrap(
+				ioutils.TooLargeError{MaximalSize: 23},
+				"unable to read the data",
+			),
+			want: assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			data.want(test, IsRetryableError(data.err))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package transformers
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers/feeds"
+)
+
+// FeedTransformer ...
+//
+// It adds the links of the feeds declared in the page (see
+// the feeds.DiscoverFeedLinks() function) to the links, so the feeds
+// are discovered without an additional loading of the page. The added links
+// are already resolved.
+type FeedTransformer struct {
+	// optional; feeds.DefaultFeedTypes are used by default
+	FeedTypes []string
+}
+
+// TransformLinks ...
+func (transformer FeedTransformer) TransformLinks(
+	links []string,
+	response *http.Response,
+	responseContent []byte,
+) ([]string, error) {
+	feedLinks, err := transformer.discoverFeedLinks(response, responseContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// the capacity is limited to prevent changing of the original links
+	return append(links[:len(links):len(links)], feedLinks...), nil
+}
+
+// TransformHTMLLinks ...
+func (transformer FeedTransformer) TransformHTMLLinks(
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	feedLinks, err := transformer.discoverFeedLinks(response, responseContent)
+	if err != nil {
+		return nil, err
+	}
+
+	// the capacity is limited to prevent changing of the original links
+	transformedLinks := links[:len(links):len(links)]
+	for _, feedLink := range feedLinks {
+		transformedLinks = append(transformedLinks, models.HTMLLink{
+			Link: feedLink,
+			Metadata: models.HTMLMetadata{
+				TagName:       "link",
+				AttributeName: "href",
+			},
+		})
+	}
+
+	return transformedLinks, nil
+}
+
+func (transformer FeedTransformer) discoverFeedLinks(
+	response *http.Response,
+	responseContent []byte,
+) ([]string, error) {
+	feedLinks, err := feeds.DiscoverFeedLinks(
+		response,
+		bytes.NewReader(responseContent),
+		transformer.FeedTypes,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to discover the feed links")
+	}
+
+	return feedLinks, nil
+}
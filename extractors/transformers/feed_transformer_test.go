@@ -0,0 +1,141 @@
+package transformers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestFeedTransformer_TransformLinks(test *testing.T) {
+	type fields struct {
+		FeedTypes []string
+	}
+	type args struct {
+		links           []string
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantLinks []string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without feeds",
+			fields: fields{
+				FeedTypes: nil,
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+				},
+				responseContent: []byte(`
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2">2</a>
+				`),
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success with the default feed types",
+			fields: fields{
+				FeedTypes: nil,
+			},
+			args: args{
+				links: []string{"http://example.com/1"},
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+				},
+				responseContent: []byte(`
+					<link rel="alternate" type="application/rss+xml" href="/rss.xml" />
+					<link rel="alternate" type="application/feed+json" href="/feed.json" />
+					<a href="http://example.com/1">1</a>
+				`),
+			},
+			wantLinks: []string{"http://example.com/1", "http://example.com/rss.xml"},
+			wantErr:   assert.NoError,
+		},
+		{
+			name: "success with the custom feed types and the base tag",
+			fields: fields{
+				FeedTypes: []string{"application/feed+json"},
+			},
+			args: args{
+				links: nil,
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+				},
+				responseContent: []byte(`
+					<base href="http://example.com/base/" />
+					<link rel="alternate" type="application/rss+xml" href="rss.xml" />
+					<link rel="alternate" type="application/feed+json" href="feed.json" />
+				`),
+			},
+			wantLinks: []string{"http://example.com/base/feed.json"},
+			wantErr:   assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			transformer := FeedTransformer{
+				FeedTypes: data.fields.FeedTypes,
+			}
+			gotLinks, gotErr := transformer.TransformLinks(
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestFeedTransformer_TransformHTMLLinks(test *testing.T) {
+	transformer := FeedTransformer{}
+	gotLinks, gotErr := transformer.TransformHTMLLinks(
+		[]models.HTMLLink{
+			{
+				Link: "http://example.com/1",
+				Metadata: models.HTMLMetadata{
+					TagName:       "a",
+					AttributeName: "href",
+				},
+			},
+		},
+		&http.Response{
+			Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+		},
+		[]byte(`
+			<link rel="alternate" type="application/atom+xml" href="/atom.xml" />
+			<a href="http://example.com/1">1</a>
+		`),
+	)
+
+	wantLinks := []models.HTMLLink{
+		{
+			Link: "http://example.com/1",
+			Metadata: models.HTMLMetadata{
+				TagName:       "a",
+				AttributeName: "href",
+			},
+		},
+		{
+			Link: "http://example.com/atom.xml",
+			Metadata: models.HTMLMetadata{
+				TagName:       "link",
+				AttributeName: "href",
+			},
+		},
+	}
+	assert.Equal(test, wantLinks, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,85 @@
+package transformers
+
+import (
+	"net/http"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// TransformHTMLLinks ...
+//
+// It uses the models.HTMLLinkTransformer interface if the transformer
+// implements it. Otherwise, the links are transformed as strings, and
+// the metadata is kept only for the links that the transformer leaves
+// unchanged.
+func TransformHTMLLinks(
+	transformer models.LinkTransformer,
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	if htmlTransformer, ok := transformer.(models.HTMLLinkTransformer); ok {
+		return htmlTransformer.TransformHTMLLinks(links, response, responseContent)
+	}
+
+	rawLinks := make([]string, 0, len(links))
+	metadata := make(map[string]models.HTMLMetadata)
+	for _, link := range links {
+		rawLinks = append(rawLinks, link.Link)
+		if _, ok := metadata[link.Link]; !ok {
+			metadata[link.Link] = link.Metadata
+		}
+	}
+
+	transformedRawLinks, err :=
+		transformer.TransformLinks(rawLinks, response, responseContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var transformedLinks []models.HTMLLink
+	for _, link := range transformedRawLinks {
+		transformedLinks = append(transformedLinks, models.HTMLLink{
+			Link:     link,
+			Metadata: metadata[link],
+		})
+	}
+
+	return transformedLinks, nil
+}
+
+// TransformHTMLPage ...
+//
+// It uses the models.HTMLPageTransformer interface if the transformer
+// implements it. Otherwise, the links are transformed
+// by the TransformHTMLLinks() function, and the page is kept unchanged.
+func TransformHTMLPage(
+	transformer models.LinkTransformer,
+	page models.RichLink,
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) (models.RichLink, []models.HTMLLink, error) {
+	if pageTransformer, ok := transformer.(models.HTMLPageTransformer); ok {
+		return pageTransformer.
+			TransformHTMLPage(page, links, response, responseContent)
+	}
+
+	transformedLinks, err :=
+		TransformHTMLLinks(transformer, links, response, responseContent)
+	if err != nil {
+		return models.RichLink{}, nil, err
+	}
+
+	return page, transformedLinks, nil
+}
+
+// MakeRawLinks ...
+func MakeRawLinks(links []models.HTMLLink) []string {
+	var rawLinks []string
+	for _, link := range links {
+		rawLinks = append(rawLinks, link.Link)
+	}
+
+	return rawLinks
+}
@@ -0,0 +1,281 @@
+package transformers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestTransformHTMLLinks(test *testing.T) {
+	type args struct {
+		transformer     models.LinkTransformer
+		links           []models.HTMLLink
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name      string
+		args      args
+		wantLinks []models.HTMLLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the HTML link transformer",
+			args: args{
+				transformer: TrimmingTransformer{TrimLink: urlutils.TrimLink},
+				links: []models.HTMLLink{
+					{
+						Link:     "  http://example.com/1  ",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+					{
+						Link:     "  http://example.com/2  ",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: nil,
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+				{
+					Link:     "http://example.com/2",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the plain link transformer",
+			args: args{
+				transformer: func() models.LinkTransformer {
+					response := &http.Response{
+						Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+					}
+
+					transformer := new(MockLinkTransformer)
+					transformer.
+						On(
+							"TransformLinks",
+							[]string{"http://example.com/1", "http://example.com/2"},
+							response,
+							[]byte("content"),
+						).
+						Return(
+							[]string{"http://example.com/1", "http://example.com/transformed/2"},
+							nil,
+						)
+
+					return transformer
+				}(),
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+					{
+						Link:     "http://example.com/2",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/", nil),
+				},
+				responseContent: []byte("content"),
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+				{Link: "http://example.com/transformed/2"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with the plain link transformer",
+			args: args{
+				transformer: func() models.LinkTransformer {
+					transformer := new(MockLinkTransformer)
+					transformer.
+						On(
+							"TransformLinks",
+							[]string{"http://example.com/1"},
+							(*http.Response)(nil),
+							[]byte("content"),
+						).
+						Return(nil, iotest.ErrTimeout)
+
+					return transformer
+				}(),
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: []byte("content"),
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotLinks, gotErr := TransformHTMLLinks(
+				data.args.transformer,
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			if transformer, ok := data.args.transformer.(*MockLinkTransformer); ok {
+				transformer.AssertExpectations(test)
+			}
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestTransformHTMLPage(test *testing.T) {
+	type args struct {
+		transformer     models.LinkTransformer
+		page            models.RichLink
+		links           []models.HTMLLink
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name      string
+		args      args
+		wantPage  models.RichLink
+		wantLinks []models.HTMLLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the HTML page transformer",
+			args: args{
+				transformer: RobotsDirectivesTransformer{UserAgent: "go-crawler"},
+				page:        models.RichLink{Link: "http://example.com/"},
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response: &http.Response{
+					Header: http.Header{RobotsTagHeaderName: {"noindex"}},
+				},
+				responseContent: []byte(`<a href="http://example.com/1">1</a>`),
+			},
+			wantPage: models.RichLink{
+				Link: "http://example.com/",
+				Attributes: models.Attributes{}.Set(
+					models.RobotsDirectivesAttribute,
+					models.RobotsDirectives{Noindex: true},
+				),
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the HTML link transformer",
+			args: args{
+				transformer: TrimmingTransformer{TrimLink: urlutils.TrimLink},
+				page:        models.RichLink{Link: "http://example.com/"},
+				links: []models.HTMLLink{
+					{
+						Link:     "  http://example.com/1  ",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: nil,
+			},
+			wantPage: models.RichLink{Link: "http://example.com/"},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			args: args{
+				transformer: func() models.LinkTransformer {
+					transformer := new(MockLinkTransformer)
+					transformer.
+						On(
+							"TransformLinks",
+							[]string{"http://example.com/1"},
+							(*http.Response)(nil),
+							[]byte("content"),
+						).
+						Return(nil, iotest.ErrTimeout)
+
+					return transformer
+				}(),
+				page: models.RichLink{Link: "http://example.com/"},
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: []byte("content"),
+			},
+			wantPage:  models.RichLink{},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotPage, gotLinks, gotErr := TransformHTMLPage(
+				data.args.transformer,
+				data.args.page,
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			if transformer, ok := data.args.transformer.(*MockLinkTransformer); ok {
+				transformer.AssertExpectations(test)
+			}
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestMakeRawLinks(test *testing.T) {
+	rawLinks := MakeRawLinks([]models.HTMLLink{
+		{
+			Link:     "http://example.com/1",
+			Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+		},
+		{Link: "http://example.com/2"},
+	})
+
+	wantRawLinks := []string{"http://example.com/1", "http://example.com/2"}
+	assert.Equal(test, wantRawLinks, rawLinks)
+}
@@ -24,3 +24,13 @@ type LinkTransformer interface {
 type Logger interface {
 	log.Logger
 }
+
+//go:generate mockery --name=ErrorHandler --inpackage --case=underscore --testonly
+
+// ErrorHandler ...
+//
+// It's used only for mock generating.
+//
+type ErrorHandler interface {
+	models.ErrorHandler
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/go-log/log"
 	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 	htmlselector "github.com/thewizardplusplus/go-html-selector"
 )
@@ -16,6 +17,7 @@ type ResolvingTransformer struct {
 	BaseTagFilters   htmlselector.OptimizedFilterGroup
 	BaseHeaderNames  []string
 	Logger           log.Logger
+	ErrorHandler     models.ErrorHandler // optional
 }
 
 // TransformLinks ...
@@ -24,19 +26,16 @@ func (transformer ResolvingTransformer) TransformLinks(
 	response *http.Response,
 	responseContent []byte,
 ) ([]string, error) {
-	baseTag := transformer.selectBaseTag(responseContent)
-	baseLinks :=
-		urlutils.GenerateBaseLinks(response, baseTag, transformer.BaseHeaderNames)
-	linkResolver, err := urlutils.NewLinkResolver(baseLinks)
+	linkResolver, err :=
+		transformer.makeLinkResolver(response, responseContent)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to construct the link resolver")
+		return nil, err
 	}
 
 	var resolvedLinks []string
 	for _, link := range links {
-		resolvedLink, err := linkResolver.ResolveLink(link)
-		if err != nil {
-			transformer.Logger.Logf("unable to resolve link %q: %s", link, err)
+		resolvedLink, ok := transformer.resolveLink(linkResolver, link, response)
+		if !ok {
 			continue
 		}
 
@@ -46,6 +45,83 @@ func (transformer ResolvingTransformer) TransformLinks(
 	return resolvedLinks, nil
 }
 
+// TransformHTMLLinks ...
+func (transformer ResolvingTransformer) TransformHTMLLinks(
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	linkResolver, err :=
+		transformer.makeLinkResolver(response, responseContent)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolvedLinks []models.HTMLLink
+	for _, link := range links {
+		resolvedLink, ok :=
+			transformer.resolveLink(linkResolver, link.Link, response)
+		if !ok {
+			continue
+		}
+
+		link.Link = resolvedLink
+		resolvedLinks = append(resolvedLinks, link)
+	}
+
+	return resolvedLinks, nil
+}
+
+func (transformer ResolvingTransformer) makeLinkResolver(
+	response *http.Response,
+	responseContent []byte,
+) (urlutils.LinkResolver, error) {
+	baseTag := transformer.selectBaseTag(responseContent)
+	baseLinks :=
+		urlutils.GenerateBaseLinks(response, baseTag, transformer.BaseHeaderNames)
+	linkResolver, err := urlutils.NewLinkResolver(baseLinks)
+	if err != nil {
+		return urlutils.LinkResolver{},
+			errors.Wrap(err, "unable to construct the link resolver")
+	}
+
+	return linkResolver, nil
+}
+
+func (transformer ResolvingTransformer) resolveLink(
+	linkResolver urlutils.LinkResolver,
+	link string,
+	response *http.Response,
+) (string, bool) {
+	resolvedLink, err := linkResolver.ResolveLink(link)
+	if err != nil {
+		transformer.Logger.Logf("unable to resolve link %q: %s", link, err)
+		transformer.handleError(link, response, err)
+
+		return "", false
+	}
+
+	return resolvedLink, true
+}
+
+func (transformer ResolvingTransformer) handleError(
+	link string,
+	response *http.Response,
+	err error,
+) {
+	var sourceLink string
+	if response != nil && response.Request != nil {
+		sourceLink = response.Request.URL.String()
+	}
+
+	models.HandleError(transformer.ErrorHandler, models.CrawlingError{
+		Kind:     models.ResolvingError,
+		Link:     models.SourcedLink{SourceLink: sourceLink, Link: link},
+		ThreadID: models.UnknownThreadID,
+		Err:      err,
+	})
+}
+
 func (transformer ResolvingTransformer) selectBaseTag(data []byte) string {
 	builder := NewBaseTagBuilder(transformer.BaseTagSelection)
 	htmlselector.SelectTags( // nolint: errcheck, gosec
@@ -10,6 +10,7 @@ import (
 	"github.com/go-log/log"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 	htmlselector "github.com/thewizardplusplus/go-html-selector"
 )
@@ -20,6 +21,7 @@ func TestResolvingTransformer_TransformLinks(test *testing.T) {
 		BaseTagFilters   htmlselector.OptimizedFilterGroup
 		BaseHeaderNames  []string
 		Logger           log.Logger
+		ErrorHandler     models.ErrorHandler
 	}
 	type args struct {
 		links           []string
@@ -159,6 +161,61 @@ func TestResolvingTransformer_TransformLinks(test *testing.T) {
 			wantLinks: []string{"http://example.com/a/b/c/d/e/f/g/h/two"},
 			wantErr:   assert.NoError,
 		},
+		{
+			name: "error with resolving of the link and an error handler",
+			fields: fields{
+				BaseTagSelection: SelectFirstBaseTag,
+				BaseTagFilters:   DefaultBaseTagFilters,
+				BaseHeaderNames:  urlutils.DefaultBaseHeaderNames,
+				Logger: func() Logger {
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"unable to resolve link %q: %s",
+							":",
+							mock.AnythingOfType("*errors.withStack"),
+						).
+						Return()
+
+					return logger
+				}(),
+				ErrorHandler: func() ErrorHandler {
+					err := errors.New("missing protocol scheme")
+					urlErr := &url.Error{Op: "parse", URL: ":", Err: err}
+
+					handler := new(MockErrorHandler)
+					handler.
+						On("HandleError", mock.MatchedBy(func(err models.CrawlingError) bool {
+							wantLink := models.SourcedLink{
+								SourceLink: "http://example.com/a/b/",
+								Link:       ":",
+							}
+							wantErrMessage := "unable to parse the link: " + urlErr.Error()
+							return err.Kind == models.ResolvingError &&
+								err.Link == wantLink &&
+								err.ThreadID == models.UnknownThreadID &&
+								err.Err.Error() == wantErrMessage
+						})).
+						Return()
+
+					return handler
+				}(),
+			},
+			args: args{
+				links: []string{":", "two"},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/a/b/",
+						nil,
+					),
+				},
+				responseContent: nil,
+			},
+			wantLinks: []string{"http://example.com/a/b/two"},
+			wantErr:   assert.NoError,
+		},
 	} {
 		test.Run(data.name, func(t *testing.T) {
 			transformer := ResolvingTransformer{
@@ -166,6 +223,7 @@ func TestResolvingTransformer_TransformLinks(test *testing.T) {
 				BaseTagFilters:   data.fields.BaseTagFilters,
 				BaseHeaderNames:  data.fields.BaseHeaderNames,
 				Logger:           data.fields.Logger,
+				ErrorHandler:     data.fields.ErrorHandler,
 			}
 			gotLinks, gotErr := transformer.TransformLinks(
 				data.args.links,
@@ -174,12 +232,60 @@ func TestResolvingTransformer_TransformLinks(test *testing.T) {
 			)
 
 			mock.AssertExpectationsForObjects(test, data.fields.Logger)
+			if data.fields.ErrorHandler != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.ErrorHandler)
+			}
 			assert.Equal(test, data.wantLinks, gotLinks)
 			data.wantErr(test, gotErr)
 		})
 	}
 }
 
+func TestResolvingTransformer_TransformHTMLLinks(test *testing.T) {
+	logger := new(MockLogger)
+	logger.
+		On(
+			"Logf",
+			"unable to resolve link %q: %s",
+			":",
+			mock.AnythingOfType("*errors.withStack"),
+		).
+		Return()
+
+	transformer := ResolvingTransformer{
+		BaseTagSelection: SelectFirstBaseTag,
+		BaseTagFilters:   DefaultBaseTagFilters,
+		BaseHeaderNames:  urlutils.DefaultBaseHeaderNames,
+		Logger:           logger,
+	}
+	gotLinks, gotErr := transformer.TransformHTMLLinks(
+		[]models.HTMLLink{
+			{
+				Link:     ":",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+			{
+				Link:     "two",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+		},
+		&http.Response{
+			Request: httptest.NewRequest(http.MethodGet, "http://example.com/a/b/", nil),
+		},
+		[]byte(`<base href="c/d/" />`),
+	)
+
+	wantLinks := []models.HTMLLink{
+		{
+			Link:     "http://example.com/a/b/c/d/two",
+			Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+		},
+	}
+	mock.AssertExpectationsForObjects(test, logger)
+	assert.Equal(test, wantLinks, gotLinks)
+	assert.NoError(test, gotErr)
+}
+
 func TestResolvingTransformer_selectBaseTag(test *testing.T) {
 	type fields struct {
 		BaseTagSelection BaseTagSelection
@@ -0,0 +1,316 @@
+package transformers
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+	robotstxtutils "github.com/thewizardplusplus/go-crawler/robots-txt-utils"
+	htmlselector "github.com/thewizardplusplus/go-html-selector"
+)
+
+// RobotsTagHeaderName ...
+const RobotsTagHeaderName = "X-Robots-Tag"
+
+var robotsDirectiveFilters = htmlselector.OptimizeFilters( // nolint: gochecknoglobals, lll
+	htmlselector.FilterGroup{
+		"meta": {"name", "content"},
+		"a":    {"href", "rel"},
+		"area": {"href", "rel"},
+	},
+)
+
+// the directives that can be specified with a value after a colon,
+// so they can't be confused with a user agent in the X-Robots-Tag header
+var valuedRobotsDirectives = []string{ // nolint: gochecknoglobals
+	"unavailable_after",
+	"max-snippet",
+	"max-image-preview",
+	"max-video-preview",
+}
+
+// RobotsDirectivesTransformer ...
+//
+// It takes into account the robots <meta /> tags, the X-Robots-Tag response
+// headers and the rel="nofollow" attributes of the <a /> and <area /> tags.
+// If the nofollow directive is specified for the page, all links are dropped.
+// Otherwise, only the links marked by the rel="nofollow" attribute
+// are dropped.
+//
+// It compares the links with the raw values of the href attributes,
+// so it should be used before the ResolvingTransformer.
+type RobotsDirectivesTransformer struct {
+	// it's used for the directives specific to the user agent
+	UserAgent string
+}
+
+// TransformLinks ...
+func (transformer RobotsDirectivesTransformer) TransformLinks(
+	links []string,
+	response *http.Response,
+	responseContent []byte,
+) ([]string, error) {
+	directives, nofollowLinks :=
+		transformer.collectDirectives(response, responseContent)
+	if directives.Nofollow {
+		return nil, nil
+	}
+	if len(nofollowLinks) == 0 {
+		return links, nil
+	}
+
+	var followedLinks []string
+	for _, link := range links {
+		if _, ok := nofollowLinks[strings.TrimSpace(link)]; ok {
+			continue
+		}
+
+		followedLinks = append(followedLinks, link)
+	}
+
+	return followedLinks, nil
+}
+
+// TransformHTMLLinks ...
+func (transformer RobotsDirectivesTransformer) TransformHTMLLinks(
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	_, followedLinks, err := transformer.TransformHTMLPage(
+		models.RichLink{},
+		links,
+		response,
+		responseContent,
+	)
+	return followedLinks, err
+}
+
+// TransformHTMLPage ...
+//
+// The directives of the page are set in its attributes
+// if any of them is specified.
+func (transformer RobotsDirectivesTransformer) TransformHTMLPage(
+	page models.RichLink,
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) (models.RichLink, []models.HTMLLink, error) {
+	directives, nofollowLinks :=
+		transformer.collectDirectives(response, responseContent)
+	if directives != (models.RobotsDirectives{}) {
+		page.Attributes =
+			page.Attributes.Set(models.RobotsDirectivesAttribute, directives)
+	}
+	if directives.Nofollow {
+		return page, nil, nil
+	}
+	if len(nofollowLinks) == 0 {
+		return page, links, nil
+	}
+
+	var followedLinks []models.HTMLLink
+	for _, link := range links {
+		if _, ok := nofollowLinks[strings.TrimSpace(link.Link)]; ok {
+			continue
+		}
+
+		followedLinks = append(followedLinks, link)
+	}
+
+	return page, followedLinks, nil
+}
+
+// it returns the directives of the page and the links that are marked
+// by the rel="nofollow" attribute
+func (transformer RobotsDirectivesTransformer) collectDirectives(
+	response *http.Response,
+	responseContent []byte,
+) (directives models.RobotsDirectives, nofollowLinks map[string]struct{}) {
+	var builder robotsDirectivesBuilder
+	htmlselector.SelectTags( // nolint: errcheck, gosec
+		bytes.NewReader(responseContent),
+		robotsDirectiveFilters,
+		&builder,
+		htmlselector.SkipEmptyTags(),
+		htmlselector.SkipEmptyAttributes(),
+	)
+
+	productToken := robotstxtutils.ExtractProductToken(transformer.UserAgent)
+	if response != nil {
+		for _, value := range response.Header[RobotsTagHeaderName] {
+			directives = mergeRobotsDirectives(
+				directives,
+				parseRobotsTagHeader(value, productToken),
+			)
+		}
+	}
+	for _, metaTag := range builder.metaTags {
+		name := strings.ToLower(strings.TrimSpace(metaTag.name))
+		if name == "robots" || (productToken != "" && name == productToken) {
+			directives = mergeRobotsDirectives(
+				directives,
+				parseRobotsDirectives(metaTag.content),
+			)
+		}
+	}
+
+	return directives, builder.nofollowLinks()
+}
+
+// the header value may contain several sections prefixed by the user agents;
+// the directives before the first prefix are applied to all user agents
+func parseRobotsTagHeader(
+	value string,
+	productToken string,
+) models.RobotsDirectives {
+	var directives models.RobotsDirectives
+	isApplicable := true
+	for _, directive := range strings.Split(value, ",") {
+		if index := strings.IndexByte(directive, ':'); index != -1 {
+			prefix := strings.ToLower(strings.TrimSpace(directive[:index]))
+			if isRobotsTagUserAgent(prefix) {
+				isApplicable = prefix == productToken
+				directive = directive[index+1:]
+			}
+		}
+
+		if isApplicable {
+			directives =
+				mergeRobotsDirectives(directives, parseRobotsDirective(directive))
+		}
+	}
+
+	return directives
+}
+
+func isRobotsTagUserAgent(prefix string) bool {
+	if prefix == "" || strings.ContainsAny(prefix, ", \t") {
+		return false
+	}
+
+	for _, directive := range valuedRobotsDirectives {
+		if prefix == directive {
+			return false
+		}
+	}
+
+	return true
+}
+
+func parseRobotsDirectives(value string) models.RobotsDirectives {
+	var directives models.RobotsDirectives
+	for _, directive := range strings.Split(value, ",") {
+		directives =
+			mergeRobotsDirectives(directives, parseRobotsDirective(directive))
+	}
+
+	return directives
+}
+
+func parseRobotsDirective(directive string) models.RobotsDirectives {
+	switch strings.ToLower(strings.TrimSpace(directive)) {
+	case "noindex":
+		return models.RobotsDirectives{Noindex: true}
+	case "nofollow":
+		return models.RobotsDirectives{Nofollow: true}
+	case "none":
+		return models.RobotsDirectives{Noindex: true, Nofollow: true}
+	default:
+		return models.RobotsDirectives{}
+	}
+}
+
+func mergeRobotsDirectives(
+	directivesOne models.RobotsDirectives,
+	directivesTwo models.RobotsDirectives,
+) models.RobotsDirectives {
+	return models.RobotsDirectives{
+		Noindex:  directivesOne.Noindex || directivesTwo.Noindex,
+		Nofollow: directivesOne.Nofollow || directivesTwo.Nofollow,
+	}
+}
+
+type robotsMetaTag struct {
+	name    string
+	content string
+}
+
+type anchorTag struct {
+	href string
+	rel  string
+}
+
+func (tag anchorTag) isNofollow() bool {
+	for _, relation := range strings.Fields(tag.rel) {
+		if strings.EqualFold(relation, "nofollow") {
+			return true
+		}
+	}
+
+	return false
+}
+
+type robotsDirectivesBuilder struct {
+	currentTagName string
+	metaTags       []robotsMetaTag
+	anchorTags     []anchorTag
+}
+
+func (builder *robotsDirectivesBuilder) AddTag(name []byte) {
+	builder.currentTagName = string(name)
+	switch builder.currentTagName {
+	case "meta":
+		builder.metaTags = append(builder.metaTags, robotsMetaTag{})
+	case "a", "area":
+		builder.anchorTags = append(builder.anchorTags, anchorTag{})
+	}
+}
+
+func (builder *robotsDirectivesBuilder) AddAttribute(
+	name []byte,
+	value []byte,
+) {
+	switch builder.currentTagName {
+	case "meta":
+		metaTag := &builder.metaTags[len(builder.metaTags)-1]
+		switch string(name) {
+		case "name":
+			metaTag.name = string(value)
+		case "content":
+			metaTag.content = string(value)
+		}
+	case "a", "area":
+		anchorTag := &builder.anchorTags[len(builder.anchorTags)-1]
+		switch string(name) {
+		case "href":
+			anchorTag.href = strings.TrimSpace(string(value))
+		case "rel":
+			anchorTag.rel = string(value)
+		}
+	}
+}
+
+// it returns the links that are marked by the rel="nofollow" attribute
+// in all tags referring to them
+func (builder robotsDirectivesBuilder) nofollowLinks() map[string]struct{} {
+	nofollowLinks := make(map[string]struct{})
+	followedLinks := make(map[string]struct{})
+	for _, anchorTag := range builder.anchorTags {
+		if anchorTag.href == "" {
+			continue
+		}
+
+		if anchorTag.isNofollow() {
+			nofollowLinks[anchorTag.href] = struct{}{}
+		} else {
+			followedLinks[anchorTag.href] = struct{}{}
+		}
+	}
+	for link := range followedLinks {
+		delete(nofollowLinks, link)
+	}
+
+	return nofollowLinks
+}
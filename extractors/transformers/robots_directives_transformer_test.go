@@ -0,0 +1,426 @@
+package transformers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestRobotsDirectivesTransformer_TransformLinks(test *testing.T) {
+	type fields struct {
+		UserAgent string
+	}
+	type args struct {
+		links           []string
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name           string
+		fields         fields
+		args           args
+		wantLinks      []string
+		wantDirectives models.RobotsDirectives
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without directives",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<meta name="description" content="noindex, nofollow" />
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2" rel="external">2</a>
+				`),
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the noindex directive in the <meta /> tag",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<meta name="Robots" content="NoIndex" />
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2">2</a>
+				`),
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{Noindex: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the nofollow directive in the <meta /> tag",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<meta name="go-crawler" content="index, nofollow" />
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2">2</a>
+				`),
+			},
+			wantLinks:      nil,
+			wantDirectives: models.RobotsDirectives{Nofollow: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the <meta /> tag for another user agent",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<meta name="googlebot" content="none" />
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2">2</a>
+				`),
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the directives in the headers",
+			fields: fields{
+				UserAgent: "go-crawler/1.0",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Header: http.Header{
+						RobotsTagHeaderName: {
+							"unavailable_after: 25 Jun 2010 15:00:00 PST",
+							"googlebot: nofollow",
+							"Go-Crawler: noindex",
+						},
+					},
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: nil,
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{Noindex: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the sections for few user agents in the header",
+			fields: fields{
+				UserAgent: "go-crawler/1.0",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Header: http.Header{
+						RobotsTagHeaderName: {
+							"googlebot: nofollow, noarchive, go-crawler: noindex, nosnippet",
+						},
+					},
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: nil,
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{Noindex: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the common and specific sections in the header",
+			fields: fields{
+				UserAgent: "go-crawler/1.0",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Header: http.Header{
+						RobotsTagHeaderName: {
+							"unavailable_after: Friday, 25 Jun 2010 15:00:00 PST, " +
+								"noindex, googlebot: nofollow",
+						},
+					},
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: nil,
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/2"},
+			wantDirectives: models.RobotsDirectives{Noindex: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the none directive in the header",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{"http://example.com/1", "http://example.com/2"},
+				response: &http.Response{
+					Header: http.Header{RobotsTagHeaderName: {"none"}},
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: nil,
+			},
+			wantLinks:      nil,
+			wantDirectives: models.RobotsDirectives{Noindex: true, Nofollow: true},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success with the nofollow links",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links: []string{
+					"http://example.com/1",
+					"http://example.com/2",
+					"http://example.com/3",
+					"http://example.com/4",
+				},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2" rel="external NoFollow">2</a>
+					<area href="http://example.com/3" rel="nofollow" />
+					<a href="http://example.com/4" rel="nofollow">4</a>
+					<a href="http://example.com/4">4</a>
+				`),
+			},
+			wantLinks:      []string{"http://example.com/1", "http://example.com/4"},
+			wantDirectives: models.RobotsDirectives{},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "success without a response",
+			fields: fields{
+				UserAgent: "go-crawler",
+			},
+			args: args{
+				links:    []string{"http://example.com/1", "http://example.com/2"},
+				response: nil,
+				responseContent: []byte(`
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2" rel="nofollow">2</a>
+				`),
+			},
+			wantLinks:      []string{"http://example.com/1"},
+			wantDirectives: models.RobotsDirectives{},
+			wantErr:        assert.NoError,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			transformer := RobotsDirectivesTransformer{
+				UserAgent: data.fields.UserAgent,
+			}
+			gotLinks, gotErr := transformer.TransformLinks(
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+			gotPage, _, _ := transformer.TransformHTMLPage(
+				models.RichLink{Link: "http://example.com/"},
+				nil,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			wantPage := models.RichLink{Link: "http://example.com/"}
+			if data.wantDirectives != (models.RobotsDirectives{}) {
+				wantPage.Attributes = wantPage.Attributes.
+					Set(models.RobotsDirectivesAttribute, data.wantDirectives)
+			}
+
+			assert.Equal(test, data.wantLinks, gotLinks)
+			assert.Equal(test, wantPage, gotPage)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestRobotsDirectivesTransformer_TransformHTMLLinks(test *testing.T) {
+	type args struct {
+		links           []models.HTMLLink
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name      string
+		args      args
+		wantLinks []models.HTMLLink
+	}{
+		{
+			name: "success with the nofollow links",
+			args: args{
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+					{
+						Link: "http://example.com/2",
+						Metadata: models.HTMLMetadata{
+							TagName: "a",
+							Rel:     "nofollow",
+						},
+					},
+				},
+				response: &http.Response{
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`
+					<a href="http://example.com/1">1</a>
+					<a href="http://example.com/2" rel="nofollow">2</a>
+				`),
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+			},
+		},
+		{
+			name: "success with the nofollow directive",
+			args: args{
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response: &http.Response{
+					Header: http.Header{RobotsTagHeaderName: {"nofollow"}},
+					Request: httptest.NewRequest(
+						http.MethodGet,
+						"http://example.com/",
+						nil,
+					),
+				},
+				responseContent: []byte(`<a href="http://example.com/1">1</a>`),
+			},
+			wantLinks: nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			transformer := RobotsDirectivesTransformer{UserAgent: "go-crawler"}
+			gotLinks, gotErr := transformer.TransformHTMLLinks(
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			assert.Equal(test, data.wantLinks, gotLinks)
+			assert.NoError(test, gotErr)
+		})
+	}
+}
+
+func TestRobotsDirectivesTransformer_TransformHTMLPage(test *testing.T) {
+	transformer := RobotsDirectivesTransformer{UserAgent: "go-crawler"}
+	gotPage, gotLinks, gotErr := transformer.TransformHTMLPage(
+		models.RichLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Depth:      1,
+			Attributes: models.Attributes{}.
+				Set(models.StatusCodeAttribute, http.StatusOK),
+		},
+		[]models.HTMLLink{
+			{
+				Link:     "http://example.com/1",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+		},
+		&http.Response{
+			Header: http.Header{RobotsTagHeaderName: {"noindex, nofollow"}},
+			Request: httptest.NewRequest(
+				http.MethodGet,
+				"http://example.com/test",
+				nil,
+			),
+		},
+		[]byte(`<a href="http://example.com/1">1</a>`),
+	)
+
+	assert.Equal(test, models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+		Attributes: models.Attributes{}.
+			Set(models.StatusCodeAttribute, http.StatusOK).
+			Set(
+				models.RobotsDirectivesAttribute,
+				models.RobotsDirectives{Noindex: true, Nofollow: true},
+			),
+	}, gotPage)
+	assert.Nil(test, gotLinks)
+	assert.NoError(test, gotErr)
+}
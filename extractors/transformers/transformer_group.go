@@ -31,3 +31,44 @@ func (transformers TransformerGroup) TransformLinks(
 
 	return transformedLinks, nil
 }
+
+// TransformHTMLLinks ...
+func (transformers TransformerGroup) TransformHTMLLinks(
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	_, transformedLinks, err := transformers.TransformHTMLPage(
+		models.RichLink{},
+		links,
+		response,
+		responseContent,
+	)
+	return transformedLinks, err
+}
+
+// TransformHTMLPage ...
+func (transformers TransformerGroup) TransformHTMLPage(
+	page models.RichLink,
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) (models.RichLink, []models.HTMLLink, error) {
+	transformedPage, transformedLinks := page, links
+	for index, transformer := range transformers {
+		var err error
+		transformedPage, transformedLinks, err = TransformHTMLPage(
+			transformer,
+			transformedPage,
+			transformedLinks,
+			response,
+			responseContent,
+		)
+		if err != nil {
+			return models.RichLink{}, nil,
+				errors.Wrapf(err, "unable to transform links via transformer #%d", index)
+		}
+	}
+
+	return transformedPage, transformedLinks, nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/go-crawler/models"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 )
 
 func TestTransformerGroup_TransformLinks(test *testing.T) {
@@ -199,3 +200,148 @@ func TestTransformerGroup_TransformLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestTransformerGroup_TransformHTMLLinks(test *testing.T) {
+	type args struct {
+		links           []models.HTMLLink
+		response        *http.Response
+		responseContent []byte
+	}
+
+	for _, data := range []struct {
+		name         string
+		transformers TransformerGroup
+		args         args
+		wantLinks    []models.HTMLLink
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			transformers: TransformerGroup{
+				TrimmingTransformer{TrimLink: urlutils.TrimLink},
+				func() models.LinkTransformer {
+					transformer := new(MockLinkTransformer)
+					transformer.
+						On(
+							"TransformLinks",
+							[]string{"http://example.com/1", "http://example.com/2"},
+							(*http.Response)(nil),
+							[]byte("content"),
+						).
+						Return([]string{"http://example.com/1"}, nil)
+
+					return transformer
+				}(),
+			},
+			args: args{
+				links: []models.HTMLLink{
+					{
+						Link:     "  http://example.com/1  ",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+					{
+						Link:     "  http://example.com/2  ",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: []byte("content"),
+			},
+			wantLinks: []models.HTMLLink{
+				{
+					Link:     "http://example.com/1",
+					Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			transformers: TransformerGroup{
+				func() models.LinkTransformer {
+					transformer := new(MockLinkTransformer)
+					transformer.
+						On(
+							"TransformLinks",
+							[]string{"http://example.com/1"},
+							(*http.Response)(nil),
+							[]byte("content"),
+						).
+						Return(nil, iotest.ErrTimeout)
+
+					return transformer
+				}(),
+			},
+			args: args{
+				links: []models.HTMLLink{
+					{
+						Link:     "http://example.com/1",
+						Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+					},
+				},
+				response:        nil,
+				responseContent: []byte("content"),
+			},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotLinks, gotErr := data.transformers.TransformHTMLLinks(
+				data.args.links,
+				data.args.response,
+				data.args.responseContent,
+			)
+
+			for _, transformer := range data.transformers {
+				if transformer, ok := transformer.(*MockLinkTransformer); ok {
+					transformer.AssertExpectations(test)
+				}
+			}
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestTransformerGroup_TransformHTMLPage(test *testing.T) {
+	transformers := TransformerGroup{
+		RobotsDirectivesTransformer{UserAgent: "go-crawler"},
+		TrimmingTransformer{TrimLink: urlutils.TrimLink},
+	}
+	gotPage, gotLinks, gotErr := transformers.TransformHTMLPage(
+		models.RichLink{Link: "http://example.com/"},
+		[]models.HTMLLink{
+			{
+				Link:     "  http://example.com/1  ",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+			{
+				Link:     "  http://example.com/2  ",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+		},
+		&http.Response{
+			Header: http.Header{RobotsTagHeaderName: {"noindex"}},
+		},
+		[]byte(`
+			<a href="  http://example.com/1  ">1</a>
+			<a href="  http://example.com/2  " rel="nofollow">2</a>
+		`),
+	)
+
+	assert.Equal(test, models.RichLink{
+		Link: "http://example.com/",
+		Attributes: models.Attributes{}.Set(
+			models.RobotsDirectivesAttribute,
+			models.RobotsDirectives{Noindex: true},
+		),
+	}, gotPage)
+	assert.Equal(test, []models.HTMLLink{
+		{
+			Link:     "http://example.com/1",
+			Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+		},
+	}, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -3,6 +3,7 @@ package transformers
 import (
 	"net/http"
 
+	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 )
 
@@ -25,3 +26,18 @@ func (transformer TrimmingTransformer) TransformLinks(
 
 	return trimmedLinks, nil
 }
+
+// TransformHTMLLinks ...
+func (transformer TrimmingTransformer) TransformHTMLLinks(
+	links []models.HTMLLink,
+	response *http.Response,
+	responseContent []byte,
+) ([]models.HTMLLink, error) {
+	var trimmedLinks []models.HTMLLink
+	for _, link := range links {
+		link.Link = urlutils.ApplyLinkTrimming(link.Link, transformer.TrimLink)
+		trimmedLinks = append(trimmedLinks, link)
+	}
+
+	return trimmedLinks, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 )
 
@@ -99,3 +100,34 @@ func TestTrimmingTransformer_TransformLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestTrimmingTransformer_TransformHTMLLinks(test *testing.T) {
+	transformer := TrimmingTransformer{TrimLink: urlutils.TrimLink}
+	gotLinks, gotErr := transformer.TransformHTMLLinks(
+		[]models.HTMLLink{
+			{
+				Link:     "  http://example.com/1  ",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+			{
+				Link:     "  http://example.com/2  ",
+				Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+			},
+		},
+		nil,
+		nil,
+	)
+
+	wantLinks := []models.HTMLLink{
+		{
+			Link:     "http://example.com/1",
+			Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+		},
+		{
+			Link:     "http://example.com/2",
+			Metadata: models.HTMLMetadata{TagName: "a", AttributeName: "href"},
+		},
+	}
+	assert.Equal(test, wantLinks, gotLinks)
+	assert.NoError(test, gotErr)
+}
@@ -3,6 +3,7 @@ package extractors
 import (
 	"context"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/extractors/transformers"
 	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
@@ -33,3 +34,26 @@ func (extractor TrimmingExtractor) ExtractLinks(
 		trimmingTransformer.TransformLinks(links, nil, nil)
 	return trimmedLinks, nil
 }
+
+// ExtractRichLinks ...
+//
+// The attributes of the page and the links are kept.
+func (extractor TrimmingExtractor) ExtractRichLinks(
+	ctx context.Context,
+	threadID int,
+	link models.RichLink,
+) (models.RichLink, []models.RichLink, error) {
+	page, links, err := adapters.LinkExtractorToV2(extractor.LinkExtractor).
+		ExtractRichLinks(ctx, threadID, link)
+	if err != nil {
+		return models.RichLink{}, nil, err
+	}
+
+	var trimmedLinks []models.RichLink
+	for _, link := range links {
+		link.Link = urlutils.ApplyLinkTrimming(link.Link, extractor.TrimLink)
+		trimmedLinks = append(trimmedLinks, link)
+	}
+
+	return page, trimmedLinks, nil
+}
@@ -6,6 +6,7 @@ import (
 	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/thewizardplusplus/go-crawler/models"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 )
@@ -156,3 +157,156 @@ func TestTrimmingExtractor_ExtractLinks(test *testing.T) {
 		})
 	}
 }
+
+func TestTrimmingExtractor_ExtractRichLinks(test *testing.T) {
+	type fields struct {
+		TrimLink      urlutils.LinkTrimming
+		LinkExtractor models.LinkExtractor
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     models.RichLink
+	}
+
+	for _, data := range []struct {
+		name      string
+		fields    fields
+		args      args
+		wantPage  models.RichLink
+		wantLinks []models.RichLink
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the first version of the extractor",
+			fields: fields{
+				TrimLink: urlutils.TrimLink,
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockLinkExtractor)
+					extractor.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/").
+						Return([]string{"  http://example.com/1  "}, nil)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage: models.RichLink{Link: "http://example.com/"},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the second version of the extractor",
+			fields: fields{
+				TrimLink: urlutils.TrimLink,
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockRichLinkExtractor)
+					extractor.
+						On(
+							"ExtractRichLinks",
+							context.Background(),
+							23,
+							models.RichLink{Link: "http://example.com/"},
+						).
+						Return(
+							models.RichLink{
+								Link: "http://example.com/",
+								Attributes: models.Attributes{}.
+									Set(models.StatusCodeAttribute, 200),
+							},
+							[]models.RichLink{
+								{
+									SourceLink: "http://example.com/",
+									Link:       "  http://example.com/1  ",
+									Depth:      1,
+									Attributes: models.Attributes{}.Set(
+										models.HTMLMetadataAttribute,
+										models.HTMLMetadata{TagName: "a"},
+									),
+								},
+							},
+							nil,
+						)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage: models.RichLink{
+				Link: "http://example.com/",
+				Attributes: models.Attributes{}.
+					Set(models.StatusCodeAttribute, 200),
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+					Attributes: models.Attributes{}.Set(
+						models.HTMLMetadataAttribute,
+						models.HTMLMetadata{TagName: "a"},
+					),
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				TrimLink: urlutils.TrimLink,
+				LinkExtractor: func() models.LinkExtractor {
+					extractor := new(MockRichLinkExtractor)
+					extractor.
+						On(
+							"ExtractRichLinks",
+							context.Background(),
+							23,
+							models.RichLink{Link: "http://example.com/"},
+						).
+						Return(models.RichLink{}, nil, iotest.ErrTimeout)
+
+					return extractor
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+			},
+			wantPage:  models.RichLink{},
+			wantLinks: nil,
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			extractor := TrimmingExtractor{
+				TrimLink:      data.fields.TrimLink,
+				LinkExtractor: data.fields.LinkExtractor,
+			}
+			gotPage, gotLinks, gotErr := extractor.ExtractRichLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.link,
+			)
+
+			mock.AssertExpectationsForObjects(test, data.fields.LinkExtractor)
+			assert.Equal(test, data.wantPage, gotPage)
+			assert.Equal(test, data.wantLinks, gotLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package frontiers
+
+import (
+	"sync"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkContainer ...
+//
+// It's not required to be safe for a concurrent use.
+type LinkContainer interface {
+	PushLink(link models.RichLink)
+	PopLink() models.RichLink
+	Len() int
+}
+
+// BlockingFrontier ...
+type BlockingFrontier struct {
+	linkContainer LinkContainer
+	isClosed      bool
+	locker        sync.Mutex
+	notifier      *sync.Cond
+}
+
+// NewBlockingFrontier ...
+func NewBlockingFrontier(linkContainer LinkContainer) *BlockingFrontier {
+	frontier := &BlockingFrontier{linkContainer: linkContainer}
+	frontier.notifier = sync.NewCond(&frontier.locker)
+
+	return frontier
+}
+
+// NewBreadthFirstFrontier ...
+func NewBreadthFirstFrontier() *BlockingFrontier {
+	return NewBlockingFrontier(new(LinkQueue))
+}
+
+// NewDepthFirstFrontier ...
+func NewDepthFirstFrontier() *BlockingFrontier {
+	return NewBlockingFrontier(new(LinkStack))
+}
+
+// NewPriorityFrontier ...
+func NewPriorityFrontier(scoreLink LinkScoring) *BlockingFrontier {
+	return NewBlockingFrontier(NewLinkHeap(scoreLink))
+}
+
+// PushLink ...
+func (frontier *BlockingFrontier) PushLink(link models.RichLink) {
+	frontier.locker.Lock()
+	defer frontier.locker.Unlock()
+
+	frontier.linkContainer.PushLink(link)
+	frontier.notifier.Signal()
+}
+
+// PopLink ...
+//
+// The links remaining after closing of the frontier are still returned.
+func (frontier *BlockingFrontier) PopLink() (link models.RichLink, ok bool) {
+	frontier.locker.Lock()
+	defer frontier.locker.Unlock()
+
+	for frontier.linkContainer.Len() == 0 && !frontier.isClosed {
+		frontier.notifier.Wait()
+	}
+	if frontier.linkContainer.Len() == 0 {
+		return models.RichLink{}, false
+	}
+
+	return frontier.linkContainer.PopLink(), true
+}
+
+// Close ...
+func (frontier *BlockingFrontier) Close() {
+	frontier.locker.Lock()
+	defer frontier.locker.Unlock()
+
+	frontier.isClosed = true
+	frontier.notifier.Broadcast()
+}
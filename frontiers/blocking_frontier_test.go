@@ -0,0 +1,99 @@
+package frontiers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestBlockingFrontier(test *testing.T) {
+	links := []models.RichLink{
+		{Link: "http://example.com/1", Depth: 1},
+		{Link: "http://example.com/2", Depth: 3},
+		{Link: "http://example.com/3", Depth: 2},
+		{Link: "http://example.com/4", Depth: 3},
+	}
+
+	for _, data := range []struct {
+		name      string
+		frontier  *BlockingFrontier
+		wantLinks []models.RichLink
+	}{
+		{
+			name:      "breadth-first",
+			frontier:  NewBreadthFirstFrontier(),
+			wantLinks: links,
+		},
+		{
+			name:     "depth-first",
+			frontier: NewDepthFirstFrontier(),
+			wantLinks: []models.RichLink{
+				{Link: "http://example.com/4", Depth: 3},
+				{Link: "http://example.com/3", Depth: 2},
+				{Link: "http://example.com/2", Depth: 3},
+				{Link: "http://example.com/1", Depth: 1},
+			},
+		},
+		{
+			name: "priority",
+			frontier: NewPriorityFrontier(func(link models.RichLink) float64 {
+				return float64(link.Depth)
+			}),
+			wantLinks: []models.RichLink{
+				{Link: "http://example.com/2", Depth: 3},
+				{Link: "http://example.com/4", Depth: 3},
+				{Link: "http://example.com/3", Depth: 2},
+				{Link: "http://example.com/1", Depth: 1},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			for _, link := range links {
+				data.frontier.PushLink(link)
+			}
+			data.frontier.Close()
+
+			var gotLinks []models.RichLink
+			for {
+				link, ok := data.frontier.PopLink()
+				if !ok {
+					break
+				}
+
+				gotLinks = append(gotLinks, link)
+			}
+
+			assert.Equal(test, data.wantLinks, gotLinks)
+		})
+	}
+}
+
+func TestBlockingFrontier_PopLink(test *testing.T) {
+	test.Run("waiting for a pushed link", func(test *testing.T) {
+		frontier := NewBreadthFirstFrontier()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			frontier.PushLink(models.RichLink{Link: "http://example.com/"})
+		}()
+
+		gotLink, gotOk := frontier.PopLink()
+
+		assert.Equal(test, models.RichLink{Link: "http://example.com/"}, gotLink)
+		assert.True(test, gotOk)
+	})
+
+	test.Run("waiting for closing", func(test *testing.T) {
+		frontier := NewBreadthFirstFrontier()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			frontier.Close()
+		}()
+
+		gotLink, gotOk := frontier.PopLink()
+
+		assert.Equal(test, models.RichLink{}, gotLink)
+		assert.False(test, gotOk)
+	})
+}
@@ -0,0 +1,37 @@
+package frontiers
+
+import (
+	"github.com/thewizardplusplus/go-crawler/models"
+	syncutils "github.com/thewizardplusplus/go-sync-utils"
+)
+
+// ChannelFrontier ...
+//
+// The order of the links in it depends on the goroutine scheduling.
+type ChannelFrontier struct {
+	links chan models.RichLink
+}
+
+// NewChannelFrontier ...
+func NewChannelFrontier(bufferSize int) ChannelFrontier {
+	return ChannelFrontier{
+		links: make(chan models.RichLink, bufferSize),
+	}
+}
+
+// PushLink ...
+func (frontier ChannelFrontier) PushLink(link models.RichLink) {
+	// use unbounded sending to avoid a deadlock
+	syncutils.UnboundedSend(frontier.links, link)
+}
+
+// PopLink ...
+func (frontier ChannelFrontier) PopLink() (link models.RichLink, ok bool) {
+	link, ok = <-frontier.links
+	return link, ok
+}
+
+// Close ...
+func (frontier ChannelFrontier) Close() {
+	close(frontier.links)
+}
@@ -0,0 +1,61 @@
+package frontiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestNewChannelFrontier(test *testing.T) {
+	got := NewChannelFrontier(23)
+
+	assert.NotNil(test, got.links)
+	assert.Equal(test, 23, cap(got.links))
+}
+
+func TestChannelFrontier(test *testing.T) {
+	for _, data := range []struct {
+		name       string
+		bufferSize int
+		links      []models.RichLink
+	}{
+		{
+			name:       "with fewer links than the buffer size",
+			bufferSize: 10,
+			links: []models.RichLink{
+				{Link: "http://example.com/1"},
+				{Link: "http://example.com/2"},
+			},
+		},
+		{
+			name:       "without a buffer",
+			bufferSize: 0,
+			links: []models.RichLink{
+				{Link: "http://example.com/1"},
+				{Link: "http://example.com/2"},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			frontier := NewChannelFrontier(data.bufferSize)
+			for _, link := range data.links {
+				frontier.PushLink(link)
+			}
+
+			var gotLinks []models.RichLink
+			for range data.links {
+				link, ok := frontier.PopLink()
+				assert.True(test, ok)
+
+				gotLinks = append(gotLinks, link)
+			}
+
+			frontier.Close()
+			_, ok := frontier.PopLink()
+
+			assert.ElementsMatch(test, data.links, gotLinks)
+			assert.False(test, ok)
+		})
+	}
+}
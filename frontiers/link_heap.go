@@ -0,0 +1,81 @@
+package frontiers
+
+import (
+	"container/heap"
+
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkScoring ...
+//
+// The links with greater scores are popped first.
+type LinkScoring func(link models.RichLink) float64
+
+type scoredLink struct {
+	link  models.RichLink
+	score float64
+	// it's used to pop the links with equal scores in the pushing order
+	number int
+}
+
+type scoredLinkHeap []scoredLink
+
+func (links scoredLinkHeap) Len() int {
+	return len(links)
+}
+
+func (links scoredLinkHeap) Less(i int, j int) bool {
+	if links[i].score != links[j].score {
+		return links[i].score > links[j].score
+	}
+
+	return links[i].number < links[j].number
+}
+
+func (links scoredLinkHeap) Swap(i int, j int) {
+	links[i], links[j] = links[j], links[i]
+}
+
+func (links *scoredLinkHeap) Push(link interface{}) {
+	*links = append(*links, link.(scoredLink))
+}
+
+func (links *scoredLinkHeap) Pop() interface{} {
+	lastIndex := len(*links) - 1
+	link := (*links)[lastIndex]
+	*links = (*links)[:lastIndex]
+
+	return link
+}
+
+// LinkHeap ...
+type LinkHeap struct {
+	scoreLink  LinkScoring
+	links      scoredLinkHeap
+	pushNumber int
+}
+
+// NewLinkHeap ...
+func NewLinkHeap(scoreLink LinkScoring) *LinkHeap {
+	return &LinkHeap{scoreLink: scoreLink}
+}
+
+// PushLink ...
+func (links *LinkHeap) PushLink(link models.RichLink) {
+	heap.Push(&links.links, scoredLink{
+		link:   link,
+		score:  links.scoreLink(link),
+		number: links.pushNumber,
+	})
+	links.pushNumber++
+}
+
+// PopLink ...
+func (links *LinkHeap) PopLink() models.RichLink {
+	return heap.Pop(&links.links).(scoredLink).link
+}
+
+// Len ...
+func (links *LinkHeap) Len() int {
+	return links.links.Len()
+}
@@ -0,0 +1,30 @@
+package frontiers
+
+import (
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkQueue ...
+type LinkQueue struct {
+	links []models.RichLink
+}
+
+// PushLink ...
+func (queue *LinkQueue) PushLink(link models.RichLink) {
+	queue.links = append(queue.links, link)
+}
+
+// PopLink ...
+func (queue *LinkQueue) PopLink() models.RichLink {
+	link := queue.links[0]
+	// reset the popped item to allow its garbage collection
+	queue.links[0] = models.RichLink{}
+	queue.links = queue.links[1:]
+
+	return link
+}
+
+// Len ...
+func (queue *LinkQueue) Len() int {
+	return len(queue.links)
+}
@@ -0,0 +1,29 @@
+package frontiers
+
+import (
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// LinkStack ...
+type LinkStack struct {
+	links []models.RichLink
+}
+
+// PushLink ...
+func (stack *LinkStack) PushLink(link models.RichLink) {
+	stack.links = append(stack.links, link)
+}
+
+// PopLink ...
+func (stack *LinkStack) PopLink() models.RichLink {
+	lastIndex := len(stack.links) - 1
+	link := stack.links[lastIndex]
+	stack.links = stack.links[:lastIndex]
+
+	return link
+}
+
+// Len ...
+func (stack *LinkStack) Len() int {
+	return len(stack.links)
+}
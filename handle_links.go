@@ -3,6 +3,7 @@ package crawler
 import (
 	"context"
 
+	"github.com/thewizardplusplus/go-crawler/extractors"
 	"github.com/thewizardplusplus/go-crawler/models"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 )
@@ -18,7 +19,7 @@ type HandleLinkDependencies struct {
 func HandleLinksConcurrently(
 	ctx context.Context,
 	concurrencyFactor int,
-	links chan string,
+	links models.LinkFrontier,
 	dependencies HandleLinkDependencies,
 ) {
 	for threadID := 0; threadID < concurrencyFactor; threadID++ {
@@ -31,14 +32,30 @@ func HandleLinksConcurrently(
 func HandleLinks(
 	ctx context.Context,
 	threadID int,
-	links chan string,
+	links models.LinkFrontier,
 	dependencies HandleLinkDependencies,
 ) {
-	for link := range links {
+	acknowledger, isAcknowledger := links.(models.LinkAcknowledger)
+	for {
+		link, ok := links.PopLink()
+		if !ok {
+			break
+		}
+
+		if isAcknowledger {
+			// prevent completion of the crawling until the acknowledgement
+			dependencies.Waiter.Add(1)
+		}
+
 		extractedLinks := HandleLink(ctx, threadID, link, dependencies)
 		for _, extractedLink := range extractedLinks {
-			// use unbounded sending to avoid a deadlock
-			syncutils.UnboundedSend(links, extractedLink)
+			links.PushLink(extractedLink)
+		}
+
+		if isAcknowledger {
+			// it should be called after the pushing of the extracted links
+			acknowledger.AcknowledgeLink(link)
+			dependencies.Waiter.Done()
 		}
 	}
 }
@@ -47,27 +64,61 @@ func HandleLinks(
 func HandleLink(
 	ctx context.Context,
 	threadID int,
-	link string,
+	link models.RichLink,
 	dependencies HandleLinkDependencies,
-) []string {
+) []models.RichLink {
 	defer dependencies.Waiter.Done()
 
-	extractedLinks, err :=
-		dependencies.LinkExtractor.ExtractLinks(ctx, threadID, link)
+	if dependencies.PageBudget != nil && !dependencies.PageBudget.SpendPage() {
+		dependencies.StatsCollector.AddSkippedPage()
+		return nil
+	}
+
+	page, extractedLinks, err := dependencies.linkExtractorV2().
+		ExtractRichLinks(ctx, threadID, link)
 	if err != nil {
-		dependencies.Logger.Logf("unable to extract links for link %q: %s", link, err)
+		dependencies.StatsCollector.AddFailedPage()
+
+		const logMessage = "unable to extract links for link %q: %s"
+		dependencies.Logger.Logf(logMessage, link.Link, err)
+
+		models.HandleError(dependencies.ErrorHandler, models.CrawlingError{
+			Kind:     extractors.ClassifyError(err),
+			Link:     link.SourcedLink(),
+			ThreadID: threadID,
+			Err:      err,
+		})
+
 		return nil
 	}
 
-	var checkedExtractedLinks []string
+	dependencies.StatsCollector.AddExtractedPage()
+	dependencies.StatsCollector.AddExtractedLinks(len(extractedLinks))
+
+	if dependencies.PageHandler != nil {
+		dependencies.PageHandler.HandleRichLink(ctx, page)
+	}
+
+	linkHandler, linkChecker :=
+		dependencies.linkHandlerV2(), dependencies.linkCheckerV2()
+	var checkedExtractedLinks []models.RichLink
 	for _, extractedLink := range extractedLinks {
-		sourcedLink := models.SourcedLink{SourceLink: link, Link: extractedLink}
-		dependencies.LinkHandler.HandleLink(ctx, sourcedLink)
+		extractedLink.SourceLink = link.Link
+		extractedLink.Depth = link.Depth + 1
+
+		linkHandler.HandleRichLink(ctx, extractedLink)
+		dependencies.StatsCollector.AddHandledLink()
 
-		if !dependencies.LinkChecker.CheckLink(ctx, sourcedLink) {
+		// don't check the links that will not be crawled anyway
+		if dependencies.PageBudget != nil && dependencies.PageBudget.IsExhausted() {
+			continue
+		}
+		if !linkChecker.CheckRichLink(ctx, extractedLink) {
+			dependencies.StatsCollector.AddRejectedLink()
 			continue
 		}
 
+		dependencies.StatsCollector.AddCheckedLink()
 		checkedExtractedLinks = append(checkedExtractedLinks, extractedLink)
 		// it should be called before the dependencies.Waiter.Done() call
 		dependencies.Waiter.Add(1)
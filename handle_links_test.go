@@ -2,13 +2,18 @@ package crawler
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"testing"
 	"testing/iotest"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/extractors"
+	"github.com/thewizardplusplus/go-crawler/frontiers"
 	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/stats"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 )
 
@@ -16,7 +21,7 @@ func TestHandleLinksConcurrently(test *testing.T) {
 	type args struct {
 		ctx               context.Context
 		concurrencyFactor int
-		links             chan string
+		links             models.LinkFrontier
 		dependencies      HandleLinkDependencies
 	}
 
@@ -29,9 +34,9 @@ func TestHandleLinksConcurrently(test *testing.T) {
 			args: args{
 				ctx:               context.Background(),
 				concurrencyFactor: 10,
-				links: func() chan string {
-					links := make(chan string, 1)
-					links <- "http://example.com/"
+				links: func() models.LinkFrontier {
+					links := frontiers.NewChannelFrontier(1)
+					links.PushLink(models.RichLink{Link: "http://example.com/"})
 
 					return links
 				}(),
@@ -76,12 +81,14 @@ func TestHandleLinksConcurrently(test *testing.T) {
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return(true)
 							checker.
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return(true)
 
@@ -93,12 +100,14 @@ func TestHandleLinksConcurrently(test *testing.T) {
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return()
 							handler.
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return()
 
@@ -121,8 +130,10 @@ func TestHandleLinksConcurrently(test *testing.T) {
 		test.Run(data.name, func(t *testing.T) {
 			waiter := data.args.dependencies.Waiter
 			synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
-			synchronousWaiter.Add(len(data.args.links))
+			// the frontier contains the only link
+			synchronousWaiter.Add(1)
 			data.args.dependencies.Waiter = synchronousWaiter
+			data.args.dependencies.StatsCollector = stats.NewCollector()
 
 			HandleLinksConcurrently(
 				data.args.ctx,
@@ -148,7 +159,7 @@ func TestHandleLinks(test *testing.T) {
 	type args struct {
 		ctx          context.Context
 		threadID     int
-		links        chan string
+		links        models.LinkFrontier
 		dependencies HandleLinkDependencies
 	}
 
@@ -161,9 +172,9 @@ func TestHandleLinks(test *testing.T) {
 			args: args{
 				ctx:      context.Background(),
 				threadID: 23,
-				links: func() chan string {
-					links := make(chan string, 1)
-					links <- "http://example.com/"
+				links: func() models.LinkFrontier {
+					links := frontiers.NewChannelFrontier(1)
+					links.PushLink(models.RichLink{Link: "http://example.com/"})
 
 					return links
 				}(),
@@ -189,12 +200,14 @@ func TestHandleLinks(test *testing.T) {
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return(true)
 							checker.
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return(true)
 
@@ -206,12 +219,14 @@ func TestHandleLinks(test *testing.T) {
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return()
 							handler.
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return()
 
@@ -234,8 +249,10 @@ func TestHandleLinks(test *testing.T) {
 		test.Run(data.name, func(test *testing.T) {
 			waiter := data.args.dependencies.Waiter
 			synchronousWaiter := syncutils.MultiWaitGroup{waiter, new(sync.WaitGroup)}
-			synchronousWaiter.Add(len(data.args.links))
+			// the frontier contains the only link
+			synchronousWaiter.Add(1)
 			data.args.dependencies.Waiter = synchronousWaiter
+			data.args.dependencies.StatsCollector = stats.NewCollector()
 
 			go HandleLinks(
 				data.args.ctx,
@@ -258,24 +275,30 @@ func TestHandleLinks(test *testing.T) {
 }
 
 func TestHandleLink(test *testing.T) {
+	statusCodeErr := errors.Wrap(
+		extractors.StatusCodeError{StatusCode: http.StatusNotFound},
+		"unable to accept the response",
+	)
+
 	type args struct {
 		ctx          context.Context
 		threadID     int
-		link         string
+		link         models.RichLink
 		dependencies HandleLinkDependencies
 	}
 
 	for _, data := range []struct {
-		name      string
-		args      args
-		wantLinks []string
+		name       string
+		args       args
+		wantLinks  []models.RichLink
+		wantReport stats.Report
 	}{
 		{
 			name: "success with all correct links",
 			args: args{
 				ctx:      context.Background(),
 				threadID: 23,
-				link:     "http://example.com/",
+				link:     models.RichLink{Link: "http://example.com/"},
 				dependencies: HandleLinkDependencies{
 					CrawlDependencies: CrawlDependencies{
 						LinkExtractor: func() models.LinkExtractor {
@@ -292,12 +315,14 @@ func TestHandleLink(test *testing.T) {
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return(true)
 							checker.
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return(true)
 
@@ -309,12 +334,14 @@ func TestHandleLink(test *testing.T) {
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return()
 							handler.
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return()
 
@@ -331,14 +358,302 @@ func TestHandleLink(test *testing.T) {
 					}(),
 				},
 			},
-			wantLinks: []string{"http://example.com/1", "http://example.com/2"},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/2",
+					Depth:      1,
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   2,
+			},
+		},
+		{
+			name: "success with the page handler",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: new(MockLinkExtractor),
+						LinkChecker: func() models.LinkChecker {
+							checker := new(MockLinkChecker)
+							checker.
+								On("CheckLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/1",
+									Depth:      1,
+								}).
+								Return(true)
+
+							return checker
+						}(),
+						LinkHandler: func() models.LinkHandler {
+							handler := new(MockLinkHandler)
+							handler.
+								On("HandleLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/1",
+									Depth:      1,
+								}).
+								Return()
+
+							return handler
+						}(),
+						Logger: new(MockLogger),
+						LinkExtractorV2: func() models.LinkExtractorV2 {
+							extractor := new(MockLinkExtractorV2)
+							extractor.
+								On(
+									"ExtractRichLinks",
+									context.Background(),
+									23,
+									models.RichLink{Link: "http://example.com/"},
+								).
+								Return(
+									models.RichLink{
+										Link: "http://example.com/",
+										Attributes: models.Attributes{}.Set(
+											models.RobotsDirectivesAttribute,
+											models.RobotsDirectives{Noindex: true},
+										),
+									},
+									[]models.RichLink{{Link: "http://example.com/1"}},
+									nil,
+								)
+
+							return extractor
+						}(),
+						PageHandler: func() models.LinkHandlerV2 {
+							handler := new(MockLinkHandlerV2)
+							handler.
+								On("HandleRichLink", context.Background(), models.RichLink{
+									Link: "http://example.com/",
+									Attributes: models.Attributes{}.Set(
+										models.RobotsDirectivesAttribute,
+										models.RobotsDirectives{Noindex: true},
+									),
+								}).
+								Return()
+
+							return handler
+						}(),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Add", 1).Return().Times(1)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 1,
+				HandledLinkCount:   1,
+				CheckedLinkCount:   1,
+			},
+		},
+		{
+			name: "success with the page handler and without links",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: func() models.LinkExtractor {
+							extractor := new(MockLinkExtractor)
+							extractor.
+								On("ExtractLinks", context.Background(), 23, "http://example.com/").
+								Return(nil, nil)
+
+							return extractor
+						}(),
+						LinkChecker: new(MockLinkChecker),
+						LinkHandler: new(MockLinkHandler),
+						Logger:      new(MockLogger),
+						PageHandler: func() models.LinkHandlerV2 {
+							handler := new(MockLinkHandlerV2)
+							handler.
+								On(
+									"HandleRichLink",
+									context.Background(),
+									models.RichLink{Link: "http://example.com/"},
+								).
+								Return()
+
+							return handler
+						}(),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: nil,
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+			},
+		},
+		{
+			name: "success with the V2 dependencies",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/source",
+					Link:       "http://example.com/",
+					Depth:      1,
+				},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: new(MockLinkExtractor),
+						LinkChecker:   new(MockLinkChecker),
+						LinkHandler:   new(MockLinkHandler),
+						Logger:        new(MockLogger),
+						LinkExtractorV2: func() models.LinkExtractorV2 {
+							extractor := new(MockLinkExtractorV2)
+							extractor.
+								On(
+									"ExtractRichLinks",
+									context.Background(),
+									23,
+									models.RichLink{
+										SourceLink: "http://example.com/source",
+										Link:       "http://example.com/",
+										Depth:      1,
+									},
+								).
+								Return(
+									models.RichLink{
+										SourceLink: "http://example.com/source",
+										Link:       "http://example.com/",
+										Depth:      1,
+									},
+									[]models.RichLink{
+										{
+											Link: "http://example.com/1",
+											Attributes: models.Attributes{}.
+												Set(models.ContentTypeAttribute, "text/html"),
+										},
+										{
+											Link: "http://example.com/2",
+											Attributes: models.Attributes{}.Set(
+												models.HTMLMetadataAttribute,
+												models.HTMLMetadata{TagName: "img"},
+											),
+										},
+									},
+									nil,
+								)
+
+							return extractor
+						}(),
+						LinkCheckerV2: func() models.LinkCheckerV2 {
+							checker := new(MockLinkCheckerV2)
+							checker.
+								On("CheckRichLink", context.Background(), models.RichLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/1",
+									Depth:      2,
+									Attributes: models.Attributes{}.
+										Set(models.ContentTypeAttribute, "text/html"),
+								}).
+								Return(true)
+							checker.
+								On("CheckRichLink", context.Background(), models.RichLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/2",
+									Depth:      2,
+									Attributes: models.Attributes{}.Set(
+										models.HTMLMetadataAttribute,
+										models.HTMLMetadata{TagName: "img"},
+									),
+								}).
+								Return(false)
+
+							return checker
+						}(),
+						LinkHandlerV2: func() models.LinkHandlerV2 {
+							handler := new(MockLinkHandlerV2)
+							handler.
+								On("HandleRichLink", context.Background(), models.RichLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/1",
+									Depth:      2,
+									Attributes: models.Attributes{}.
+										Set(models.ContentTypeAttribute, "text/html"),
+								}).
+								Return()
+							handler.
+								On("HandleRichLink", context.Background(), models.RichLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/2",
+									Depth:      2,
+									Attributes: models.Attributes{}.Set(
+										models.HTMLMetadataAttribute,
+										models.HTMLMetadata{TagName: "img"},
+									),
+								}).
+								Return()
+
+							return handler
+						}(),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Add", 1).Return().Times(1)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      2,
+					Attributes: models.Attributes{}.
+						Set(models.ContentTypeAttribute, "text/html"),
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   1,
+				RejectedLinkCount:  1,
+			},
 		},
 		{
 			name: "success with some correct links",
 			args: args{
 				ctx:      context.Background(),
 				threadID: 23,
-				link:     "http://example.com/",
+				link:     models.RichLink{Link: "http://example.com/"},
 				dependencies: HandleLinkDependencies{
 					CrawlDependencies: CrawlDependencies{
 						LinkExtractor: func() models.LinkExtractor {
@@ -355,12 +670,14 @@ func TestHandleLink(test *testing.T) {
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return(false)
 							checker.
 								On("CheckLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return(true)
 
@@ -372,12 +689,14 @@ func TestHandleLink(test *testing.T) {
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/1",
+									Depth:      1,
 								}).
 								Return()
 							handler.
 								On("HandleLink", context.Background(), models.SourcedLink{
 									SourceLink: "http://example.com/",
 									Link:       "http://example.com/2",
+									Depth:      1,
 								}).
 								Return()
 
@@ -394,14 +713,177 @@ func TestHandleLink(test *testing.T) {
 					}(),
 				},
 			},
-			wantLinks: []string{"http://example.com/2"},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/2",
+					Depth:      1,
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   1,
+				RejectedLinkCount:  1,
+			},
+		},
+		{
+			name: "success with a non-zero depth",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/1",
+					Depth:      1,
+				},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: func() models.LinkExtractor {
+							extractor := new(MockLinkExtractor)
+							extractor.
+								On("ExtractLinks", context.Background(), 23, "http://example.com/1").
+								Return([]string{"http://example.com/1/1"}, nil)
+
+							return extractor
+						}(),
+						LinkChecker: func() models.LinkChecker {
+							checker := new(MockLinkChecker)
+							checker.
+								On("CheckLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/1",
+									Link:       "http://example.com/1/1",
+									Depth:      2,
+								}).
+								Return(true)
+
+							return checker
+						}(),
+						LinkHandler: func() models.LinkHandler {
+							handler := new(MockLinkHandler)
+							handler.
+								On("HandleLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/1",
+									Link:       "http://example.com/1/1",
+									Depth:      2,
+								}).
+								Return()
+
+							return handler
+						}(),
+						Logger: new(MockLogger),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Add", 1).Return().Times(1)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: []models.RichLink{
+				{
+					SourceLink: "http://example.com/1",
+					Link:       "http://example.com/1/1",
+					Depth:      2,
+				},
+			},
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 1,
+				HandledLinkCount:   1,
+				CheckedLinkCount:   1,
+			},
+		},
+		{
+			name: "success with the page budget exhausted by the current page",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: func() models.LinkExtractor {
+							extractor := new(MockLinkExtractor)
+							extractor.
+								On("ExtractLinks", context.Background(), 23, "http://example.com/").
+								Return([]string{"http://example.com/1", "http://example.com/2"}, nil)
+
+							return extractor
+						}(),
+						LinkChecker: new(MockLinkChecker),
+						LinkHandler: func() models.LinkHandler {
+							handler := new(MockLinkHandler)
+							handler.
+								On("HandleLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/1",
+									Depth:      1,
+								}).
+								Return()
+							handler.
+								On("HandleLink", context.Background(), models.SourcedLink{
+									SourceLink: "http://example.com/",
+									Link:       "http://example.com/2",
+									Depth:      1,
+								}).
+								Return()
+
+							return handler
+						}(),
+						Logger:     new(MockLogger),
+						PageBudget: NewPageBudget(1),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: nil,
+			wantReport: stats.Report{
+				ExtractedPageCount: 1,
+				ExtractedLinkCount: 2,
+				HandledLinkCount:   2,
+			},
+		},
+		{
+			name: "success with the exhausted page budget",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     models.RichLink{Link: "http://example.com/"},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: new(MockLinkExtractor),
+						LinkChecker:   new(MockLinkChecker),
+						LinkHandler:   new(MockLinkHandler),
+						Logger:        new(MockLogger),
+						PageBudget:    NewPageBudget(0),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: nil,
+			wantReport: stats.Report{
+				SkippedPageCount: 1,
+			},
 		},
 		{
 			name: "error",
 			args: args{
 				ctx:      context.Background(),
 				threadID: 23,
-				link:     "http://example.com/",
+				link:     models.RichLink{Link: "http://example.com/"},
 				dependencies: HandleLinkDependencies{
 					CrawlDependencies: CrawlDependencies{
 						LinkExtractor: func() models.LinkExtractor {
@@ -437,9 +919,157 @@ func TestHandleLink(test *testing.T) {
 				},
 			},
 			wantLinks: nil,
+			wantReport: stats.Report{
+				FailedPageCount: 1,
+			},
+		},
+		{
+			name: "error with an error handler",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Depth:      1,
+				},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: func() models.LinkExtractor {
+							extractor := new(MockLinkExtractor)
+							extractor.
+								On(
+									"ExtractLinks",
+									context.Background(),
+									23,
+									"http://example.com/test",
+								).
+								Return(nil, iotest.ErrTimeout)
+
+							return extractor
+						}(),
+						LinkChecker: new(MockLinkChecker),
+						LinkHandler: new(MockLinkHandler),
+						Logger: func() Logger {
+							logger := new(MockLogger)
+							logger.
+								On(
+									"Logf",
+									"unable to extract links for link %q: %s",
+									"http://example.com/test",
+									iotest.ErrTimeout,
+								).
+								Return()
+
+							return logger
+						}(),
+						ErrorHandler: func() ErrorHandler {
+							handler := new(MockErrorHandler)
+							handler.
+								On("HandleError", models.CrawlingError{
+									Kind: models.FetchError,
+									Link: models.SourcedLink{
+										SourceLink: "http://example.com/",
+										Link:       "http://example.com/test",
+										Depth:      1,
+									},
+									ThreadID: 23,
+									Err:      iotest.ErrTimeout,
+								}).
+								Return()
+
+							return handler
+						}(),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: nil,
+			wantReport: stats.Report{
+				FailedPageCount: 1,
+			},
+		},
+		{
+			name: "error with an error handler and a status code error",
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Depth:      1,
+				},
+				dependencies: HandleLinkDependencies{
+					CrawlDependencies: CrawlDependencies{
+						LinkExtractor: func() models.LinkExtractor {
+							extractor := new(MockLinkExtractor)
+							extractor.
+								On(
+									"ExtractLinks",
+									context.Background(),
+									23,
+									"http://example.com/test",
+								).
+								Return(nil, statusCodeErr)
+
+							return extractor
+						}(),
+						LinkChecker: new(MockLinkChecker),
+						LinkHandler: new(MockLinkHandler),
+						Logger: func() Logger {
+							logger := new(MockLogger)
+							logger.
+								On(
+									"Logf",
+									"unable to extract links for link %q: %s",
+									"http://example.com/test",
+									statusCodeErr,
+								).
+								Return()
+
+							return logger
+						}(),
+						ErrorHandler: func() ErrorHandler {
+							handler := new(MockErrorHandler)
+							handler.
+								On("HandleError", models.CrawlingError{
+									Kind: models.StatusError,
+									Link: models.SourcedLink{
+										SourceLink: "http://example.com/",
+										Link:       "http://example.com/test",
+										Depth:      1,
+									},
+									ThreadID: 23,
+									Err:      statusCodeErr,
+								}).
+								Return()
+
+							return handler
+						}(),
+					},
+					Waiter: func() syncutils.WaitGroup {
+						waiter := new(MockWaiter)
+						waiter.On("Done").Return().Times(1)
+
+						return waiter
+					}(),
+				},
+			},
+			wantLinks: nil,
+			wantReport: stats.Report{
+				FailedPageCount: 1,
+			},
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
+			statsCollector := stats.NewCollector()
+			data.args.dependencies.StatsCollector = statsCollector
+
 			gotLinks := HandleLink(
 				data.args.ctx,
 				data.args.threadID,
@@ -455,7 +1085,78 @@ func TestHandleLink(test *testing.T) {
 				data.args.dependencies.LinkHandler,
 				data.args.dependencies.Logger,
 			)
+			if data.args.dependencies.ErrorHandler != nil {
+				mock.AssertExpectationsForObjects(
+					test,
+					data.args.dependencies.ErrorHandler,
+				)
+			}
+			if data.args.dependencies.PageHandler != nil {
+				mock.AssertExpectationsForObjects(
+					test,
+					data.args.dependencies.PageHandler,
+				)
+			}
+			if data.args.dependencies.LinkExtractorV2 != nil {
+				mock.AssertExpectationsForObjects(
+					test,
+					data.args.dependencies.LinkExtractorV2,
+				)
+			}
+			if data.args.dependencies.LinkCheckerV2 != nil {
+				mock.AssertExpectationsForObjects(
+					test,
+					data.args.dependencies.LinkCheckerV2,
+				)
+			}
+			if data.args.dependencies.LinkHandlerV2 != nil {
+				mock.AssertExpectationsForObjects(
+					test,
+					data.args.dependencies.LinkHandlerV2,
+				)
+			}
 			assert.Equal(test, data.wantLinks, gotLinks)
+			assert.Equal(test, data.wantReport, statsCollector.Report())
 		})
 	}
 }
+
+func TestHandleLink_withoutStatsCollector(test *testing.T) {
+	extractor := new(MockLinkExtractor)
+	extractor.
+		On("ExtractLinks", context.Background(), 23, "http://example.com/").
+		Return([]string{"http://example.com/1"}, nil)
+
+	sourcedLink := models.SourcedLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/1",
+		Depth:      1,
+	}
+	checker := new(MockLinkChecker)
+	checker.On("CheckLink", context.Background(), sourcedLink).Return(true)
+
+	handler := new(MockLinkHandler)
+	handler.On("HandleLink", context.Background(), sourcedLink).Return()
+
+	waiter := new(MockWaiter)
+	waiter.On("Add", 1).Return().Times(1)
+	waiter.On("Done").Return().Times(1)
+
+	gotLinks := HandleLink(
+		context.Background(),
+		23,
+		models.RichLink{Link: "http://example.com/"},
+		HandleLinkDependencies{
+			CrawlDependencies: CrawlDependencies{
+				LinkExtractor: extractor,
+				LinkChecker:   checker,
+				LinkHandler:   handler,
+				Logger:        new(MockLogger),
+			},
+			Waiter: waiter,
+		},
+	)
+
+	mock.AssertExpectationsForObjects(test, extractor, checker, handler, waiter)
+	assert.Equal(test, []models.RichLink{models.NewRichLink(sourcedLink)}, gotLinks)
+}
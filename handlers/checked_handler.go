@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
@@ -23,3 +24,15 @@ func (handler CheckedHandler) HandleLink(
 
 	handler.LinkHandler.HandleLink(ctx, link)
 }
+
+// HandleRichLink ...
+func (handler CheckedHandler) HandleRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) {
+	if !adapters.LinkCheckerToV2(handler.LinkChecker).CheckRichLink(ctx, link) {
+		return
+	}
+
+	adapters.LinkHandlerToV2(handler.LinkHandler).HandleRichLink(ctx, link)
+}
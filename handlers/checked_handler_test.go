@@ -97,3 +97,160 @@ func TestCheckedHandler_HandleLink(test *testing.T) {
 		})
 	}
 }
+
+func TestCheckedHandler_HandleRichLink(test *testing.T) {
+	type richChecker struct {
+		*MockLinkChecker
+		*MockLinkCheckerV2
+	}
+	type richHandler struct {
+		*MockLinkHandler
+		*MockLinkHandlerV2
+	}
+	type fields struct {
+		LinkChecker models.LinkChecker
+		LinkHandler models.LinkHandler
+	}
+	type args struct {
+		ctx  context.Context
+		link models.RichLink
+	}
+
+	for _, data := range []struct {
+		name   string
+		fields fields
+		args   args
+	}{
+		{
+			name: "with the first versions of the checker and the handler",
+			fields: fields{
+				LinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkChecker)
+					checker.
+						On("CheckLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+						}).
+						Return(true)
+
+					return checker
+				}(),
+				LinkHandler: func() models.LinkHandler {
+					handler := new(MockLinkHandler)
+					handler.
+						On("HandleLink", context.Background(), models.SourcedLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+						}).
+						Return()
+
+					return handler
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Attributes: models.Attributes{}.
+						Set(models.ContentTypeAttribute, "text/html"),
+				},
+			},
+		},
+		{
+			name: "with the second versions of the checker and the handler",
+			fields: fields{
+				LinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkCheckerV2)
+					checker.
+						On("CheckRichLink", context.Background(), models.RichLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+							Attributes: models.Attributes{}.
+								Set(models.ContentTypeAttribute, "text/html"),
+						}).
+						Return(true)
+
+					return richChecker{
+						MockLinkChecker:   new(MockLinkChecker),
+						MockLinkCheckerV2: checker,
+					}
+				}(),
+				LinkHandler: func() models.LinkHandler {
+					handler := new(MockLinkHandlerV2)
+					handler.
+						On("HandleRichLink", context.Background(), models.RichLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+							Attributes: models.Attributes{}.
+								Set(models.ContentTypeAttribute, "text/html"),
+						}).
+						Return()
+
+					return richHandler{
+						MockLinkHandler:   new(MockLinkHandler),
+						MockLinkHandlerV2: handler,
+					}
+				}(),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+					Attributes: models.Attributes{}.
+						Set(models.ContentTypeAttribute, "text/html"),
+				},
+			},
+		},
+		{
+			name: "with a not passed checking",
+			fields: fields{
+				LinkChecker: func() models.LinkChecker {
+					checker := new(MockLinkCheckerV2)
+					checker.
+						On("CheckRichLink", context.Background(), models.RichLink{
+							SourceLink: "http://example.com/",
+							Link:       "http://example.com/test",
+						}).
+						Return(false)
+
+					return richChecker{
+						MockLinkChecker:   new(MockLinkChecker),
+						MockLinkCheckerV2: checker,
+					}
+				}(),
+				LinkHandler: new(MockLinkHandler),
+			},
+			args: args{
+				ctx: context.Background(),
+				link: models.RichLink{
+					SourceLink: "http://example.com/",
+					Link:       "http://example.com/test",
+				},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			handler := CheckedHandler{
+				LinkChecker: data.fields.LinkChecker,
+				LinkHandler: data.fields.LinkHandler,
+			}
+			handler.HandleRichLink(data.args.ctx, data.args.link)
+
+			for _, object := range []interface{}{
+				data.fields.LinkChecker,
+				data.fields.LinkHandler,
+			} {
+				switch object := object.(type) {
+				case richChecker:
+					mock.AssertExpectationsForObjects(test, object.MockLinkCheckerV2)
+				case richHandler:
+					mock.AssertExpectationsForObjects(test, object.MockLinkHandlerV2)
+				default:
+					mock.AssertExpectationsForObjects(test, object)
+				}
+			}
+		})
+	}
+}
@@ -3,19 +3,20 @@ package handlers
 import (
 	"context"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 	syncutils "github.com/thewizardplusplus/go-sync-utils"
 )
 
 type linkHandlerWrapper struct {
-	linkHandler models.LinkHandler
+	linkHandler models.LinkHandlerV2
 }
 
 func (wrapper linkHandlerWrapper) Handle(
 	ctx context.Context,
 	data interface{},
 ) {
-	wrapper.linkHandler.HandleLink(ctx, data.(models.SourcedLink))
+	wrapper.linkHandler.HandleRichLink(ctx, data.(models.RichLink))
 }
 
 // ConcurrentHandler ...
@@ -28,6 +29,17 @@ type ConcurrentHandler struct {
 func NewConcurrentHandler(
 	bufferSize int,
 	linkHandler models.LinkHandler,
+) ConcurrentHandler {
+	return NewConcurrentHandlerV2(
+		bufferSize,
+		adapters.LinkHandlerToV2(linkHandler),
+	)
+}
+
+// NewConcurrentHandlerV2 ...
+func NewConcurrentHandlerV2(
+	bufferSize int,
+	linkHandler models.LinkHandlerV2,
 ) ConcurrentHandler {
 	return ConcurrentHandler{
 		innerConcurrentHandler: syncutils.NewConcurrentHandler(
@@ -43,6 +55,14 @@ func NewConcurrentHandler(
 func (handler ConcurrentHandler) HandleLink(
 	ctx context.Context,
 	link models.SourcedLink,
+) {
+	handler.innerConcurrentHandler.Handle(models.NewRichLink(link))
+}
+
+// HandleRichLink ...
+func (handler ConcurrentHandler) HandleRichLink(
+	ctx context.Context,
+	link models.RichLink,
 ) {
 	handler.innerConcurrentHandler.Handle(link)
 }
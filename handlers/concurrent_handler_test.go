@@ -73,3 +73,35 @@ func TestConcurrentHandler(test *testing.T) {
 		})
 	}
 }
+
+func TestConcurrentHandler_withRichLinks(test *testing.T) {
+	links := []models.RichLink{
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/1",
+			Attributes: models.Attributes{}.Set(models.StatusCodeAttribute, 200),
+		},
+		{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/2",
+		},
+	}
+
+	innerHandler := new(MockLinkHandlerV2)
+	for _, link := range links {
+		innerHandler.
+			On("HandleRichLink", context.Background(), link).
+			Return().
+			Times(1)
+	}
+
+	concurrentHandler := NewConcurrentHandlerV2(1000, innerHandler)
+	go concurrentHandler.StartConcurrently(context.Background(), 10)
+
+	for _, link := range links {
+		concurrentHandler.HandleRichLink(context.Background(), link)
+	}
+	concurrentHandler.Stop()
+
+	mock.AssertExpectationsForObjects(test, innerHandler)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/thewizardplusplus/go-crawler/adapters"
 	"github.com/thewizardplusplus/go-crawler/models"
 )
 
@@ -28,3 +29,22 @@ func (handlers HandlerGroup) HandleLink(
 
 	waiter.Wait()
 }
+
+// HandleRichLink ...
+func (handlers HandlerGroup) HandleRichLink(
+	ctx context.Context,
+	link models.RichLink,
+) {
+	var waiter sync.WaitGroup
+	waiter.Add(len(handlers))
+
+	for _, handler := range handlers {
+		go func(handler models.LinkHandler) {
+			defer waiter.Done()
+
+			adapters.LinkHandlerToV2(handler).HandleRichLink(ctx, link)
+		}(handler)
+	}
+
+	waiter.Wait()
+}
@@ -74,3 +74,44 @@ func TestHandlerGroup_HandleLink(test *testing.T) {
 		})
 	}
 }
+
+func TestHandlerGroup_HandleRichLink(test *testing.T) {
+	type richHandler struct {
+		*MockLinkHandler
+		*MockLinkHandlerV2
+	}
+
+	handler := new(MockLinkHandler)
+	handler.
+		On("HandleLink", context.Background(), models.SourcedLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+		}).
+		Return()
+
+	handlerV2 := new(MockLinkHandlerV2)
+	handlerV2.
+		On("HandleRichLink", context.Background(), models.RichLink{
+			SourceLink: "http://example.com/",
+			Link:       "http://example.com/test",
+			Attributes: models.Attributes{}.
+				Set(models.ContentTypeAttribute, "text/html"),
+		}).
+		Return()
+
+	handlers := HandlerGroup{
+		handler,
+		richHandler{
+			MockLinkHandler:   new(MockLinkHandler),
+			MockLinkHandlerV2: handlerV2,
+		},
+	}
+	handlers.HandleRichLink(context.Background(), models.RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Attributes: models.Attributes{}.
+			Set(models.ContentTypeAttribute, "text/html"),
+	})
+
+	mock.AssertExpectationsForObjects(test, handler, handlerV2)
+}
@@ -14,6 +14,16 @@ type LinkChecker interface {
 	models.LinkChecker
 }
 
+//go:generate mockery --name=LinkCheckerV2 --inpackage --case=underscore --testonly
+
+// LinkCheckerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkCheckerV2 interface {
+	models.LinkCheckerV2
+}
+
 //go:generate mockery --name=LinkHandler --inpackage --case=underscore --testonly
 
 // LinkHandler ...
@@ -23,3 +33,13 @@ type LinkChecker interface {
 type LinkHandler interface {
 	models.LinkHandler
 }
+
+//go:generate mockery --name=LinkHandlerV2 --inpackage --case=underscore --testonly
+
+// LinkHandlerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkHandlerV2 interface {
+	models.LinkHandlerV2
+}
@@ -0,0 +1,96 @@
+package ioutils
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// SizeLimit ...
+type SizeLimit struct {
+	// a non-positive value means no limit
+	MaximalSize int64
+	// if it's set, the data is truncated instead of the failing
+	Truncate bool
+}
+
+// TooLargeError ...
+type TooLargeError struct {
+	MaximalSize int64
+}
+
+// Error ...
+func (err TooLargeError) Error() string {
+	const message = "the data is too large (more than %d bytes)"
+	return fmt.Sprintf(message, err.MaximalSize)
+}
+
+// IsRetryable ...
+//
+// The data will not get smaller on the repeated loading, so it always
+// returns false.
+func (err TooLargeError) IsRetryable() bool {
+	return false
+}
+
+type limitedReader struct {
+	reader        io.Reader
+	limit         SizeLimit
+	remainingSize int64
+}
+
+// LimitReader ...
+//
+// On exceeding of the limit, the returned reader fails with the TooLargeError
+// or returns io.EOF in the truncating mode.
+func LimitReader(reader io.Reader, limit SizeLimit) io.Reader {
+	if limit.MaximalSize <= 0 {
+		return reader
+	}
+
+	return &limitedReader{
+		reader:        reader,
+		limit:         limit,
+		remainingSize: limit.MaximalSize,
+	}
+}
+
+// ReadAll ...
+//
+// It's similar to ioutil.ReadAll(), but it respects the specified limit.
+func ReadAll(reader io.Reader, limit SizeLimit) ([]byte, error) {
+	return ioutil.ReadAll(LimitReader(reader, limit))
+}
+
+func (reader *limitedReader) Read(buffer []byte) (n int, err error) {
+	if reader.remainingSize <= 0 {
+		if reader.limit.Truncate {
+			return 0, io.EOF
+		}
+
+		return 0, reader.checkExceeding()
+	}
+
+	if int64(len(buffer)) > reader.remainingSize {
+		buffer = buffer[:reader.remainingSize]
+	}
+
+	n, err = reader.reader.Read(buffer)
+	reader.remainingSize -= int64(n)
+
+	return n, err
+}
+
+// it checks whether the inner reader has data after the limit
+func (reader *limitedReader) checkExceeding() error {
+	var buffer [1]byte
+	for {
+		n, err := reader.reader.Read(buffer[:])
+		if n > 0 {
+			return TooLargeError{MaximalSize: reader.limit.MaximalSize}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package ioutils
+
+import (
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTooLargeError_Error(test *testing.T) {
+	err := TooLargeError{MaximalSize: 23}
+	assert.Equal(test, "the data is too large (more than 23 bytes)", err.Error())
+}
+
+func TestTooLargeError_IsRetryable(test *testing.T) {
+	err := TooLargeError{MaximalSize: 23}
+	assert.False(test, err.IsRetryable())
+}
+
+func TestReadAll(test *testing.T) {
+	for _, data := range []struct {
+		name     string
+		data     string
+		limit    SizeLimit
+		wantData []byte
+		wantErr  error
+	}{
+		{
+			name:     "without a limit",
+			data:     "test data",
+			limit:    SizeLimit{MaximalSize: 0},
+			wantData: []byte("test data"),
+			wantErr:  nil,
+		},
+		{
+			name:     "with the data less than the limit",
+			data:     "test data",
+			limit:    SizeLimit{MaximalSize: 10},
+			wantData: []byte("test data"),
+			wantErr:  nil,
+		},
+		{
+			name:     "with the data equal to the limit",
+			data:     "test data",
+			limit:    SizeLimit{MaximalSize: 9},
+			wantData: []byte("test data"),
+			wantErr:  nil,
+		},
+		{
+			name:     "with the data greater than the limit",
+			data:     "test data",
+			limit:    SizeLimit{MaximalSize: 4},
+			wantData: []byte("test"),
+			wantErr:  TooLargeError{MaximalSize: 4},
+		},
+		{
+			name:     "with the data greater than the limit and the truncating",
+			data:     "test data",
+			limit:    SizeLimit{MaximalSize: 4, Truncate: true},
+			wantData: []byte("test"),
+			wantErr:  nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			// read the data by single bytes to check the splitting of reading
+			reader := iotest.OneByteReader(strings.NewReader(data.data))
+			gotData, gotErr := ReadAll(reader, data.limit)
+
+			assert.Equal(test, data.wantData, gotData)
+			assert.Equal(test, data.wantErr, gotErr)
+		})
+	}
+}
+
+func TestReadAll_withError(test *testing.T) {
+	reader := iotest.TimeoutReader(strings.NewReader("test data"))
+	_, gotErr := ReadAll(reader, SizeLimit{MaximalSize: 100})
+
+	assert.Equal(test, iotest.ErrTimeout, gotErr)
+}
@@ -55,3 +55,43 @@ type Waiter interface {
 type Logger interface {
 	log.Logger
 }
+
+//go:generate mockery --name=ErrorHandler --inpackage --case=underscore --testonly
+
+// ErrorHandler ...
+//
+// It's used only for mock generating.
+//
+type ErrorHandler interface {
+	models.ErrorHandler
+}
+
+//go:generate mockery --name=LinkExtractorV2 --inpackage --case=underscore --testonly
+
+// LinkExtractorV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkExtractorV2 interface {
+	models.LinkExtractorV2
+}
+
+//go:generate mockery --name=LinkCheckerV2 --inpackage --case=underscore --testonly
+
+// LinkCheckerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkCheckerV2 interface {
+	models.LinkCheckerV2
+}
+
+//go:generate mockery --name=LinkHandlerV2 --inpackage --case=underscore --testonly
+
+// LinkHandlerV2 ...
+//
+// It's used only for mock generating.
+//
+type LinkHandlerV2 interface {
+	models.LinkHandlerV2
+}
@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+)
+
+// UnknownThreadID ...
+//
+// It's used for the errors occurred outside the crawling threads
+// or in the components that don't know the thread ID.
+const UnknownThreadID = -1
+
+// ErrorKind ...
+type ErrorKind int
+
+// ...
+const (
+	FetchError ErrorKind = iota
+	ParseError
+	RobotsTXTError
+	SitemapError
+	ResolvingError
+	FeedError
+	StatusError
+	SizeError
+)
+
+// String ...
+func (kind ErrorKind) String() string {
+	switch kind {
+	case FetchError:
+		return "fetch error"
+	case ParseError:
+		return "parse error"
+	case RobotsTXTError:
+		return "robots.txt error"
+	case SitemapError:
+		return "sitemap error"
+	case ResolvingError:
+		return "resolving error"
+	case FeedError:
+		return "feed error"
+	case StatusError:
+		return "status error"
+	case SizeError:
+		return "size error"
+	default:
+		return fmt.Sprintf("error of kind #%d", int(kind))
+	}
+}
+
+// CrawlingError ...
+type CrawlingError struct {
+	Kind     ErrorKind
+	Link     SourcedLink
+	ThreadID int
+	Err      error
+}
+
+// Error ...
+func (err CrawlingError) Error() string {
+	return fmt.Sprintf("%s for link %q: %s", err.Kind, err.Link.Link, err.Err)
+}
+
+// Cause ...
+//
+// It's required for the github.com/pkg/errors package.
+func (err CrawlingError) Cause() error {
+	return err.Err
+}
+
+// Unwrap ...
+//
+// It's required for the errors package of the standard library.
+func (err CrawlingError) Unwrap() error {
+	return err.Err
+}
+
+// HandleError ...
+//
+// The error handlers are optional everywhere, so it does nothing
+// if the handler is nil.
+func HandleError(handler ErrorHandler, err CrawlingError) {
+	if handler == nil {
+		return
+	}
+
+	handler.HandleError(err)
+}
@@ -10,6 +10,20 @@ type LinkExtractor interface {
 	ExtractLinks(ctx context.Context, threadID int, link string) ([]string, error)
 }
 
+// ResponseLinkExtractor ...
+//
+// Unlike LinkExtractor, it extracts the links from the already received
+// response, so the link isn't requested again. The response body is closed
+// by the caller.
+type ResponseLinkExtractor interface {
+	ExtractResponseLinks(
+		ctx context.Context,
+		threadID int,
+		link string,
+		response *http.Response,
+	) ([]string, error)
+}
+
 // LinkTransformer ...
 type LinkTransformer interface {
 	TransformLinks(
@@ -19,6 +33,31 @@ type LinkTransformer interface {
 	) ([]string, error)
 }
 
+// HTMLLinkTransformer ...
+//
+// A link transformer can implement it to keep the HTML metadata of the links
+// during the transformation.
+type HTMLLinkTransformer interface {
+	TransformHTMLLinks(
+		links []HTMLLink,
+		response *http.Response,
+		responseContent []byte,
+	) ([]HTMLLink, error)
+}
+
+// HTMLPageTransformer ...
+//
+// A link transformer can implement it to set the attributes of the page
+// the links are extracted from (e.g., its robots directives).
+type HTMLPageTransformer interface {
+	TransformHTMLPage(
+		page RichLink,
+		links []HTMLLink,
+		response *http.Response,
+		responseContent []byte,
+	) (RichLink, []HTMLLink, error)
+}
+
 // LinkChecker ...
 type LinkChecker interface {
 	CheckLink(ctx context.Context, link SourcedLink) bool
@@ -28,3 +67,56 @@ type LinkChecker interface {
 type LinkHandler interface {
 	HandleLink(ctx context.Context, link SourcedLink)
 }
+
+// LinkExtractorV2 ...
+//
+// Unlike LinkExtractor, it accepts and returns the links with the attributes.
+// In addition to the extracted links, it returns the extracted page itself
+// with its own attributes (e.g., its status code). The sources and the depths
+// of the extracted links are set by the crawler.
+type LinkExtractorV2 interface {
+	ExtractRichLinks(
+		ctx context.Context,
+		threadID int,
+		link RichLink,
+	) (page RichLink, links []RichLink, err error)
+}
+
+// LinkCheckerV2 ...
+type LinkCheckerV2 interface {
+	CheckRichLink(ctx context.Context, link RichLink) bool
+}
+
+// LinkHandlerV2 ...
+type LinkHandlerV2 interface {
+	HandleRichLink(ctx context.Context, link RichLink)
+}
+
+// ErrorHandler ...
+type ErrorHandler interface {
+	HandleError(err CrawlingError)
+}
+
+// LinkRegister ...
+type LinkRegister interface {
+	// it should return true only if the link hasn't been registered before
+	RegisterLink(link string) (wasRegistered bool, err error)
+}
+
+// LinkFrontier ...
+//
+// The links are stored with their attributes.
+type LinkFrontier interface {
+	PushLink(link RichLink)
+	// it should block until a link is available or the frontier is closed
+	PopLink() (link RichLink, ok bool)
+	Close()
+}
+
+// LinkAcknowledger ...
+//
+// A link frontier can implement it to be notified when processing of a link
+// is completed, i.e., after all links extracted from it have been pushed.
+type LinkAcknowledger interface {
+	AcknowledgeLink(link RichLink)
+}
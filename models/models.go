@@ -1,7 +1,100 @@
 package models
 
+import (
+	"fmt"
+	"time"
+)
+
+// SitemapLinkKind ...
+type SitemapLinkKind int
+
+// ...
+const (
+	SitemapPageLink SitemapLinkKind = iota
+	SitemapImageLink
+	SitemapVideoLink
+	SitemapAlternateLink
+)
+
+// String ...
+func (kind SitemapLinkKind) String() string {
+	switch kind {
+	case SitemapPageLink:
+		return "page link"
+	case SitemapImageLink:
+		return "image link"
+	case SitemapVideoLink:
+		return "video link"
+	case SitemapAlternateLink:
+		return "alternate link"
+	default:
+		return fmt.Sprintf("link of kind #%d", int(kind))
+	}
+}
+
+// SitemapNews ...
+type SitemapNews struct {
+	PublicationName     string
+	PublicationLanguage string
+	Title               string
+	// zero if it isn't specified or is invalid
+	PublicationDate time.Time
+}
+
+// SitemapMetadata ...
+//
+// A zero value means that the link wasn't found in the sitemap.xml files.
+type SitemapMetadata struct {
+	Kind SitemapLinkKind
+	// it's set only for the links of the extensions (images, videos, etc.);
+	// it's the link of the page they belong to
+	PageLink string
+	// it's set only for the alternate links
+	Language string
+
+	// zero if it isn't specified or is invalid
+	LastModification time.Time
+	ChangeFrequency  string
+	// zero if it isn't specified
+	Priority float32
+	// it's set only for the page links with the news extension
+	News SitemapNews
+}
+
+// RobotsDirectives ...
+//
+// They are specified for a page by the robots <meta /> tags
+// and the X-Robots-Tag response headers.
+type RobotsDirectives struct {
+	// the page shouldn't be indexed
+	Noindex bool
+	// the links of the page shouldn't be followed
+	Nofollow bool
+}
+
+// HTMLMetadata ...
+//
+// It describes the HTML tag a link was extracted from.
+type HTMLMetadata struct {
+	TagName       string
+	AttributeName string
+	// it's the value of the rel attribute of the tag
+	Rel string
+}
+
+// HTMLLink ...
+type HTMLLink struct {
+	Link     string
+	Metadata HTMLMetadata
+}
+
 // SourcedLink ...
+//
+// Unlike the RichLink structure, it has no attributes, so it's used
+// by the first versions of the interfaces.
 type SourcedLink struct {
 	SourceLink string
 	Link       string
+	// distance from the seed links; the seed links themselves have zero depth
+	Depth int
 }
@@ -0,0 +1,243 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// AttributeKey ...
+type AttributeKey string
+
+// ...
+const (
+	// the value is of the int type; it's set for the extracted pages
+	StatusCodeAttribute AttributeKey = "status_code"
+	// the value is of the string type; it's set for the extracted pages
+	ContentTypeAttribute AttributeKey = "content_type"
+	// the value is of the time.Time type; it's set for the extracted pages
+	ExtractionTimeAttribute AttributeKey = "extraction_time"
+	// the value is of the SitemapMetadata type
+	SitemapMetadataAttribute AttributeKey = "sitemap_metadata"
+	// the value is of the RobotsDirectives type; it's set for the extracted
+	// pages that specify the directives
+	RobotsDirectivesAttribute AttributeKey = "robots_directives"
+	// the value is of the HTMLMetadata type
+	HTMLMetadataAttribute AttributeKey = "html_metadata"
+)
+
+// the types of the values of the well-known attributes;
+// they are used for restoring of the values from JSON
+var attributeTypes = map[AttributeKey]reflect.Type{ // nolint: gochecknoglobals
+	StatusCodeAttribute:       reflect.TypeOf(0),
+	ContentTypeAttribute:      reflect.TypeOf(""),
+	ExtractionTimeAttribute:   reflect.TypeOf(time.Time{}),
+	SitemapMetadataAttribute:  reflect.TypeOf(SitemapMetadata{}),
+	RobotsDirectivesAttribute: reflect.TypeOf(RobotsDirectives{}),
+	HTMLMetadataAttribute:     reflect.TypeOf(HTMLMetadata{}),
+}
+
+// Attributes ...
+//
+// It's immutable: the Set() and Delete() methods return a modified copy,
+// so the attributes can be shared between the goroutines. A zero value
+// is ready to use.
+type Attributes struct {
+	values map[AttributeKey]interface{}
+}
+
+// Len ...
+func (attributes Attributes) Len() int {
+	return len(attributes.values)
+}
+
+// Keys ...
+//
+// It returns the keys in the ascending order.
+func (attributes Attributes) Keys() []AttributeKey {
+	var keys []AttributeKey
+	for key := range attributes.values {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i int, j int) bool { return keys[i] < keys[j] })
+
+	return keys
+}
+
+// Value ...
+func (attributes Attributes) Value(key AttributeKey) (
+	value interface{},
+	ok bool,
+) {
+	value, ok = attributes.values[key]
+	return value, ok
+}
+
+// String ...
+//
+// It returns false if the value is missing or is of another type.
+func (attributes Attributes) String(key AttributeKey) (string, bool) {
+	value, ok := attributes.values[key].(string)
+	return value, ok
+}
+
+// Int ...
+//
+// It returns false if the value is missing or is of another type.
+func (attributes Attributes) Int(key AttributeKey) (int, bool) {
+	value, ok := attributes.values[key].(int)
+	return value, ok
+}
+
+// Bool ...
+//
+// It returns false if the value is missing or is of another type.
+func (attributes Attributes) Bool(key AttributeKey) (bool, bool) {
+	value, ok := attributes.values[key].(bool)
+	return value, ok
+}
+
+// Time ...
+//
+// It returns false if the value is missing or is of another type.
+func (attributes Attributes) Time(key AttributeKey) (time.Time, bool) {
+	value, ok := attributes.values[key].(time.Time)
+	return value, ok
+}
+
+// MarshalJSON ...
+//
+// The values are marshalled as by the json.Marshal() function.
+func (attributes Attributes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(attributes.values)
+}
+
+// UnmarshalJSON ...
+//
+// The values of the well-known attributes are restored with their types;
+// the other ones are unmarshalled into the interface{} values as by
+// the json.Unmarshal() function.
+func (attributes *Attributes) UnmarshalJSON(data []byte) error {
+	var rawValues map[AttributeKey]json.RawMessage
+	if err := json.Unmarshal(data, &rawValues); err != nil {
+		return errors.Wrap(err, "unable to unmarshal the attributes")
+	}
+	if len(rawValues) == 0 {
+		*attributes = Attributes{}
+		return nil
+	}
+
+	values := make(map[AttributeKey]interface{}, len(rawValues))
+	for key, rawValue := range rawValues {
+		valueType, ok := attributeTypes[key]
+		if !ok {
+			valueType = reflect.TypeOf((*interface{})(nil)).Elem()
+		}
+
+		value := reflect.New(valueType)
+		if err := json.Unmarshal(rawValue, value.Interface()); err != nil {
+			return errors.Wrapf(err, "unable to unmarshal attribute %q", key)
+		}
+
+		values[key] = value.Elem().Interface()
+	}
+
+	*attributes = Attributes{values: values}
+	return nil
+}
+
+// Set ...
+func (attributes Attributes) Set(
+	key AttributeKey,
+	value interface{},
+) Attributes {
+	values := make(map[AttributeKey]interface{}, len(attributes.values)+1)
+	for key, value := range attributes.values {
+		values[key] = value
+	}
+	values[key] = value
+
+	return Attributes{values: values}
+}
+
+// Merge ...
+//
+// The values of the other attributes replace the values with the same keys.
+func (attributes Attributes) Merge(otherAttributes Attributes) Attributes {
+	if len(otherAttributes.values) == 0 {
+		return attributes
+	}
+	if len(attributes.values) == 0 {
+		return otherAttributes
+	}
+
+	values := make(
+		map[AttributeKey]interface{},
+		len(attributes.values)+len(otherAttributes.values),
+	)
+	for key, value := range attributes.values {
+		values[key] = value
+	}
+	for key, value := range otherAttributes.values {
+		values[key] = value
+	}
+
+	return Attributes{values: values}
+}
+
+// Delete ...
+func (attributes Attributes) Delete(key AttributeKey) Attributes {
+	if _, ok := attributes.values[key]; !ok {
+		return attributes
+	}
+	if len(attributes.values) == 1 {
+		return Attributes{}
+	}
+
+	values := make(map[AttributeKey]interface{}, len(attributes.values)-1)
+	for currentKey, value := range attributes.values {
+		if currentKey != key {
+			values[currentKey] = value
+		}
+	}
+
+	return Attributes{values: values}
+}
+
+// RichLink ...
+//
+// It stores all the optional data in the attributes, so it can be extended
+// without changing of the interfaces. The attributes are kept when the link
+// is stored in a link frontier or in a checkpoint.
+type RichLink struct {
+	SourceLink string
+	Link       string
+	// distance from the seed links; the seed links themselves have zero depth
+	Depth      int
+	Attributes Attributes
+}
+
+// NewRichLink ...
+//
+// The created link has no attributes.
+func NewRichLink(link SourcedLink) RichLink {
+	return RichLink{
+		SourceLink: link.SourceLink,
+		Link:       link.Link,
+		Depth:      link.Depth,
+	}
+}
+
+// SourcedLink ...
+//
+// It's the reverse of the NewRichLink() function, so the attributes are lost.
+func (link RichLink) SourcedLink() SourcedLink {
+	return SourcedLink{
+		SourceLink: link.SourceLink,
+		Link:       link.Link,
+		Depth:      link.Depth,
+	}
+}
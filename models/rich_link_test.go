@@ -0,0 +1,193 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttributes(test *testing.T) {
+	extractionTime := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	var attributes Attributes
+	updatedAttributes := attributes.
+		Set(StatusCodeAttribute, 200).
+		Set(ContentTypeAttribute, "text/html").
+		Set(ExtractionTimeAttribute, extractionTime).
+		Set("custom", true)
+
+	// the attributes are immutable
+	assert.Equal(test, 0, attributes.Len())
+	assert.Equal(test, 4, updatedAttributes.Len())
+	assert.Equal(test, []AttributeKey{
+		ContentTypeAttribute,
+		"custom",
+		ExtractionTimeAttribute,
+		StatusCodeAttribute,
+	}, updatedAttributes.Keys())
+
+	statusCode, ok := updatedAttributes.Int(StatusCodeAttribute)
+	assert.Equal(test, 200, statusCode)
+	assert.True(test, ok)
+
+	contentType, ok := updatedAttributes.String(ContentTypeAttribute)
+	assert.Equal(test, "text/html", contentType)
+	assert.True(test, ok)
+
+	gotExtractionTime, ok := updatedAttributes.Time(ExtractionTimeAttribute)
+	assert.Equal(test, extractionTime, gotExtractionTime)
+	assert.True(test, ok)
+
+	custom, ok := updatedAttributes.Bool("custom")
+	assert.True(test, custom)
+	assert.True(test, ok)
+
+	// the value of another type
+	contentType, ok = updatedAttributes.String(StatusCodeAttribute)
+	assert.Empty(test, contentType)
+	assert.False(test, ok)
+
+	// the missing value
+	value, ok := updatedAttributes.Value("missing")
+	assert.Nil(test, value)
+	assert.False(test, ok)
+
+	deletedAttributes := updatedAttributes.Delete("custom")
+	assert.Equal(test, 4, updatedAttributes.Len())
+	assert.Equal(test, 3, deletedAttributes.Len())
+	_, ok = deletedAttributes.Value("custom")
+	assert.False(test, ok)
+
+	assert.Equal(test, Attributes{}, Attributes{}.Set("key", 1).Delete("key"))
+}
+
+func TestAttributes_Merge(test *testing.T) {
+	attributes := Attributes{}.
+		Set(StatusCodeAttribute, 200).
+		Set(ContentTypeAttribute, "text/html")
+	otherAttributes := Attributes{}.
+		Set(ContentTypeAttribute, "application/xml").
+		Set("custom", true)
+	mergedAttributes := attributes.Merge(otherAttributes)
+
+	// the attributes are immutable
+	assert.Equal(test, 2, attributes.Len())
+	assert.Equal(test, 2, otherAttributes.Len())
+	assert.Equal(test, Attributes{}.
+		Set(StatusCodeAttribute, 200).
+		Set(ContentTypeAttribute, "application/xml").
+		Set("custom", true), mergedAttributes)
+
+	assert.Equal(test, attributes, attributes.Merge(Attributes{}))
+	assert.Equal(test, otherAttributes, Attributes{}.Merge(otherAttributes))
+}
+
+func TestAttributes_json(test *testing.T) {
+	extractionTime := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	for _, data := range []struct {
+		name       string
+		attributes Attributes
+		want       Attributes
+	}{
+		{
+			name:       "without attributes",
+			attributes: Attributes{},
+			want:       Attributes{},
+		},
+		{
+			name: "with the well-known attributes",
+			attributes: Attributes{}.
+				Set(StatusCodeAttribute, 200).
+				Set(ContentTypeAttribute, "text/html").
+				Set(ExtractionTimeAttribute, extractionTime).
+				Set(SitemapMetadataAttribute, SitemapMetadata{
+					Kind:     SitemapImageLink,
+					PageLink: "http://example.com/",
+					Priority: 0.5,
+				}).
+				Set(RobotsDirectivesAttribute, RobotsDirectives{Noindex: true}).
+				Set(HTMLMetadataAttribute, HTMLMetadata{TagName: "a"}),
+			want: Attributes{}.
+				Set(StatusCodeAttribute, 200).
+				Set(ContentTypeAttribute, "text/html").
+				Set(ExtractionTimeAttribute, extractionTime).
+				Set(SitemapMetadataAttribute, SitemapMetadata{
+					Kind:     SitemapImageLink,
+					PageLink: "http://example.com/",
+					Priority: 0.5,
+				}).
+				Set(RobotsDirectivesAttribute, RobotsDirectives{Noindex: true}).
+				Set(HTMLMetadataAttribute, HTMLMetadata{TagName: "a"}),
+		},
+		{
+			name: "with the custom attributes",
+			attributes: Attributes{}.
+				Set("flag", true).
+				Set("number", 23).
+				Set("list", []string{"one", "two"}),
+			want: Attributes{}.
+				Set("flag", true).
+				Set("number", float64(23)).
+				Set("list", []interface{}{"one", "two"}),
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			linkData, err := json.Marshal(RichLink{
+				Link:       "http://example.com/test",
+				Attributes: data.attributes,
+			})
+			if !assert.NoError(test, err) {
+				return
+			}
+
+			var got RichLink
+			err = json.Unmarshal(linkData, &got)
+
+			assert.Equal(test, data.want, got.Attributes)
+			assert.NoError(test, err)
+		})
+	}
+}
+
+func TestAttributes_UnmarshalJSON_withError(test *testing.T) {
+	var attributes Attributes
+	err := json.Unmarshal([]byte(`{"status_code":"200"}`), &attributes)
+
+	assert.Equal(test, Attributes{}, attributes)
+	assert.Error(test, err)
+}
+
+func TestNewRichLink(test *testing.T) {
+	got := NewRichLink(SourcedLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+	})
+
+	want := RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+	}
+	assert.Equal(test, want, got)
+}
+
+func TestRichLink_SourcedLink(test *testing.T) {
+	link := RichLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+		Attributes: Attributes{}.
+			Set(SitemapMetadataAttribute, SitemapMetadata{Priority: 0.5}).
+			Set(StatusCodeAttribute, 200),
+	}
+	got := link.SourcedLink()
+
+	want := SourcedLink{
+		SourceLink: "http://example.com/",
+		Link:       "http://example.com/test",
+		Depth:      1,
+	}
+	assert.Equal(test, want, got)
+}
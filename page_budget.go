@@ -0,0 +1,34 @@
+package crawler
+
+import (
+	"sync/atomic"
+)
+
+// PageBudget ...
+type PageBudget struct {
+	// this field should be first in the structure
+	// to guarantee the 64-bit alignment required by the atomic operations
+	spentPageCount   int64
+	maximalPageCount int64
+}
+
+// NewPageBudget ...
+func NewPageBudget(maximalPageCount int) *PageBudget {
+	return &PageBudget{
+		maximalPageCount: int64(maximalPageCount),
+	}
+}
+
+// SpendPage ...
+//
+// It reports whether the page fits into the budget.
+func (budget *PageBudget) SpendPage() bool {
+	spentPageCount := atomic.AddInt64(&budget.spentPageCount, 1)
+	return spentPageCount <= budget.maximalPageCount
+}
+
+// IsExhausted ...
+func (budget *PageBudget) IsExhausted() bool {
+	spentPageCount := atomic.LoadInt64(&budget.spentPageCount)
+	return spentPageCount >= budget.maximalPageCount
+}
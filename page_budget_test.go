@@ -0,0 +1,87 @@
+package crawler
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPageBudget(test *testing.T) {
+	got := NewPageBudget(23)
+
+	assert.Equal(test, &PageBudget{maximalPageCount: 23}, got)
+}
+
+func TestPageBudget(test *testing.T) {
+	for _, data := range []struct {
+		name             string
+		maximalPageCount int
+		spentPageCount   int
+		wantSpentPages   int
+		wantIsExhausted  assert.BoolAssertionFunc
+	}{
+		{
+			name:             "without spending",
+			maximalPageCount: 10,
+			spentPageCount:   0,
+			wantSpentPages:   0,
+			wantIsExhausted:  assert.False,
+		},
+		{
+			name:             "with spending below the budget",
+			maximalPageCount: 10,
+			spentPageCount:   5,
+			wantSpentPages:   5,
+			wantIsExhausted:  assert.False,
+		},
+		{
+			name:             "with spending up to the budget",
+			maximalPageCount: 10,
+			spentPageCount:   10,
+			wantSpentPages:   10,
+			wantIsExhausted:  assert.True,
+		},
+		{
+			name:             "with spending over the budget",
+			maximalPageCount: 10,
+			spentPageCount:   15,
+			wantSpentPages:   10,
+			wantIsExhausted:  assert.True,
+		},
+		{
+			name:             "with the zero budget",
+			maximalPageCount: 0,
+			spentPageCount:   5,
+			wantSpentPages:   0,
+			wantIsExhausted:  assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			budget := NewPageBudget(data.maximalPageCount)
+
+			var waiter sync.WaitGroup
+			waiter.Add(data.spentPageCount)
+
+			var spentPages int
+			var spentPagesLocker sync.Mutex
+			for index := 0; index < data.spentPageCount; index++ {
+				go func() {
+					defer waiter.Done()
+
+					if budget.SpendPage() {
+						spentPagesLocker.Lock()
+						defer spentPagesLocker.Unlock()
+
+						spentPages++
+					}
+				}()
+			}
+
+			waiter.Wait()
+
+			assert.Equal(test, data.wantSpentPages, spentPages)
+			data.wantIsExhausted(test, budget.IsExhausted())
+		})
+	}
+}
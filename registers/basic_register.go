@@ -2,19 +2,73 @@ package registers
 
 import (
 	"context"
+	"fmt"
 	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// EvictionReason ...
+type EvictionReason int
+
+// ...
+const (
+	ExpirationEviction EvictionReason = iota
+	CapacityEviction
 )
 
+// String ...
+func (reason EvictionReason) String() string {
+	switch reason {
+	case ExpirationEviction:
+		return "expiration"
+	case CapacityEviction:
+		return "capacity"
+	default:
+		return fmt.Sprintf("eviction reason #%d", int(reason))
+	}
+}
+
+//go:generate mockery --name=EvictionHandler --inpackage --case=underscore --testonly
+
+// EvictionHandler ...
+type EvictionHandler interface {
+	HandleEviction(key interface{}, reason EvictionReason)
+}
+
 // BasicRegister ...
+//
+// Concurrent registrations of the same key share one in-flight call
+// of the registering handler. Each waiting caller can give up
+// on cancellation of its own context without affecting the other ones.
 type BasicRegister struct {
 	registeredValues *sync.Map
+	// it's used instead of the registeredValues field
+	// if the TTL or the capacity is specified
+	boundedValues *boundedStorage
+	// optional; it's used for the negative caching
+	registeredErrors *boundedStorage
+	// it deduplicates the concurrent registrations of the same key
+	registeringCalls *callGroup
 }
 
 // NewBasicRegister ...
-func NewBasicRegister() BasicRegister {
-	return BasicRegister{
-		registeredValues: new(sync.Map),
+func NewBasicRegister(options ...BasicRegisterOption) BasicRegister {
+	register := BasicRegister{registeringCalls: newCallGroup()}
+	config := newBasicRegisterConfig(options)
+	if config.isBounded() {
+		register.boundedValues = newBoundedStorage(config)
+	} else {
+		register.registeredValues = new(sync.Map)
 	}
+	if config.errorTTL > 0 {
+		register.registeredErrors = newBoundedStorage(BasicRegisterConfig{
+			ttl:      config.errorTTL,
+			capacity: config.capacity,
+		})
+	}
+
+	return register
 }
 
 // RegisterValue ...
@@ -29,16 +83,97 @@ func (register BasicRegister) RegisterValue(
 	value interface{},
 	err error,
 ) {
-	value, ok := register.registeredValues.Load(key)
-	if !ok {
-		var err error
-		value, err = registeringHandler(ctx, key)
-		if err != nil {
-			return nil, err
+	for {
+		if value, err, ok := register.loadResult(key); ok {
+			return value, err
 		}
+		call, isLeader := register.registeringCalls.joinCall(key)
+		if isLeader {
+			// the result could be stored by the previous leading caller
+			// after the check above
+			if value, err, ok := register.loadResult(key); ok {
+				call.value, call.err = value, err
+			} else {
+				result := register.callHandler(ctx, key, registeringHandler)
+				call.value, call.err = result.value, result.err
+				call.isCancelled = result.isCancelled
+			}
+
+			register.registeringCalls.finishCall(key, call)
+			return call.value, call.err
+		}
+
+		select {
+		case <-call.done:
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "unable to wait for the registering")
+		}
+
+		// the leading caller gave up, so try to become the leading one
+		if !call.isCancelled {
+			return call.value, call.err
+		}
+	}
+}
+
+func (register BasicRegister) callHandler(
+	ctx context.Context,
+	key interface{},
+	registeringHandler func(ctx context.Context, key interface{}) (
+		value interface{},
+		err error,
+	),
+) *registeringCall {
+	value, err := registeringHandler(ctx, key)
+	if err != nil {
+		if ctx.Err() != nil {
+			return &registeringCall{err: err, isCancelled: true}
+		}
+
+		if register.registeredErrors != nil {
+			register.registeredErrors.store(key, err)
+		}
+
+		return &registeringCall{err: err}
+	}
+
+	register.storeValue(key, value)
+	return &registeringCall{value: value}
+}
+
+func (register BasicRegister) loadResult(key interface{}) (
+	value interface{},
+	err error,
+	ok bool,
+) {
+	if value, ok := register.loadValue(key); ok {
+		return value, nil, true
+	}
+
+	if register.registeredErrors != nil {
+		if err, ok := register.registeredErrors.load(key); ok {
+			return nil, err.(error), true
+		}
+	}
+
+	return nil, nil, false
+}
+func (register BasicRegister) loadValue(key interface{}) (
+	value interface{},
+	ok bool,
+) {
+	if register.boundedValues != nil {
+		return register.boundedValues.load(key)
+	}
+
+	return register.registeredValues.Load(key)
+}
 
-		register.registeredValues.Store(key, value)
+func (register BasicRegister) storeValue(key interface{}, value interface{}) {
+	if register.boundedValues != nil {
+		register.boundedValues.store(key, value)
+		return
 	}
 
-	return value, nil
+	register.registeredValues.Store(key, value)
 }
@@ -0,0 +1,68 @@
+package registers
+
+import (
+	"time"
+)
+
+// BasicRegisterConfig ...
+type BasicRegisterConfig struct {
+	ttl             time.Duration
+	capacity        int
+	evictionHandler EvictionHandler
+	errorTTL        time.Duration
+}
+
+// BasicRegisterOption ...
+type BasicRegisterOption func(config *BasicRegisterConfig)
+
+// WithTTL ...
+//
+// A non-positive TTL means that the values never expire.
+func WithTTL(ttl time.Duration) BasicRegisterOption {
+	return func(config *BasicRegisterConfig) {
+		config.ttl = ttl
+	}
+}
+
+// WithCapacity ...
+//
+// On exceeding of the capacity, the least recently used values are evicted.
+// A non-positive capacity means no limit.
+func WithCapacity(capacity int) BasicRegisterOption {
+	return func(config *BasicRegisterConfig) {
+		config.capacity = capacity
+	}
+}
+
+// WithEvictionHandler ...
+func WithEvictionHandler(handler EvictionHandler) BasicRegisterOption {
+	return func(config *BasicRegisterConfig) {
+		config.evictionHandler = handler
+	}
+}
+
+// WithErrorTTL ...
+//
+// It enables the negative caching: the registering error is returned
+// for the same key without calling of the registering handler until
+// the specified TTL expires. The errors caused by the cancellation
+// of the caller context aren't cached. A non-positive TTL means that
+// the errors aren't cached.
+func WithErrorTTL(ttl time.Duration) BasicRegisterOption {
+	return func(config *BasicRegisterConfig) {
+		config.errorTTL = ttl
+	}
+}
+
+func newBasicRegisterConfig(options []BasicRegisterOption) BasicRegisterConfig {
+	var config BasicRegisterConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
+
+func (config BasicRegisterConfig) isBounded() bool {
+	return config.ttl > 0 || config.capacity > 0
+}
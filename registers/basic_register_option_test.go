@@ -0,0 +1,72 @@
+package registers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTTL(test *testing.T) {
+	var config BasicRegisterConfig
+	option := WithTTL(5 * time.Second)
+	option(&config)
+
+	assert.Equal(test, 5*time.Second, config.ttl)
+}
+
+func TestWithCapacity(test *testing.T) {
+	var config BasicRegisterConfig
+	option := WithCapacity(23)
+	option(&config)
+
+	assert.Equal(test, 23, config.capacity)
+}
+
+func TestWithEvictionHandler(test *testing.T) {
+	evictionHandler := new(MockEvictionHandler)
+
+	var config BasicRegisterConfig
+	option := WithEvictionHandler(evictionHandler)
+	option(&config)
+
+	assert.Equal(test, evictionHandler, config.evictionHandler)
+}
+
+func TestWithErrorTTL(test *testing.T) {
+	var config BasicRegisterConfig
+	option := WithErrorTTL(5 * time.Second)
+	option(&config)
+
+	assert.Equal(test, 5*time.Second, config.errorTTL)
+}
+
+func TestBasicRegisterConfig_isBounded(test *testing.T) {
+	for _, data := range []struct {
+		name   string
+		config BasicRegisterConfig
+		want   assert.BoolAssertionFunc
+	}{
+		{
+			name:   "without a TTL and a capacity",
+			config: BasicRegisterConfig{},
+			want:   assert.False,
+		},
+		{
+			name:   "with a TTL",
+			config: BasicRegisterConfig{ttl: 5 * time.Second},
+			want:   assert.True,
+		},
+		{
+			name:   "with a capacity",
+			config: BasicRegisterConfig{capacity: 23},
+			want:   assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := data.config.isBounded()
+
+			data.want(test, got)
+		})
+	}
+}
@@ -3,9 +3,12 @@ package registers
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -14,6 +17,37 @@ func TestNewBasicRegister(test *testing.T) {
 	got := NewBasicRegister()
 
 	assert.Equal(test, new(sync.Map), got.registeredValues)
+	assert.Nil(test, got.boundedValues)
+	assert.Nil(test, got.registeredErrors)
+	assert.NotNil(test, got.registeringCalls)
+}
+
+func TestNewBasicRegister_withOptions(test *testing.T) {
+	evictionHandler := new(MockEvictionHandler)
+	got := NewBasicRegister(
+		WithTTL(5*time.Second),
+		WithCapacity(23),
+		WithEvictionHandler(evictionHandler),
+		WithErrorTTL(time.Second),
+	)
+
+	mock.AssertExpectationsForObjects(test, evictionHandler)
+	assert.Nil(test, got.registeredValues)
+	if assert.NotNil(test, got.boundedValues) {
+		assert.Equal(test, BasicRegisterConfig{
+			ttl:             5 * time.Second,
+			capacity:        23,
+			evictionHandler: evictionHandler,
+			errorTTL:        time.Second,
+		}, got.boundedValues.config)
+	}
+	if assert.NotNil(test, got.registeredErrors) {
+		assert.Equal(test, BasicRegisterConfig{
+			ttl:      time.Second,
+			capacity: 23,
+		}, got.registeredErrors.config)
+	}
+	assert.NotNil(test, got.registeringCalls)
 }
 
 func TestBasicRegister_RegisterValue(test *testing.T) {
@@ -95,6 +129,7 @@ func TestBasicRegister_RegisterValue(test *testing.T) {
 		test.Run(data.name, func(t *testing.T) {
 			register := BasicRegister{
 				registeredValues: data.fields.registeredValues,
+				registeringCalls: newCallGroup(),
 			}
 			gotValue, gotErr := register.RegisterValue(
 				data.args.ctx,
@@ -108,3 +143,228 @@ func TestBasicRegister_RegisterValue(test *testing.T) {
 		})
 	}
 }
+
+func TestBasicRegister_RegisterValue_withBoundedValues(test *testing.T) {
+	clock := &fakeClock{
+		now: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+	}
+	register := NewBasicRegister(WithTTL(time.Minute))
+	register.boundedValues.clock = clock.Now
+
+	registeringHandler := new(MockRegisteringHandler)
+	registeringHandler.
+		On("HandleRegistering", context.Background(), "key").
+		Return("value #1", nil).
+		Once()
+	registeringHandler.
+		On("HandleRegistering", context.Background(), "key").
+		Return("value #2", nil).
+		Once()
+
+	var gotValues []interface{}
+	for _, duration := range []time.Duration{0, 30 * time.Second, time.Minute} {
+		clock.Advance(duration)
+
+		gotValue, gotErr := register.RegisterValue(
+			context.Background(),
+			"key",
+			registeringHandler.HandleRegistering,
+		)
+		assert.NoError(test, gotErr)
+
+		gotValues = append(gotValues, gotValue)
+	}
+
+	mock.AssertExpectationsForObjects(test, registeringHandler)
+	wantValues := []interface{}{"value #1", "value #1", "value #2"}
+	assert.Equal(test, wantValues, gotValues)
+}
+
+func TestBasicRegister_RegisterValue_withRegisteredErrors(test *testing.T) {
+	clock := &fakeClock{
+		now: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+	}
+	register := NewBasicRegister(WithErrorTTL(time.Minute))
+	register.registeredErrors.clock = clock.Now
+
+	registeringHandler := new(MockRegisteringHandler)
+	registeringHandler.
+		On("HandleRegistering", context.Background(), "key").
+		Return(nil, iotest.ErrTimeout).
+		Once()
+	registeringHandler.
+		On("HandleRegistering", context.Background(), "key").
+		Return("value", nil).
+		Once()
+
+	var gotValues []interface{}
+	var gotErrs []error
+	for _, duration := range []time.Duration{0, 30 * time.Second, time.Minute} {
+		clock.Advance(duration)
+
+		gotValue, gotErr := register.RegisterValue(
+			context.Background(),
+			"key",
+			registeringHandler.HandleRegistering,
+		)
+
+		gotValues = append(gotValues, gotValue)
+		gotErrs = append(gotErrs, gotErr)
+	}
+
+	mock.AssertExpectationsForObjects(test, registeringHandler)
+	assert.Equal(test, []interface{}{nil, nil, "value"}, gotValues)
+	assert.Equal(test, []error{iotest.ErrTimeout, iotest.ErrTimeout, nil}, gotErrs)
+}
+
+func TestBasicRegister_RegisterValue_withCancelledError(test *testing.T) {
+	register := NewBasicRegister(WithErrorTTL(time.Minute))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	registeringHandler := new(MockRegisteringHandler)
+	registeringHandler.
+		On("HandleRegistering", ctx, "key").
+		Return(nil, context.Canceled).
+		Once()
+	registeringHandler.
+		On("HandleRegistering", context.Background(), "key").
+		Return("value", nil).
+		Once()
+
+	_, gotErr := register.RegisterValue(
+		ctx,
+		"key",
+		registeringHandler.HandleRegistering,
+	)
+	assert.Equal(test, context.Canceled, gotErr)
+
+	gotValue, gotErr := register.RegisterValue(
+		context.Background(),
+		"key",
+		registeringHandler.HandleRegistering,
+	)
+	assert.NoError(test, gotErr)
+
+	mock.AssertExpectationsForObjects(test, registeringHandler)
+	assert.Equal(test, "value", gotValue)
+}
+
+func TestBasicRegister_RegisterValue_concurrently(test *testing.T) {
+	register := NewBasicRegister()
+
+	var callCount int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	registeringHandler := func(ctx context.Context, key interface{}) (
+		interface{},
+		error,
+	) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			close(started)
+		}
+		<-release
+
+		return "value", nil
+	}
+
+	const callerCount = 10
+	gotValues := make([]interface{}, callerCount)
+	var waiter sync.WaitGroup
+	waiter.Add(callerCount)
+	for index := 0; index < callerCount; index++ {
+		go func(index int) {
+			defer waiter.Done()
+
+			gotValues[index], _ = // nolint: gosec
+				register.RegisterValue(context.Background(), "key", registeringHandler)
+		}(index)
+	}
+
+	<-started
+	// wait for the other callers to join the in-flight call
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	waiter.Wait()
+
+	assert.Equal(test, int32(1), atomic.LoadInt32(&callCount))
+	for _, gotValue := range gotValues {
+		assert.Equal(test, "value", gotValue)
+	}
+}
+
+func TestBasicRegister_RegisterValue_withCancelledWaiter(test *testing.T) {
+	register := NewBasicRegister()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	registeringHandler := func(ctx context.Context, key interface{}) (
+		interface{},
+		error,
+	) {
+		close(started)
+		<-release
+
+		return "value", nil
+	}
+
+	leaderResults := make(chan interface{})
+	go func() {
+		value, _ := // nolint: gosec
+			register.RegisterValue(context.Background(), "key", registeringHandler)
+		leaderResults <- value
+	}()
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	gotValue, gotErr := register.RegisterValue(ctx, "key", registeringHandler)
+	assert.Nil(test, gotValue)
+	assert.Equal(test, context.Canceled, errors.Cause(gotErr))
+
+	close(release)
+	assert.Equal(test, "value", <-leaderResults)
+}
+
+func TestBasicRegister_RegisterValue_withCancelledLeader(test *testing.T) {
+	register := NewBasicRegister()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var callCount int32
+	registeringHandler := func(ctx context.Context, key interface{}) (
+		interface{},
+		error,
+	) {
+		if atomic.AddInt32(&callCount, 1) == 1 {
+			close(started)
+			<-ctx.Done()
+
+			return nil, ctx.Err()
+		}
+
+		return "value", nil
+	}
+
+	leaderErrs := make(chan error)
+	go func() {
+		_, err := register.RegisterValue(ctx, "key", registeringHandler)
+		leaderErrs <- err
+	}()
+	<-started
+
+	waiterResults := make(chan interface{})
+	go func() {
+		value, _ := // nolint: gosec
+			register.RegisterValue(context.Background(), "key", registeringHandler)
+		waiterResults <- value
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	assert.Equal(test, context.Canceled, <-leaderErrs)
+	assert.Equal(test, "value", <-waiterResults)
+	assert.Equal(test, int32(2), atomic.LoadInt32(&callCount))
+}
@@ -0,0 +1,90 @@
+package registers
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"sync"
+
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+// BloomLinkRegister ...
+//
+// It uses the Bloom filter, so its memory consumption doesn't depend
+// on the lengths of the links. But a new link may be mistakenly
+// considered as already registered with the specified false-positive rate
+// (if the link count doesn't exceed the expected one), so such links
+// will not be crawled.
+type BloomLinkRegister struct {
+	sanitizeLink urlutils.LinkSanitizer
+
+	locker    sync.Mutex
+	bits      []uint64
+	bitCount  uint64
+	hashCount int
+}
+
+// NewBloomLinkRegister ...
+//
+// The expected link count less than one is treated as one. The false-positive
+// rate should be in the range (0, 1), otherwise 0.01 is used.
+func NewBloomLinkRegister(
+	sanitizeLink urlutils.LinkSanitizer,
+	expectedLinkCount int,
+	falsePositiveRate float64,
+) *BloomLinkRegister {
+	if expectedLinkCount < 1 {
+		expectedLinkCount = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	// the optimal parameters of the Bloom filter
+	linkCount := float64(expectedLinkCount)
+	bitCount :=
+		math.Ceil(-linkCount * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashCount := int(math.Max(1, math.Round(bitCount/linkCount*math.Ln2)))
+
+	wordCount := (uint64(bitCount) + 63) / 64
+	return &BloomLinkRegister{
+		sanitizeLink: sanitizeLink,
+
+		bits:      make([]uint64, wordCount),
+		bitCount:  wordCount * 64,
+		hashCount: hashCount,
+	}
+}
+
+// RegisterLink ...
+func (register *BloomLinkRegister) RegisterLink(link string) (
+	wasRegistered bool,
+	err error,
+) {
+	link, err = sanitizeLink(register.sanitizeLink, link)
+	if err != nil {
+		return false, err
+	}
+
+	// the double hashing by two halves of the single 128-bit hash
+	hash := fnv.New128a()
+	hash.Write([]byte(link)) // nolint: errcheck, gosec
+	hashSum := hash.Sum(nil)
+	firstHash := binary.BigEndian.Uint64(hashSum[:8])
+	secondHash := binary.BigEndian.Uint64(hashSum[8:]) | 1
+
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	for index := 0; index < register.hashCount; index++ {
+		bitIndex := (firstHash + uint64(index)*secondHash) % register.bitCount
+		wordIndex, bitMask := bitIndex/64, uint64(1)<<(bitIndex%64)
+		if register.bits[wordIndex]&bitMask == 0 {
+			register.bits[wordIndex] |= bitMask
+			wasRegistered = true
+		}
+	}
+
+	return wasRegistered, nil
+}
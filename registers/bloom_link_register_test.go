@@ -0,0 +1,60 @@
+package registers
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestNewBloomLinkRegister(test *testing.T) {
+	got := NewBloomLinkRegister(urlutils.SanitizeLink, 1000, 0.01)
+
+	assert.Equal(test, urlutils.SanitizeLink, got.sanitizeLink)
+	// 9586 bits by the formula rounded up to the whole words
+	assert.Equal(test, uint64(9600), got.bitCount)
+	assert.Len(test, got.bits, 150)
+	assert.Equal(test, 7, got.hashCount)
+}
+
+func TestBloomLinkRegister_RegisterLink(test *testing.T) {
+	test.Run("success", func(test *testing.T) {
+		register := NewBloomLinkRegister(urlutils.SanitizeLink, 1000, 0.01)
+		for _, data := range []struct {
+			link              string
+			wantWasRegistered assert.BoolAssertionFunc
+			wantErr           assert.ErrorAssertionFunc
+		}{
+			{"http://example.com/1", assert.True, assert.NoError},
+			{"http://example.com/2", assert.True, assert.NoError},
+			{"http://example.com/1", assert.False, assert.NoError},
+			{"http://example.com/test/../2", assert.False, assert.NoError},
+			{":", assert.False, assert.Error},
+		} {
+			wasRegistered, err := register.RegisterLink(data.link)
+
+			data.wantWasRegistered(test, wasRegistered, data.link)
+			data.wantErr(test, err, data.link)
+		}
+	})
+
+	test.Run("false-positive rate", func(test *testing.T) {
+		const linkCount = 10000
+		register :=
+			NewBloomLinkRegister(urlutils.DoNotSanitizeLink, linkCount, 0.01)
+
+		var falsePositiveCount int
+		for index := 0; index < linkCount; index++ {
+			link := fmt.Sprintf("http://example.com/%d", index)
+			wasRegistered, err := register.RegisterLink(link)
+			if !wasRegistered {
+				falsePositiveCount++
+			}
+
+			assert.NoError(test, err)
+		}
+
+		assert.True(test, falsePositiveCount < linkCount*2/100)
+	})
+}
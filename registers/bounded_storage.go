@@ -0,0 +1,153 @@
+package registers
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type boundedEntry struct {
+	key   interface{}
+	value interface{}
+	// zero if the entry never expires
+	expirationTime    time.Time
+	recencyElement    *list.Element
+	expirationElement *list.Element
+}
+
+type eviction struct {
+	key    interface{}
+	reason EvictionReason
+}
+
+type boundedStorage struct {
+	config BasicRegisterConfig
+	clock  func() time.Time
+
+	lock    sync.Mutex
+	entries map[interface{}]*boundedEntry
+	// the most recently used entries are at the front
+	recencyList *list.List
+	// the entries are ordered by the expiration time,
+	// since the TTL is the same for all of them
+	expirationList *list.List
+}
+
+func newBoundedStorage(config BasicRegisterConfig) *boundedStorage {
+	return &boundedStorage{
+		config: config,
+		clock:  time.Now,
+
+		entries:        make(map[interface{}]*boundedEntry),
+		recencyList:    list.New(),
+		expirationList: list.New(),
+	}
+}
+
+func (storage *boundedStorage) load(key interface{}) (
+	value interface{},
+	ok bool,
+) {
+	now := storage.clock()
+
+	storage.lock.Lock()
+	entry, ok := storage.entries[key]
+	var evictions []eviction
+	if ok && storage.isExpired(entry, now) {
+		storage.removeEntry(entry)
+		evictions = append(evictions, eviction{
+			key:    entry.key,
+			reason: ExpirationEviction,
+		})
+
+		ok = false
+	} else if ok {
+		storage.recencyList.MoveToFront(entry.recencyElement)
+	}
+	storage.lock.Unlock()
+
+	storage.handleEvictions(evictions)
+	if !ok {
+		return nil, false
+	}
+
+	return entry.value, true
+}
+
+func (storage *boundedStorage) store(key interface{}, value interface{}) {
+	now := storage.clock()
+
+	storage.lock.Lock()
+	if previousEntry, ok := storage.entries[key]; ok {
+		// the replacement isn't treated as an eviction
+		storage.removeEntry(previousEntry)
+	}
+
+	entry := &boundedEntry{key: key, value: value}
+	if storage.config.ttl > 0 {
+		entry.expirationTime = now.Add(storage.config.ttl)
+	}
+	entry.recencyElement = storage.recencyList.PushFront(entry)
+	entry.expirationElement = storage.expirationList.PushBack(entry)
+	storage.entries[key] = entry
+
+	evictions := storage.evictEntries(now)
+	storage.lock.Unlock()
+
+	storage.handleEvictions(evictions)
+}
+
+// it should be called under the lock
+func (storage *boundedStorage) evictEntries(now time.Time) []eviction {
+	var evictions []eviction
+	for storage.expirationList.Len() != 0 {
+		entry := storage.expirationList.Front().Value.(*boundedEntry)
+		if !storage.isExpired(entry, now) {
+			break
+		}
+
+		storage.removeEntry(entry)
+		evictions = append(evictions, eviction{
+			key:    entry.key,
+			reason: ExpirationEviction,
+		})
+	}
+
+	for storage.config.capacity > 0 &&
+		len(storage.entries) > storage.config.capacity {
+		entry := storage.recencyList.Back().Value.(*boundedEntry)
+		storage.removeEntry(entry)
+		evictions = append(evictions, eviction{
+			key:    entry.key,
+			reason: CapacityEviction,
+		})
+	}
+
+	return evictions
+}
+
+func (storage *boundedStorage) isExpired(
+	entry *boundedEntry,
+	now time.Time,
+) bool {
+	return !entry.expirationTime.IsZero() && !now.Before(entry.expirationTime)
+}
+
+// it should be called under the lock
+func (storage *boundedStorage) removeEntry(entry *boundedEntry) {
+	storage.recencyList.Remove(entry.recencyElement)
+	storage.expirationList.Remove(entry.expirationElement)
+	delete(storage.entries, entry.key)
+}
+
+// it should be called outside the lock to allow the handler
+// to access the storage
+func (storage *boundedStorage) handleEvictions(evictions []eviction) {
+	if storage.config.evictionHandler == nil {
+		return
+	}
+
+	for _, eviction := range evictions {
+		storage.config.evictionHandler.HandleEviction(eviction.key, eviction.reason)
+	}
+}
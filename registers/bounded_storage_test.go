@@ -0,0 +1,124 @@
+package registers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (clock *fakeClock) Now() time.Time {
+	return clock.now
+}
+
+func (clock *fakeClock) Advance(duration time.Duration) {
+	clock.now = clock.now.Add(duration)
+}
+
+func TestBoundedStorage_withTTL(test *testing.T) {
+	evictionHandler := new(MockEvictionHandler)
+	evictionHandler.On("HandleEviction", "one", ExpirationEviction).Return().Once()
+	evictionHandler.On("HandleEviction", "two", ExpirationEviction).Return().Once()
+
+	clock := &fakeClock{
+		now: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+	}
+	storage := newBoundedStorage(BasicRegisterConfig{
+		ttl:             time.Minute,
+		evictionHandler: evictionHandler,
+	})
+	storage.clock = clock.Now
+
+	storage.store("one", 1)
+	clock.Advance(30 * time.Second)
+	storage.store("two", 2)
+
+	gotValue, gotOK := storage.load("one")
+	assert.Equal(test, 1, gotValue)
+	assert.True(test, gotOK)
+
+	// the expired value is evicted on loading
+	clock.Advance(30 * time.Second)
+	gotValue, gotOK = storage.load("one")
+	assert.Nil(test, gotValue)
+	assert.False(test, gotOK)
+
+	// the expired value is evicted on storing of another one
+	clock.Advance(30 * time.Second)
+	storage.store("three", 3)
+
+	mock.AssertExpectationsForObjects(test, evictionHandler)
+	assert.Len(test, storage.entries, 1)
+	assert.Equal(test, 1, storage.recencyList.Len())
+	assert.Equal(test, 1, storage.expirationList.Len())
+
+	gotValue, gotOK = storage.load("three")
+	assert.Equal(test, 3, gotValue)
+	assert.True(test, gotOK)
+}
+
+func TestBoundedStorage_withCapacity(test *testing.T) {
+	evictionHandler := new(MockEvictionHandler)
+	evictionHandler.On("HandleEviction", "two", CapacityEviction).Return().Once()
+
+	storage := newBoundedStorage(BasicRegisterConfig{
+		capacity:        2,
+		evictionHandler: evictionHandler,
+	})
+
+	storage.store("one", 1)
+	storage.store("two", 2)
+	// the recently used value isn't evicted
+	storage.load("one")
+	storage.store("three", 3)
+
+	mock.AssertExpectationsForObjects(test, evictionHandler)
+
+	gotValue, gotOK := storage.load("one")
+	assert.Equal(test, 1, gotValue)
+	assert.True(test, gotOK)
+
+	gotValue, gotOK = storage.load("two")
+	assert.Nil(test, gotValue)
+	assert.False(test, gotOK)
+
+	gotValue, gotOK = storage.load("three")
+	assert.Equal(test, 3, gotValue)
+	assert.True(test, gotOK)
+}
+
+func TestBoundedStorage_withReplacement(test *testing.T) {
+	evictionHandler := new(MockEvictionHandler)
+	storage := newBoundedStorage(BasicRegisterConfig{
+		ttl:             time.Minute,
+		capacity:        2,
+		evictionHandler: evictionHandler,
+	})
+
+	storage.store("one", 1)
+	storage.store("one", 2)
+
+	mock.AssertExpectationsForObjects(test, evictionHandler)
+	assert.Len(test, storage.entries, 1)
+	assert.Equal(test, 1, storage.recencyList.Len())
+	assert.Equal(test, 1, storage.expirationList.Len())
+
+	gotValue, gotOK := storage.load("one")
+	assert.Equal(test, 2, gotValue)
+	assert.True(test, gotOK)
+}
+
+func TestBoundedStorage_withoutEvictionHandler(test *testing.T) {
+	storage := newBoundedStorage(BasicRegisterConfig{capacity: 1})
+	storage.store("one", 1)
+	storage.store("two", 2)
+
+	gotValue, gotOK := storage.load("one")
+	assert.Nil(test, gotValue)
+	assert.False(test, gotOK)
+}
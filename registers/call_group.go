@@ -0,0 +1,53 @@
+package registers
+
+import (
+	"sync"
+)
+
+type registeringCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+	// if the context of the leading caller was cancelled,
+	// the result isn't shared with the other callers
+	isCancelled bool
+}
+
+type callGroup struct {
+	lock  sync.Mutex
+	calls map[interface{}]*registeringCall
+}
+
+func newCallGroup() *callGroup {
+	return &callGroup{
+		calls: make(map[interface{}]*registeringCall),
+	}
+}
+
+// it returns the in-flight call for the key or starts the new one;
+// in the latter case, the caller becomes the leading one
+// and should finish the call
+func (group *callGroup) joinCall(key interface{}) (
+	call *registeringCall,
+	isLeader bool,
+) {
+	group.lock.Lock()
+	defer group.lock.Unlock()
+
+	if call, ok := group.calls[key]; ok {
+		return call, false
+	}
+
+	call = &registeringCall{done: make(chan struct{})}
+	group.calls[key] = call
+
+	return call, true
+}
+
+func (group *callGroup) finishCall(key interface{}, call *registeringCall) {
+	group.lock.Lock()
+	delete(group.calls, key)
+	group.lock.Unlock()
+
+	close(call.done)
+}
@@ -0,0 +1,244 @@
+package registers
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+const (
+	diskSlotSize         = 16 // the size of a 128-bit hash
+	initialDiskSlotCount = 1 << 16
+	diskReadingSize      = 4096 * diskSlotSize
+)
+
+var emptyDiskSlot = make([]byte, diskSlotSize) // nolint: gochecknoglobals
+
+// DiskLinkRegister ...
+//
+// It stores the 128-bit hashes of the registered links in the on-disk
+// hash table with the open addressing, so its memory consumption
+// doesn't depend on the link count. The table is doubled when it becomes
+// half full. The probability of a hash collision is negligible.
+type DiskLinkRegister struct {
+	sanitizeLink urlutils.LinkSanitizer
+	filename     string
+
+	locker    sync.Mutex
+	file      *os.File
+	slotCount int64
+	linkCount int64
+}
+
+// OpenDiskLinkRegister ...
+//
+// The links registered in the specified file before are kept.
+func OpenDiskLinkRegister(
+	sanitizeLink urlutils.LinkSanitizer,
+	filename string,
+) (*DiskLinkRegister, error) {
+	file, slotCount, linkCount, err := openDiskTable(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	register := &DiskLinkRegister{
+		sanitizeLink: sanitizeLink,
+		filename:     filename,
+
+		file:      file,
+		slotCount: slotCount,
+		linkCount: linkCount,
+	}
+	return register, nil
+}
+
+// RegisterLink ...
+func (register *DiskLinkRegister) RegisterLink(link string) (
+	wasRegistered bool,
+	err error,
+) {
+	link, err = sanitizeLink(register.sanitizeLink, link)
+	if err != nil {
+		return false, err
+	}
+
+	hash := fnv.New128a()
+	hash.Write([]byte(link)) // nolint: errcheck, gosec
+	linkHash := hash.Sum(nil)
+	// an empty slot is marked by the zero hash
+	linkHash[0] |= 1
+
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	if (register.linkCount+1)*2 > register.slotCount {
+		if err := register.grow(); err != nil {
+			return false, errors.Wrap(err, "unable to grow the table")
+		}
+	}
+
+	wasRegistered, err =
+		putDiskSlot(register.file, register.slotCount, linkHash)
+	if err != nil {
+		return false, err
+	}
+	if wasRegistered {
+		register.linkCount++
+	}
+
+	return wasRegistered, nil
+}
+
+// Close ...
+func (register *DiskLinkRegister) Close() error {
+	register.locker.Lock()
+	defer register.locker.Unlock()
+
+	if err := register.file.Close(); err != nil {
+		return errors.Wrap(err, "unable to close the table file")
+	}
+
+	return nil
+}
+
+func (register *DiskLinkRegister) grow() error {
+	temporaryFilename := register.filename + ".tmp"
+	temporaryFile, err := os.Create(temporaryFilename)
+	if err != nil {
+		return errors.Wrap(err, "unable to create the temporary table file")
+	}
+	defer temporaryFile.Close() // nolint: errcheck
+
+	slotCount := register.slotCount * 2
+	if err := temporaryFile.Truncate(slotCount * diskSlotSize); err != nil {
+		return errors.Wrap(err, "unable to allocate the temporary table file")
+	}
+
+	err = scanDiskSlots(register.file, func(linkHash []byte) error {
+		_, err := putDiskSlot(temporaryFile, slotCount, linkHash)
+		return err
+	})
+	if err != nil {
+		return errors.Wrap(err, "unable to rehash the table")
+	}
+
+	if err := temporaryFile.Sync(); err != nil {
+		return errors.Wrap(err, "unable to sync the temporary table file")
+	}
+	if err := os.Rename(temporaryFilename, register.filename); err != nil {
+		return errors.Wrap(err, "unable to replace the table file")
+	}
+
+	file, err := os.OpenFile(register.filename, os.O_RDWR, 0)
+	if err != nil {
+		return errors.Wrap(err, "unable to reopen the table file")
+	}
+
+	register.file.Close() // nolint: errcheck, gosec
+	register.file = file
+	register.slotCount = slotCount
+
+	return nil
+}
+
+func openDiskTable(filename string) (
+	file *os.File,
+	slotCount int64,
+	linkCount int64,
+	err error,
+) {
+	file, err = os.OpenFile(filename, os.O_RDWR|os.O_CREATE, 0600)
+	if err != nil {
+		return nil, 0, 0, errors.Wrap(err, "unable to open the table file")
+	}
+
+	fileInfo, err := file.Stat()
+	if err != nil {
+		file.Close() // nolint: errcheck, gosec
+		return nil, 0, 0, errors.Wrap(err, "unable to get the table file size")
+	}
+
+	slotCount = fileInfo.Size() / diskSlotSize
+	if slotCount == 0 {
+		slotCount = initialDiskSlotCount
+		if err := file.Truncate(slotCount * diskSlotSize); err != nil {
+			file.Close() // nolint: errcheck, gosec
+			return nil, 0, 0, errors.Wrap(err, "unable to allocate the table file")
+		}
+	}
+
+	err = scanDiskSlots(file, func(linkHash []byte) error {
+		linkCount++
+		return nil
+	})
+	if err != nil {
+		file.Close() // nolint: errcheck, gosec
+		return nil, 0, 0, errors.Wrap(err, "unable to count the links")
+	}
+
+	return file, slotCount, linkCount, nil
+}
+
+// it calls the handler for each non-empty slot
+func scanDiskSlots(file *os.File, handler func(linkHash []byte) error) error {
+	buffer := make([]byte, diskReadingSize)
+	for offset := int64(0); ; {
+		size, err := file.ReadAt(buffer, offset)
+		if err != nil && err != io.EOF {
+			return errors.Wrap(err, "unable to read the table file")
+		}
+
+		for index := 0; index+diskSlotSize <= size; index += diskSlotSize {
+			linkHash := buffer[index : index+diskSlotSize]
+			if bytes.Equal(linkHash, emptyDiskSlot) {
+				continue
+			}
+
+			if err := handler(linkHash); err != nil {
+				return err
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		offset += int64(size)
+	}
+}
+
+// it uses the linear probing
+func putDiskSlot(file *os.File, slotCount int64, linkHash []byte) (
+	wasPut bool,
+	err error,
+) {
+	hashPart := binary.BigEndian.Uint64(linkHash[8:])
+	slotIndex := int64(hashPart % uint64(slotCount))
+	slot := make([]byte, diskSlotSize)
+	for {
+		offset := slotIndex * diskSlotSize
+		if _, err := file.ReadAt(slot, offset); err != nil {
+			return false, errors.Wrap(err, "unable to read the slot")
+		}
+
+		if bytes.Equal(slot, linkHash) {
+			return false, nil
+		}
+		if bytes.Equal(slot, emptyDiskSlot) {
+			if _, err := file.WriteAt(linkHash, offset); err != nil {
+				return false, errors.Wrap(err, "unable to write the slot")
+			}
+
+			return true, nil
+		}
+
+		slotIndex = (slotIndex + 1) % slotCount
+	}
+}
@@ -0,0 +1,70 @@
+package registers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestDiskLinkRegister(test *testing.T) {
+	directory, err := ioutil.TempDir("", "go-crawler-test")
+	require.NoError(test, err)
+	defer os.RemoveAll(directory)
+
+	filename := filepath.Join(directory, "links")
+	register, err := OpenDiskLinkRegister(urlutils.SanitizeLink, filename)
+	require.NoError(test, err)
+
+	for _, data := range []struct {
+		link              string
+		wantWasRegistered assert.BoolAssertionFunc
+		wantErr           assert.ErrorAssertionFunc
+	}{
+		{"http://example.com/1", assert.True, assert.NoError},
+		{"http://example.com/2", assert.True, assert.NoError},
+		{"http://example.com/1", assert.False, assert.NoError},
+		{"http://example.com/test/../2", assert.False, assert.NoError},
+		{":", assert.False, assert.Error},
+	} {
+		wasRegistered, err := register.RegisterLink(data.link)
+
+		data.wantWasRegistered(test, wasRegistered, data.link)
+		data.wantErr(test, err, data.link)
+	}
+
+	// check the growing of the table
+	const linkCount = initialDiskSlotCount
+	for index := 0; index < linkCount; index++ {
+		link := fmt.Sprintf("http://example.com/test/%d", index)
+		wasRegistered, err := register.RegisterLink(link)
+
+		require.True(test, wasRegistered, link)
+		require.NoError(test, err, link)
+	}
+	assert.Equal(test, int64(linkCount+2), register.linkCount)
+	assert.Equal(test, int64(initialDiskSlotCount*4), register.slotCount)
+	require.NoError(test, register.Close())
+
+	// check the reopening of the table
+	register, err = OpenDiskLinkRegister(urlutils.SanitizeLink, filename)
+	require.NoError(test, err)
+	defer register.Close() // nolint: errcheck
+
+	assert.Equal(test, int64(linkCount+2), register.linkCount)
+	assert.Equal(test, int64(initialDiskSlotCount*4), register.slotCount)
+	for _, link := range []string{
+		"http://example.com/2",
+		"http://example.com/test/23",
+	} {
+		wasRegistered, err := register.RegisterLink(link)
+
+		assert.False(test, wasRegistered, link)
+		assert.NoError(test, err, link)
+	}
+}
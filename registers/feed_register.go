@@ -0,0 +1,204 @@
+package registers
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"sync"
+
+	"github.com/go-log/log"
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers/feeds"
+)
+
+// FeedRegister ...
+type FeedRegister struct {
+	linkGenerator models.LinkExtractor
+	logger        log.Logger
+	linkLoader    func(link string, options interface{}) ([]byte, error)
+
+	feedRegister BasicRegister
+}
+
+// NewFeedRegister ...
+//
+// The link loader receives a context as the options, so the sitemap.Loader
+// from the registers/sitemap package may be used for it. The register options
+// are applied to the cache of the feeds.
+func NewFeedRegister(
+	linkGenerator models.LinkExtractor,
+	logger log.Logger,
+	linkLoader func(link string, options interface{}) ([]byte, error),
+	registerOptions ...BasicRegisterOption,
+) FeedRegister {
+	return FeedRegister{
+		linkGenerator: linkGenerator,
+		logger:        logger,
+		linkLoader:    linkLoader,
+
+		feedRegister: NewBasicRegister(registerOptions...),
+	}
+}
+
+// RegisterFeed ...
+//
+// If some feeds can't be loaded, it returns the error of the first of them
+// together with the items of the loaded feeds. The errors are cached
+// according to the register options (see the WithErrorTTL() function).
+func (register FeedRegister) RegisterFeed(
+	ctx context.Context,
+	threadID int,
+	link string,
+) (
+	feeds.Feed,
+	error,
+) {
+	feedLinks, err := register.linkGenerator.ExtractLinks(ctx, threadID, link)
+	if err != nil {
+		return feeds.Feed{}, errors.Wrap(err, "unable to generate feed links")
+	}
+
+	// different generators may discover the same feed
+	feedLinks = uniqueLinks(feedLinks)
+
+	var waiter sync.WaitGroup
+	waiter.Add(len(feedLinks))
+
+	feedDataGroup := make([]feeds.Feed, len(feedLinks))
+	errs := make([]error, len(feedLinks))
+	for index, feedLink := range feedLinks {
+		go func(index int, feedLink string) {
+			defer waiter.Done()
+
+			feedDataGroup[index], errs[index] =
+				register.loadFeedData(ctx, feedLink)
+		}(index, feedLink)
+	}
+
+	waiter.Wait()
+
+	var totalFeedData feeds.Feed
+	var firstErr error
+	for index, feedData := range feedDataGroup {
+		if err := errs[index]; err != nil {
+			const logMessage = "unable to load feed link %q: %s"
+			register.logger.Logf(logMessage, feedLinks[index], err)
+
+			if firstErr == nil {
+				const message = "unable to load feed link %q"
+				firstErr = errors.Wrapf(err, message, feedLinks[index])
+			}
+
+			continue
+		}
+
+		totalFeedData.Items = append(totalFeedData.Items, feedData.Items...)
+	}
+
+	return totalFeedData, firstErr
+}
+
+// RegisterFeedReader ...
+//
+// Unlike the RegisterFeed method, it parses the feed from the already
+// received data, so neither the link generator nor the link loader is used.
+// The reader isn't read if the feed is already registered.
+func (register FeedRegister) RegisterFeedReader(
+	ctx context.Context,
+	feedLink string,
+	reader io.Reader,
+) (
+	feeds.Feed,
+	error,
+) {
+	feedData, err := register.feedRegister.RegisterValue(
+		ctx,
+		feedLink,
+		func(ctx context.Context, feedLink interface{}) (interface{}, error) {
+			data, err := ioutil.ReadAll(reader)
+			if err != nil {
+				return nil, errors.Wrap(err, "unable to read the feed")
+			}
+
+			return parseFeed(feedLink.(string), data)
+		},
+	)
+	if err != nil {
+		return feeds.Feed{}, err
+	}
+
+	return feedData.(feeds.Feed), nil
+}
+
+func (register FeedRegister) loadFeedData(
+	ctx context.Context,
+	feedLink string,
+) (feeds.Feed, error) {
+	feedData, err := register.feedRegister.RegisterValue(
+		ctx,
+		feedLink,
+		func(ctx context.Context, feedLink interface{}) (interface{}, error) {
+			return register.loadFeed(ctx, feedLink.(string))
+		},
+	)
+	if err != nil {
+		return feeds.Feed{}, err
+	}
+
+	return feedData.(feeds.Feed), nil
+}
+
+func (register FeedRegister) loadFeed(
+	ctx context.Context,
+	feedLink string,
+) (feeds.Feed, error) {
+	data, err := register.linkLoader(feedLink, ctx)
+	if err != nil {
+		return feeds.Feed{}, errors.Wrap(err, "unable to load the feed")
+	}
+
+	return parseFeed(feedLink, data)
+}
+
+func parseFeed(feedLink string, data []byte) (feeds.Feed, error) {
+	feedData, err := feeds.ParseFeed(data)
+	if err != nil {
+		return feeds.Feed{}, errors.Wrap(err, "unable to parse the feed")
+	}
+
+	parsedFeedLink, err := url.Parse(feedLink)
+	if err != nil {
+		return feeds.Feed{}, errors.Wrap(err, "unable to parse the feed link")
+	}
+
+	// the item links are resolved relative to the feed link
+	for index, item := range feedData.Items {
+		parsedItemLink, err := url.Parse(item.Link)
+		if err != nil {
+			return feeds.Feed{},
+				errors.Wrapf(err, "unable to parse item link %q", item.Link)
+		}
+
+		itemLink := parsedFeedLink.ResolveReference(parsedItemLink)
+		feedData.Items[index].Link = itemLink.String()
+	}
+
+	return feedData, nil
+}
+
+func uniqueLinks(links []string) []string {
+	var uniqueLinks []string
+	registeredLinks := make(map[string]struct{})
+	for _, link := range links {
+		if _, ok := registeredLinks[link]; ok {
+			continue
+		}
+
+		registeredLinks[link] = struct{}{}
+		uniqueLinks = append(uniqueLinks, link)
+	}
+
+	return uniqueLinks
+}
@@ -0,0 +1,520 @@
+package registers
+
+import (
+	"context"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/go-log/log"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/thewizardplusplus/go-crawler/models"
+	"github.com/thewizardplusplus/go-crawler/registers/feeds"
+)
+
+func TestNewFeedRegister(test *testing.T) {
+	linkGenerator := new(MockLinkExtractor)
+	logger := new(MockLogger)
+	linkLoader := new(MockLinkLoader)
+	register := NewFeedRegister(linkGenerator, logger, linkLoader.LoadLink)
+
+	mock.AssertExpectationsForObjects(test, linkGenerator, logger, linkLoader)
+	assert.Equal(test, linkGenerator, register.linkGenerator)
+	assert.Equal(test, logger, register.logger)
+	assert.NotNil(test, register.linkLoader)
+	assert.Equal(
+		test,
+		NewBasicRegister(),
+		register.feedRegister,
+	)
+}
+
+func TestFeedRegister_RegisterFeed(test *testing.T) {
+	type fields struct {
+		linkGenerator models.LinkExtractor
+		logger        log.Logger
+		linkLoader    LinkLoader
+		feedRegister  BasicRegister
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		link     string
+	}
+
+	for _, data := range []struct {
+		name         string
+		fields       fields
+		args         args
+		wantFeedData feeds.Feed
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					feedLinks := []string{
+						"http://example.com/rss.xml",
+						"http://example.com/atom.xml",
+						"http://example.com/rss.xml",
+					}
+
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/test").
+						Return(feedLinks, nil)
+
+					return linkGenerator
+				}(),
+				linkLoader: func() LinkLoader {
+					const responseOne = `
+						<rss version="2.0">
+							<channel>
+								<item><link>http://example.com/1</link></item>
+								<item><link>/2</link></item>
+							</channel>
+						</rss>
+					`
+					const responseTwo = `
+						<feed xmlns="http://www.w3.org/2005/Atom">
+							<entry><link href="http://example.com/3" /></entry>
+							<entry><link href="4" /></entry>
+						</feed>
+					`
+
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte(responseOne), nil).
+						Once()
+					linkLoader.
+						On("LoadLink", "http://example.com/atom.xml", context.Background()).
+						Return([]byte(responseTwo), nil).
+						Once()
+
+					return linkLoader
+				}(),
+				feedRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/test",
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{
+					{Link: "http://example.com/1"},
+					{Link: "http://example.com/2"},
+					{Link: "http://example.com/3"},
+					{Link: "http://example.com/4"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with some feeds",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					feedLinks := []string{
+						"http://example.com/rss.xml",
+						"http://example.com/atom.xml",
+					}
+
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/test").
+						Return(feedLinks, nil)
+
+					return linkGenerator
+				}(),
+				logger: func() Logger {
+					logger := new(MockLogger)
+					logger.
+						On(
+							"Logf",
+							"unable to load feed link %q: %s",
+							"http://example.com/atom.xml",
+							mock.AnythingOfType("*errors.withStack"),
+						).
+						Return()
+
+					return logger
+				}(),
+				linkLoader: func() LinkLoader {
+					const response = `
+						<rss version="2.0">
+							<channel>
+								<item><link>http://example.com/1</link></item>
+							</channel>
+						</rss>
+					`
+
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte(response), nil)
+					linkLoader.
+						On("LoadLink", "http://example.com/atom.xml", context.Background()).
+						Return(nil, iotest.ErrTimeout)
+
+					return linkLoader
+				}(),
+				feedRegister: NewBasicRegister(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/test",
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{{Link: "http://example.com/1"}},
+			},
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.Equal(test, iotest.ErrTimeout, errors.Cause(err), msgAndArgs...)
+			},
+		},
+		{
+			name: "error",
+			fields: fields{
+				linkGenerator: func() models.LinkExtractor {
+					linkGenerator := new(MockLinkExtractor)
+					linkGenerator.
+						On("ExtractLinks", context.Background(), 23, "http://example.com/test").
+						Return(nil, iotest.ErrTimeout)
+
+					return linkGenerator
+				}(),
+				linkLoader: new(MockLinkLoader),
+				feedRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				link:     "http://example.com/test",
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr:      assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := FeedRegister{
+				linkGenerator: data.fields.linkGenerator,
+				logger:        data.fields.logger,
+				linkLoader:    data.fields.linkLoader.LoadLink,
+				feedRegister:  data.fields.feedRegister,
+			}
+			gotFeedData, gotErr :=
+				register.RegisterFeed(data.args.ctx, data.args.threadID, data.args.link)
+
+			mock.AssertExpectationsForObjects(
+				test,
+				data.fields.linkGenerator,
+				data.fields.linkLoader,
+			)
+			if data.fields.logger != nil {
+				mock.AssertExpectationsForObjects(test, data.fields.logger)
+			}
+			assert.Equal(test, data.wantFeedData, gotFeedData)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestFeedRegister_RegisterFeedReader(test *testing.T) {
+	type fields struct {
+		feedRegister BasicRegister
+	}
+	type args struct {
+		ctx      context.Context
+		feedLink string
+		reader   io.Reader
+	}
+
+	for _, data := range []struct {
+		name         string
+		fields       fields
+		args         args
+		wantFeedData feeds.Feed
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success",
+			fields: fields{
+				feedRegister: NewBasicRegister(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+				reader: strings.NewReader(`
+					<rss version="2.0">
+						<channel>
+							<item><link>http://example.com/1</link></item>
+							<item><link>/2</link></item>
+						</channel>
+					</rss>
+				`),
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{
+					{Link: "http://example.com/1"},
+					{Link: "http://example.com/2"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the registered feed",
+			fields: fields{
+				feedRegister: func() BasicRegister {
+					register := NewBasicRegister()
+					register.RegisterValue( // nolint: errcheck, gosec
+						context.Background(),
+						"http://example.com/rss.xml",
+						func(ctx context.Context, key interface{}) (interface{}, error) {
+							feedData := feeds.Feed{
+								Items: []feeds.Item{{Link: "http://example.com/1"}},
+							}
+							return feedData, nil
+						},
+					)
+
+					return register
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+				// the reader isn't read for the registered feed
+				reader: iotest.TimeoutReader(strings.NewReader("")),
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{{Link: "http://example.com/1"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with reading",
+			fields: fields{
+				feedRegister: NewBasicRegister(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+				reader:   iotest.TimeoutReader(strings.NewReader("data")),
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error with parsing",
+			fields: fields{
+				feedRegister: NewBasicRegister(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+				reader:   strings.NewReader("incorrect"),
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr:      assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := FeedRegister{
+				feedRegister: data.fields.feedRegister,
+			}
+			gotFeedData, gotErr := register.RegisterFeedReader(
+				data.args.ctx,
+				data.args.feedLink,
+				data.args.reader,
+			)
+
+			assert.Equal(test, data.wantFeedData, gotFeedData)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestFeedRegister_loadFeedData(test *testing.T) {
+	type fields struct {
+		linkLoader   LinkLoader
+		feedRegister BasicRegister
+	}
+	type args struct {
+		ctx      context.Context
+		feedLink string
+	}
+
+	for _, data := range []struct {
+		name         string
+		fields       fields
+		args         args
+		wantFeedData feeds.Feed
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with an unregistered feed link",
+			fields: fields{
+				linkLoader: func() LinkLoader {
+					const response = `
+						<rss version="2.0">
+							<channel>
+								<item><title>One</title><link>/1</link></item>
+							</channel>
+						</rss>
+					`
+
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte(response), nil)
+
+					return linkLoader
+				}(),
+				feedRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{{Title: "One", Link: "http://example.com/1"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a registered feed link",
+			fields: fields{
+				linkLoader: new(MockLinkLoader),
+				feedRegister: BasicRegister{
+					registeringCalls: newCallGroup(),
+					registeredValues: func() *sync.Map {
+						feedData := feeds.Feed{
+							Items: []feeds.Item{{Title: "One", Link: "http://example.com/1"}},
+						}
+
+						registeredFeeds := new(sync.Map)
+						registeredFeeds.Store("http://example.com/rss.xml", feedData)
+
+						return registeredFeeds
+					}(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+			},
+			wantFeedData: feeds.Feed{
+				Items: []feeds.Item{{Title: "One", Link: "http://example.com/1"}},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with loading",
+			fields: fields{
+				linkLoader: func() LinkLoader {
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return(nil, iotest.ErrTimeout)
+
+					return linkLoader
+				}(),
+				feedRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.Equal(test, iotest.ErrTimeout, errors.Cause(err), msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a cached error",
+			fields: fields{
+				linkLoader: new(MockLinkLoader),
+				feedRegister: func() BasicRegister {
+					register := NewBasicRegister(WithErrorTTL(time.Hour))
+					register.RegisterValue( // nolint: errcheck, gosec
+						context.Background(),
+						"http://example.com/rss.xml",
+						func(context.Context, interface{}) (interface{}, error) {
+							return nil, iotest.ErrTimeout
+						},
+					)
+
+					return register
+				}(),
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr:      assert.Error,
+		},
+		{
+			name: "error with parsing",
+			fields: fields{
+				linkLoader: func() LinkLoader {
+					linkLoader := new(MockLinkLoader)
+					linkLoader.
+						On("LoadLink", "http://example.com/rss.xml", context.Background()).
+						Return([]byte("<html></html>"), nil)
+
+					return linkLoader
+				}(),
+				feedRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			},
+			args: args{
+				ctx:      context.Background(),
+				feedLink: "http://example.com/rss.xml",
+			},
+			wantFeedData: feeds.Feed{},
+			wantErr:      assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := FeedRegister{
+				linkLoader:   data.fields.linkLoader.LoadLink,
+				feedRegister: data.fields.feedRegister,
+			}
+			gotFeedData, gotErr :=
+				register.loadFeedData(data.args.ctx, data.args.feedLink)
+
+			mock.AssertExpectationsForObjects(test, data.fields.linkLoader)
+			assert.Equal(test, data.wantFeedData, gotFeedData)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
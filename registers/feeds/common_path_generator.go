@@ -0,0 +1,62 @@
+package feeds
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+// DefaultCommonPaths ...
+var DefaultCommonPaths = []string{ // nolint: gochecknoglobals
+	"feed",
+	"rss",
+	"feed.xml",
+	"rss.xml",
+	"atom.xml",
+	"index.xml",
+}
+
+// CommonPathGenerator ...
+type CommonPathGenerator struct {
+	SanitizeLink urlutils.LinkSanitizer // optional
+	// optional; DefaultCommonPaths are used by default
+	CommonPaths  []string
+	MaximalDepth int
+}
+
+// ExtractLinks ...
+func (generator CommonPathGenerator) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	baseLink string,
+) (
+	[]string,
+	error,
+) {
+	commonPaths := generator.CommonPaths
+	if commonPaths == nil {
+		commonPaths = DefaultCommonPaths
+	}
+
+	var feedLinks []string
+	for _, commonPath := range commonPaths {
+		hierarchicalLinks, err := urlutils.GenerateHierarchicalLinks(
+			baseLink,
+			commonPath,
+			urlutils.SanitizeBaseLink(generator.SanitizeLink),
+			urlutils.WithMaximalHierarchyDepth(generator.MaximalDepth),
+		)
+		if err != nil {
+			return nil, errors.Wrapf(
+				err,
+				"unable to generate the hierarchical links for path %q",
+				commonPath,
+			)
+		}
+
+		feedLinks = append(feedLinks, hierarchicalLinks...)
+	}
+
+	return feedLinks, nil
+}
@@ -0,0 +1,122 @@
+package feeds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestCommonPathGenerator_ExtractLinks(test *testing.T) {
+	type fields struct {
+		SanitizeLink urlutils.LinkSanitizer
+		CommonPaths  []string
+		MaximalDepth int
+	}
+	type args struct {
+		ctx      context.Context
+		threadID int
+		baseLink string
+	}
+
+	for _, data := range []struct {
+		name          string
+		fields        fields
+		args          args
+		wantFeedLinks []string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the default common paths",
+			fields: fields{
+				SanitizeLink: nil,
+				CommonPaths:  nil,
+				MaximalDepth: 0,
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				baseLink: "http://example.com/one/two",
+			},
+			wantFeedLinks: []string{
+				"http://example.com/feed",
+				"http://example.com/rss",
+				"http://example.com/feed.xml",
+				"http://example.com/rss.xml",
+				"http://example.com/atom.xml",
+				"http://example.com/index.xml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the custom common paths and a depth limit",
+			fields: fields{
+				SanitizeLink: nil,
+				CommonPaths:  []string{"feed", "atom.xml"},
+				MaximalDepth: 1,
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				baseLink: "http://example.com/one/two",
+			},
+			wantFeedLinks: []string{
+				"http://example.com/feed",
+				"http://example.com/one/feed",
+				"http://example.com/atom.xml",
+				"http://example.com/one/atom.xml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with sanitizing",
+			fields: fields{
+				SanitizeLink: urlutils.SanitizeLink,
+				CommonPaths:  []string{"feed"},
+				MaximalDepth: -1,
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				baseLink: "http://example.com/one/../two/test",
+			},
+			wantFeedLinks: []string{
+				"http://example.com/feed",
+				"http://example.com/two/feed",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error",
+			fields: fields{
+				SanitizeLink: nil,
+				CommonPaths:  []string{"feed"},
+				MaximalDepth: -1,
+			},
+			args: args{
+				ctx:      context.Background(),
+				threadID: 23,
+				baseLink: ":",
+			},
+			wantFeedLinks: nil,
+			wantErr:       assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			generator := CommonPathGenerator{
+				SanitizeLink: data.fields.SanitizeLink,
+				CommonPaths:  data.fields.CommonPaths,
+				MaximalDepth: data.fields.MaximalDepth,
+			}
+			gotFeedLinks, gotErr := generator.ExtractLinks(
+				data.args.ctx,
+				data.args.threadID,
+				data.args.baseLink,
+			)
+
+			assert.Equal(test, data.wantFeedLinks, gotFeedLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package feeds
+
+import (
+	"context"
+)
+
+// DirectGenerator ...
+//
+// It returns the passed link itself, so the feed links may be passed
+// to the feed extractor directly (e.g., via the MIME registry
+// of the extractors.DefaultExtractor).
+type DirectGenerator struct{}
+
+// ExtractLinks ...
+func (generator DirectGenerator) ExtractLinks(
+	ctx context.Context,
+	threadID int,
+	link string,
+) (
+	[]string,
+	error,
+) {
+	return []string{link}, nil
+}
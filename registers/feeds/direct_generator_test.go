@@ -0,0 +1,17 @@
+package feeds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectGenerator_ExtractLinks(test *testing.T) {
+	var generator DirectGenerator
+	gotFeedLinks, gotErr :=
+		generator.ExtractLinks(context.Background(), 23, "http://example.com/feed")
+
+	assert.Equal(test, []string{"http://example.com/feed"}, gotFeedLinks)
+	assert.NoError(test, gotErr)
+}
@@ -0,0 +1,126 @@
+package feeds
+
+import (
+	"encoding/xml"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Feed ...
+type Feed struct {
+	Items []Item
+}
+
+// Item ...
+type Item struct {
+	Title string
+	Link  string
+}
+
+type rootElement struct {
+	XMLName xml.Name
+}
+
+type rssFeed struct {
+	Items []rssItem `xml:"channel>item"`
+}
+
+type rssItem struct {
+	Title string  `xml:"title"`
+	Link  string  `xml:"link"`
+	GUID  rssGUID `xml:"guid"`
+}
+
+type rssGUID struct {
+	IsPermaLink string `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type atomFeed struct {
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomEntry struct {
+	Title string     `xml:"title"`
+	Links []atomLink `xml:"link"`
+}
+
+type atomLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+// ParseFeed ...
+//
+// It supports RSS 2.0 and Atom feeds. The items without links are skipped.
+func ParseFeed(data []byte) (Feed, error) {
+	var root rootElement
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return Feed{}, errors.Wrap(err, "unable to parse the root element")
+	}
+
+	switch root.XMLName.Local {
+	case "rss":
+		return parseRSSFeed(data)
+	case "feed":
+		return parseAtomFeed(data)
+	default:
+		return Feed{}, errors.Errorf("unsupported feed format %q", root.XMLName.Local)
+	}
+}
+
+func parseRSSFeed(data []byte) (Feed, error) {
+	var rssData rssFeed
+	if err := xml.Unmarshal(data, &rssData); err != nil {
+		return Feed{}, errors.Wrap(err, "unable to parse the RSS feed")
+	}
+
+	var feed Feed
+	for _, item := range rssData.Items {
+		link := strings.TrimSpace(item.Link)
+		// the GUID is a permanent link by default
+		if link == "" && item.GUID.IsPermaLink != "false" {
+			link = strings.TrimSpace(item.GUID.Value)
+		}
+		if link == "" {
+			continue
+		}
+
+		feed.Items = append(feed.Items, Item{
+			Title: strings.TrimSpace(item.Title),
+			Link:  link,
+		})
+	}
+
+	return feed, nil
+}
+
+func parseAtomFeed(data []byte) (Feed, error) {
+	var atomData atomFeed
+	if err := xml.Unmarshal(data, &atomData); err != nil {
+		return Feed{}, errors.Wrap(err, "unable to parse the Atom feed")
+	}
+
+	var feed Feed
+	for _, entry := range atomData.Entries {
+		var link string
+		for _, entryLink := range entry.Links {
+			// the link relation is "alternate" by default
+			if entryLink.Rel == "" || entryLink.Rel == "alternate" {
+				link = strings.TrimSpace(entryLink.Href)
+				break
+			}
+		}
+		if link == "" {
+			continue
+		}
+
+		feed.Items = append(feed.Items, Item{
+			Title: strings.TrimSpace(entry.Title),
+			Link:  link,
+		})
+	}
+
+	return feed, nil
+}
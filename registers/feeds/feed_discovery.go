@@ -0,0 +1,144 @@
+package feeds
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+	htmlselector "github.com/thewizardplusplus/go-html-selector"
+)
+
+// DefaultFeedTypes ...
+var DefaultFeedTypes = []string{ // nolint: gochecknoglobals
+	"application/rss+xml",
+	"application/atom+xml",
+}
+
+// DiscoverFeedLinks ...
+//
+// It discovers the feeds declared in the page
+// via the <link rel="alternate" type="..." href="..." /> tags. The feed links
+// are resolved relative to the page; the ones that can't be resolved
+// are skipped. DefaultFeedTypes are used if the feed types are nil.
+func DiscoverFeedLinks(
+	response *http.Response,
+	pageReader io.Reader,
+	feedTypes []string,
+) ([]string, error) {
+	var builder feedLinkBuilder
+	err := htmlselector.SelectTags(
+		pageReader,
+		htmlselector.OptimizeFilters(htmlselector.FilterGroup{
+			"base": {"href"},
+			"link": {"rel", "type", "href"},
+		}),
+		&builder,
+		htmlselector.SkipEmptyTags(),
+		htmlselector.SkipEmptyAttributes(),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to select the tags")
+	}
+
+	baseLinks := urlutils.GenerateBaseLinks(
+		response,
+		builder.baseTagValue,
+		urlutils.DefaultBaseHeaderNames,
+	)
+	linkResolver, err := urlutils.NewLinkResolver(baseLinks)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the link resolver")
+	}
+
+	if feedTypes == nil {
+		feedTypes = DefaultFeedTypes
+	}
+
+	var feedLinks []string
+	for _, link := range builder.links {
+		if !link.isFeed(feedTypes) {
+			continue
+		}
+
+		feedLink, err := linkResolver.ResolveLink(link.href)
+		if err != nil {
+			continue
+		}
+
+		feedLinks = append(feedLinks, feedLink)
+	}
+
+	return feedLinks, nil
+}
+
+type feedLink struct {
+	rel      string
+	mimeType string
+	href     string
+}
+
+func (link feedLink) isFeed(feedTypes []string) bool {
+	if link.href == "" {
+		return false
+	}
+
+	var isAlternate bool
+	for _, relation := range strings.Fields(link.rel) {
+		if strings.EqualFold(relation, "alternate") {
+			isAlternate = true
+			break
+		}
+	}
+	if !isAlternate {
+		return false
+	}
+
+	mimeType, _, err := mime.ParseMediaType(link.mimeType)
+	if err != nil {
+		return false
+	}
+	for _, feedType := range feedTypes {
+		if mimeType == feedType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type feedLinkBuilder struct {
+	currentTagName string
+	baseTagValue   string
+	links          []feedLink
+}
+
+func (builder *feedLinkBuilder) AddTag(name []byte) {
+	builder.currentTagName = string(name)
+	if builder.currentTagName == "link" {
+		builder.links = append(builder.links, feedLink{})
+	}
+}
+
+func (builder *feedLinkBuilder) AddAttribute(name []byte, value []byte) {
+	if builder.currentTagName == "base" {
+		// only the first <base> tag is significant
+		if builder.baseTagValue == "" {
+			builder.baseTagValue = string(value)
+		}
+
+		return
+	}
+
+	link := &builder.links[len(builder.links)-1]
+	switch string(name) {
+	case "rel":
+		link.rel = string(value)
+	case "type":
+		link.mimeType = string(value)
+	case "href":
+		link.href = strings.TrimSpace(string(value))
+	}
+}
@@ -0,0 +1,103 @@
+package feeds
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscoverFeedLinks(test *testing.T) {
+	type args struct {
+		response   *http.Response
+		pageReader io.Reader
+		feedTypes  []string
+	}
+
+	for _, data := range []struct {
+		name          string
+		args          args
+		wantFeedLinks []string
+		wantErr       assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the default feed types",
+			args: args{
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				},
+				pageReader: strings.NewReader(`
+					<html>
+						<head>
+							<link rel="stylesheet" type="text/css" href="/style.css" />
+							<link rel="alternate" type="application/rss+xml" href="/rss.xml" />
+							<link
+								rel="Alternate"
+								type="application/atom+xml; charset=utf-8"
+								href="http://example.com/atom.xml"
+							/>
+							<link rel="alternate" type="application/rss+xml" />
+							<link rel="alternate" type="application/rss+xml" href="http://[::1" />
+							<link rel="alternate" hreflang="en" href="/en/" />
+						</head>
+					</html>
+				`),
+				feedTypes: nil,
+			},
+			wantFeedLinks: []string{
+				"http://example.com/rss.xml",
+				"http://example.com/atom.xml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the custom feed types and the base tag",
+			args: args{
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				},
+				pageReader: strings.NewReader(`
+					<html>
+						<head>
+							<base href="http://example.com/base/" />
+							<link rel="alternate" type="application/rss+xml" href="rss.xml" />
+							<link rel="alternate" type="application/feed+json" href="feed.json" />
+						</head>
+					</html>
+				`),
+				feedTypes: []string{"application/feed+json"},
+			},
+			wantFeedLinks: []string{"http://example.com/base/feed.json"},
+			wantErr:       assert.NoError,
+		},
+		{
+			name: "error with page reading",
+			args: args{
+				response: &http.Response{
+					Request: httptest.NewRequest(http.MethodGet, "http://example.com/test", nil),
+				},
+				pageReader: iotest.TimeoutReader(strings.NewReader(`
+					<link rel="alternate" type="application/rss+xml" href="/rss.xml" />
+				`)),
+				feedTypes: nil,
+			},
+			wantFeedLinks: nil,
+			wantErr:       assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotFeedLinks, gotErr := DiscoverFeedLinks(
+				data.args.response,
+				data.args.pageReader,
+				data.args.feedTypes,
+			)
+
+			assert.Equal(test, data.wantFeedLinks, gotFeedLinks)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package feeds
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeed(test *testing.T) {
+	type args struct {
+		data []byte
+	}
+
+	for _, data := range []struct {
+		name     string
+		args     args
+		wantFeed Feed
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with an RSS feed",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<rss version="2.0">
+						<channel>
+							<title>Test</title>
+							<item>
+								<title>One</title>
+								<link>http://example.com/1</link>
+							</item>
+							<item>
+								<title>Two</title>
+								<guid>http://example.com/2</guid>
+							</item>
+							<item>
+								<title>Three</title>
+								<guid isPermaLink="false">3</guid>
+							</item>
+						</channel>
+					</rss>
+				`),
+			},
+			wantFeed: Feed{
+				Items: []Item{
+					{Title: "One", Link: "http://example.com/1"},
+					{Title: "Two", Link: "http://example.com/2"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an Atom feed",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<feed xmlns="http://www.w3.org/2005/Atom">
+						<title>Test</title>
+						<entry>
+							<title>One</title>
+							<link href="http://example.com/1" />
+						</entry>
+						<entry>
+							<title>Two</title>
+							<link rel="edit" href="http://example.com/2/edit" />
+							<link rel="alternate" href="http://example.com/2" />
+						</entry>
+						<entry>
+							<title>Three</title>
+							<link rel="edit" href="http://example.com/3/edit" />
+						</entry>
+					</feed>
+				`),
+			},
+			wantFeed: Feed{
+				Items: []Item{
+					{Title: "One", Link: "http://example.com/1"},
+					{Title: "Two", Link: "http://example.com/2"},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with an empty feed",
+			args: args{
+				data: []byte(`<rss version="2.0"><channel></channel></rss>`),
+			},
+			wantFeed: Feed{},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "error with an unsupported feed format",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+					</urlset>
+				`),
+			},
+			wantFeed: Feed{},
+			wantErr:  assert.Error,
+		},
+		{
+			name: "error with an invalid XML",
+			args: args{
+				data: []byte("<rss"),
+			},
+			wantFeed: Feed{},
+			wantErr:  assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotFeed, gotErr := ParseFeed(data.args.data)
+
+			assert.Equal(test, data.wantFeed, gotFeed)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
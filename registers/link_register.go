@@ -8,13 +8,13 @@ import (
 
 // LinkRegister ...
 type LinkRegister struct {
-	sanitizeLink urlutils.LinkSanitizing
+	sanitizeLink urlutils.LinkSanitizer
 
 	registeredLinks mapset.Set
 }
 
 // NewLinkRegister ...
-func NewLinkRegister(sanitizeLink urlutils.LinkSanitizing) LinkRegister {
+func NewLinkRegister(sanitizeLink urlutils.LinkSanitizer) LinkRegister {
 	return LinkRegister{
 		sanitizeLink: sanitizeLink,
 
@@ -27,15 +27,23 @@ func (register LinkRegister) RegisterLink(link string) (
 	wasRegistered bool,
 	err error,
 ) {
-	if register.sanitizeLink == urlutils.SanitizeLink {
-		sanitizedLink, err := urlutils.ApplyLinkSanitizing(link)
-		if err != nil {
-			return false, errors.Wrapf(err, "unable to sanitize the link")
-		}
-
-		link = sanitizedLink
+	link, err = sanitizeLink(register.sanitizeLink, link)
+	if err != nil {
+		return false, err
 	}
 
 	wasRegistered = register.registeredLinks.Add(link)
 	return wasRegistered, nil
 }
+
+func sanitizeLink(
+	sanitizer urlutils.LinkSanitizer,
+	link string,
+) (string, error) {
+	sanitizedLink, err := urlutils.ApplyLinkSanitizer(sanitizer, link)
+	if err != nil {
+		return "", errors.Wrapf(err, "unable to sanitize the link")
+	}
+
+	return sanitizedLink, nil
+}
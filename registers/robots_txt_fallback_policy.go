@@ -0,0 +1,146 @@
+package registers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// RobotsTXTFailureKind ...
+type RobotsTXTFailureKind int
+
+// ...
+const (
+	// the robots.txt file is unavailable (4xx status)
+	ClientErrorFailure RobotsTXTFailureKind = iota
+	// the robots.txt file is unreachable because of a server error (5xx status)
+	ServerErrorFailure
+	// the robots.txt file is unreachable because of a network error
+	NetworkErrorFailure
+	// the robots.txt file is unreachable because of a timeout
+	TimeoutFailure
+)
+
+// String ...
+func (kind RobotsTXTFailureKind) String() string {
+	switch kind {
+	case ClientErrorFailure:
+		return "client error"
+	case ServerErrorFailure:
+		return "server error"
+	case NetworkErrorFailure:
+		return "network error"
+	case TimeoutFailure:
+		return "timeout"
+	default:
+		return fmt.Sprintf("robots.txt failure #%d", int(kind))
+	}
+}
+
+// RobotsTXTFailure ...
+type RobotsTXTFailure struct {
+	Kind RobotsTXTFailureKind
+	Err  error
+}
+
+// Error ...
+func (failure RobotsTXTFailure) Error() string {
+	return fmt.Sprintf("robots.txt %s: %s", failure.Kind, failure.Err)
+}
+
+// Unwrap ...
+//
+// It's required for the errors package of the standard library.
+//
+// The Cause method isn't implemented intentionally, so the errors.Cause()
+// function of the github.com/pkg/errors package stops on this error.
+func (failure RobotsTXTFailure) Unwrap() error {
+	return failure.Err
+}
+
+// RobotsTXTOutcome ...
+type RobotsTXTOutcome int
+
+// ...
+const (
+	// in a policy, it means the outcome recommended by RFC 9309:
+	// the allowing of all links for the ClientErrorFailure kind
+	// and the retrying later for the other kinds
+	DefaultOutcome RobotsTXTOutcome = iota
+	AllowAllOutcome
+	DisallowAllOutcome
+	// the failure is returned as an error; the register caches it
+	// according to its error TTL (DefaultRobotsTXTErrorTTL by default)
+	// and then tries to load the robots.txt file again, so the links
+	// are disallowed meanwhile
+	RetryLaterOutcome
+)
+
+// String ...
+func (outcome RobotsTXTOutcome) String() string {
+	switch outcome {
+	case DefaultOutcome:
+		return "default"
+	case AllowAllOutcome:
+		return "allow all"
+	case DisallowAllOutcome:
+		return "disallow all"
+	case RetryLaterOutcome:
+		return "retry later"
+	default:
+		return fmt.Sprintf("robots.txt outcome #%d", int(outcome))
+	}
+}
+
+// RobotsTXTFallbackPolicy ...
+type RobotsTXTFallbackPolicy struct {
+	ClientErrorOutcome  RobotsTXTOutcome
+	ServerErrorOutcome  RobotsTXTOutcome
+	NetworkErrorOutcome RobotsTXTOutcome
+	TimeoutOutcome      RobotsTXTOutcome
+}
+
+// Outcome ...
+//
+// It never returns the DefaultOutcome value.
+func (policy RobotsTXTFallbackPolicy) Outcome(
+	kind RobotsTXTFailureKind,
+) RobotsTXTOutcome {
+	var outcome RobotsTXTOutcome
+	switch kind {
+	case ClientErrorFailure:
+		outcome = policy.ClientErrorOutcome
+	case ServerErrorFailure:
+		outcome = policy.ServerErrorOutcome
+	case NetworkErrorFailure:
+		outcome = policy.NetworkErrorOutcome
+	case TimeoutFailure:
+		outcome = policy.TimeoutOutcome
+	}
+	if outcome != DefaultOutcome {
+		return outcome
+	}
+
+	if kind == ClientErrorFailure {
+		return AllowAllOutcome
+	}
+
+	return RetryLaterOutcome
+}
+
+// it returns the error as is if it's caused by the cancellation
+// of the context, since it isn't a failure of the robots.txt file
+func newRobotsTXTFailure(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+
+	kind := NetworkErrorFailure
+	if netErr, ok := errors.Cause(err).(net.Error); ok && netErr.Timeout() {
+		kind = TimeoutFailure
+	}
+
+	return RobotsTXTFailure{Kind: kind, Err: err}
+}
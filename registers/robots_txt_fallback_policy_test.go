@@ -0,0 +1,134 @@
+package registers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"testing/iotest"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRobotsTXTFallbackPolicy_Outcome(test *testing.T) {
+	type args struct {
+		kind RobotsTXTFailureKind
+	}
+
+	for _, data := range []struct {
+		name   string
+		policy RobotsTXTFallbackPolicy
+		args   args
+		want   RobotsTXTOutcome
+	}{
+		{
+			name:   "client error/default outcome",
+			policy: RobotsTXTFallbackPolicy{},
+			args:   args{kind: ClientErrorFailure},
+			want:   AllowAllOutcome,
+		},
+		{
+			name:   "client error/specified outcome",
+			policy: RobotsTXTFallbackPolicy{ClientErrorOutcome: DisallowAllOutcome},
+			args:   args{kind: ClientErrorFailure},
+			want:   DisallowAllOutcome,
+		},
+		{
+			name:   "server error/default outcome",
+			policy: RobotsTXTFallbackPolicy{},
+			args:   args{kind: ServerErrorFailure},
+			want:   RetryLaterOutcome,
+		},
+		{
+			name:   "server error/specified outcome",
+			policy: RobotsTXTFallbackPolicy{ServerErrorOutcome: DisallowAllOutcome},
+			args:   args{kind: ServerErrorFailure},
+			want:   DisallowAllOutcome,
+		},
+		{
+			name:   "network error/default outcome",
+			policy: RobotsTXTFallbackPolicy{},
+			args:   args{kind: NetworkErrorFailure},
+			want:   RetryLaterOutcome,
+		},
+		{
+			name:   "network error/specified outcome",
+			policy: RobotsTXTFallbackPolicy{NetworkErrorOutcome: AllowAllOutcome},
+			args:   args{kind: NetworkErrorFailure},
+			want:   AllowAllOutcome,
+		},
+		{
+			name:   "timeout/default outcome",
+			policy: RobotsTXTFallbackPolicy{},
+			args:   args{kind: TimeoutFailure},
+			want:   RetryLaterOutcome,
+		},
+		{
+			name:   "timeout/specified outcome",
+			policy: RobotsTXTFallbackPolicy{TimeoutOutcome: AllowAllOutcome},
+			args:   args{kind: TimeoutFailure},
+			want:   AllowAllOutcome,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := data.policy.Outcome(data.args.kind)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func Test_newRobotsTXTFailure(test *testing.T) {
+	type args struct {
+		ctx context.Context
+		err error
+	}
+
+	for _, data := range []struct {
+		name    string
+		args    args
+		wantErr error
+	}{
+		{
+			name: "network error",
+			args: args{
+				ctx: context.Background(),
+				err: iotest.ErrTimeout,
+			},
+			wantErr: RobotsTXTFailure{
+				Kind: NetworkErrorFailure,
+				Err:  iotest.ErrTimeout,
+			},
+		},
+		{
+			name: "timeout",
+			args: args{
+				ctx: context.Background(),
+				err: errors.WithMessage(&net.DNSError{IsTimeout: true}, "test"),
+			},
+			wantErr: RobotsTXTFailure{
+				Kind: TimeoutFailure,
+				Err:  errors.WithMessage(&net.DNSError{IsTimeout: true}, "test"),
+			},
+		},
+		{
+			name: "cancelled context",
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				err: context.Canceled,
+			},
+			wantErr: context.Canceled,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotErr := newRobotsTXTFailure(data.args.ctx, data.args.err)
+
+			assert.Equal(test, data.wantErr, gotErr)
+		})
+	}
+}
@@ -5,24 +5,53 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
-	"github.com/temoto/robotstxt"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+	robotstxtutils "github.com/thewizardplusplus/go-crawler/robots-txt-utils"
 	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
 	httputils "github.com/thewizardplusplus/go-http-utils"
 )
 
+// DefaultRobotsTXTSizeLimit ...
+//
+// RFC 9309 requires to parse at least 500 KiB of the robots.txt file
+// and allows to ignore the rest of it.
+var DefaultRobotsTXTSizeLimit = ioutils.SizeLimit{ // nolint: gochecknoglobals
+	MaximalSize: 500 * 1024,
+	Truncate:    true,
+}
+
+// RobotsTXTResult ...
+type RobotsTXTResult struct {
+	// it's used for the matching of links according to RFC 9309
+	// and for the reading of the crawl delays and the sitemap links
+	RuleSet robotstxtutils.RuleSet
+	// they are set only if the robots.txt file failed to load
+	// and the fallback policy was applied
+	Failure *RobotsTXTFailure
+	Outcome RobotsTXTOutcome
+}
+
 // RobotsTXTRegister ...
 type RobotsTXTRegister struct {
-	httpClient httputils.HTTPClient
+	httpClient     httputils.HTTPClient
+	fallbackPolicy RobotsTXTFallbackPolicy
+	sizeLimit      ioutils.SizeLimit
 
 	robotsTXTRegister BasicRegister
 }
 
 // NewRobotsTXTRegister ...
-func NewRobotsTXTRegister(httpClient httputils.HTTPClient) RobotsTXTRegister {
+func NewRobotsTXTRegister(
+	httpClient httputils.HTTPClient,
+	options ...RobotsTXTRegisterOption,
+) RobotsTXTRegister {
+	config := newRobotsTXTRegisterConfig(options)
 	return RobotsTXTRegister{
-		httpClient: httpClient,
+		httpClient:     httpClient,
+		fallbackPolicy: config.fallbackPolicy,
+		sizeLimit:      config.sizeLimit,
 
-		robotsTXTRegister: NewBasicRegister(),
+		robotsTXTRegister: NewBasicRegister(config.cacheOptions...),
 	}
 }
 
@@ -31,7 +60,27 @@ func (register RobotsTXTRegister) RegisterRobotsTXT(
 	ctx context.Context,
 	link string,
 ) (
-	*robotstxt.RobotsData,
+	robotstxtutils.RuleSet,
+	error,
+) {
+	result, err := register.RegisterRobotsTXTResult(ctx, link)
+	if err != nil {
+		return robotstxtutils.RuleSet{}, err
+	}
+
+	return result.RuleSet, nil
+}
+
+// RegisterRobotsTXTResult ...
+//
+// Unlike the RegisterRobotsTXT method, it also returns the outcome
+// of the fallback policy if it was applied. For the RetryLaterOutcome value,
+// the RobotsTXTFailure error is returned instead.
+func (register RobotsTXTRegister) RegisterRobotsTXTResult(
+	ctx context.Context,
+	link string,
+) (
+	RobotsTXTResult,
 	error,
 ) {
 	robotsTXTLinks, err := urlutils.GenerateHierarchicalLinks(
@@ -40,48 +89,111 @@ func (register RobotsTXTRegister) RegisterRobotsTXT(
 		urlutils.WithMaximalHierarchyDepth(0),
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to create the robots.txt link")
+		return RobotsTXTResult{},
+			errors.Wrap(err, "unable to create the robots.txt link")
 	}
 
 	// if successful, the result will always be one link
 	robotsTXTLink := robotsTXTLinks[0]
-	robotsTXTData, err := register.robotsTXTRegister.RegisterValue(
+	result, err := register.robotsTXTRegister.RegisterValue(
 		ctx,
 		robotsTXTLink,
 		func(ctx context.Context, robotsTXTLink interface{}) (interface{}, error) {
-			return register.loadRobotsTXTData(ctx, robotsTXTLink.(string))
+			return register.loadRobotsTXTResult(ctx, robotsTXTLink.(string))
 		},
 	)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to load the robots.txt data")
+		return RobotsTXTResult{},
+			errors.Wrap(err, "unable to load the robots.txt data")
+	}
+
+	return result.(RobotsTXTResult), nil
+}
+
+func (register RobotsTXTRegister) loadRobotsTXTResult(
+	ctx context.Context,
+	robotsTXTLink string,
+) (
+	RobotsTXTResult,
+	error,
+) {
+	result, err := register.loadRobotsTXTData(ctx, robotsTXTLink)
+	if err == nil {
+		return result, nil
 	}
 
-	return robotsTXTData.(*robotstxt.RobotsData), nil
+	failure, ok := errors.Cause(err).(RobotsTXTFailure)
+	if !ok {
+		return RobotsTXTResult{}, err
+	}
+
+	var ruleSet robotstxtutils.RuleSet
+	outcome := register.fallbackPolicy.Outcome(failure.Kind)
+	switch outcome {
+	case AllowAllOutcome:
+		ruleSet = robotstxtutils.AllowAllRuleSet()
+	case DisallowAllOutcome:
+		ruleSet = robotstxtutils.DisallowAllRuleSet()
+	default:
+		return RobotsTXTResult{}, err
+	}
+
+	return RobotsTXTResult{
+		RuleSet: ruleSet,
+		Failure: &failure,
+		Outcome: outcome,
+	}, nil
 }
 
 func (register RobotsTXTRegister) loadRobotsTXTData(
 	ctx context.Context,
 	robotsTXTLink string,
 ) (
-	*robotstxt.RobotsData,
+	RobotsTXTResult,
 	error,
 ) {
 	request, err := http.NewRequest(http.MethodGet, robotsTXTLink, nil)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to create the request")
+		return RobotsTXTResult{}, errors.Wrap(err, "unable to create the request")
 	}
 	request = request.WithContext(ctx)
 
 	response, err := register.httpClient.Do(request)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to send the request")
+		return RobotsTXTResult{}, errors.Wrap(
+			newRobotsTXTFailure(ctx, err),
+			"unable to send the request",
+		)
 	}
 	defer response.Body.Close() // nolint: errcheck
 
-	robotsTXTData, err := robotstxt.FromResponse(response)
+	switch {
+	case response.StatusCode >= 400 && response.StatusCode < 500:
+		return RobotsTXTResult{}, RobotsTXTFailure{
+			Kind: ClientErrorFailure,
+			Err:  errors.Errorf("response status is %d", response.StatusCode),
+		}
+	case response.StatusCode >= 500 && response.StatusCode < 600:
+		return RobotsTXTResult{}, RobotsTXTFailure{
+			Kind: ServerErrorFailure,
+			Err:  errors.Errorf("response status is %d", response.StatusCode),
+		}
+	}
+
+	data, err := ioutils.ReadAll(response.Body, register.sizeLimit)
 	if err != nil {
-		return nil, errors.Wrap(err, "unable to parse the response")
+		// the too large robots.txt file will not become smaller on the reloading,
+		// so the fallback policy isn't applied to it
+		if _, ok := errors.Cause(err).(ioutils.TooLargeError); ok {
+			return RobotsTXTResult{},
+				errors.Wrap(err, "unable to read the response")
+		}
+
+		return RobotsTXTResult{}, errors.Wrap(
+			newRobotsTXTFailure(ctx, err),
+			"unable to read the response",
+		)
 	}
 
-	return robotsTXTData, nil
+	return RobotsTXTResult{RuleSet: robotstxtutils.ParseRuleSet(data)}, nil
 }
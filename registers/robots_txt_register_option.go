@@ -0,0 +1,76 @@
+package registers
+
+import (
+	"time"
+
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+)
+
+// DefaultRobotsTXTErrorTTL ...
+//
+// It's used for the negative caching of the robots.txt files, so an
+// unreachable robots.txt file with the RetryLaterOutcome value is requested
+// again at most once a minute instead of for each link of the host.
+const DefaultRobotsTXTErrorTTL = time.Minute
+
+// RobotsTXTRegisterConfig ...
+type RobotsTXTRegisterConfig struct {
+	fallbackPolicy RobotsTXTFallbackPolicy
+	sizeLimit      ioutils.SizeLimit
+	cacheOptions   []BasicRegisterOption
+}
+
+// RobotsTXTRegisterOption ...
+type RobotsTXTRegisterOption func(config *RobotsTXTRegisterConfig)
+
+// WithRobotsTXTFallbackPolicy ...
+//
+// By default, the fallback policy recommended by RFC 9309 is used.
+func WithRobotsTXTFallbackPolicy(
+	policy RobotsTXTFallbackPolicy,
+) RobotsTXTRegisterOption {
+	return func(config *RobotsTXTRegisterConfig) {
+		config.fallbackPolicy = policy
+	}
+}
+
+// WithRobotsTXTSizeLimit ...
+//
+// By default, DefaultRobotsTXTSizeLimit is used. A negative maximal size
+// means no limit.
+func WithRobotsTXTSizeLimit(
+	sizeLimit ioutils.SizeLimit,
+) RobotsTXTRegisterOption {
+	return func(config *RobotsTXTRegisterConfig) {
+		config.sizeLimit = sizeLimit
+	}
+}
+
+// WithRobotsTXTCacheOptions ...
+//
+// The options are applied to the cache of the robots.txt files
+// after the default ones, so the WithErrorTTL() option overrides
+// DefaultRobotsTXTErrorTTL.
+func WithRobotsTXTCacheOptions(
+	options ...BasicRegisterOption,
+) RobotsTXTRegisterOption {
+	return func(config *RobotsTXTRegisterConfig) {
+		config.cacheOptions = append(config.cacheOptions, options...)
+	}
+}
+
+func newRobotsTXTRegisterConfig(
+	options []RobotsTXTRegisterOption,
+) RobotsTXTRegisterConfig {
+	config := RobotsTXTRegisterConfig{
+		sizeLimit: DefaultRobotsTXTSizeLimit,
+		cacheOptions: []BasicRegisterOption{
+			WithErrorTTL(DefaultRobotsTXTErrorTTL),
+		},
+	}
+	for _, option := range options {
+		option(&config)
+	}
+
+	return config
+}
@@ -0,0 +1,55 @@
+package registers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+)
+
+func TestWithRobotsTXTFallbackPolicy(test *testing.T) {
+	fallbackPolicy := RobotsTXTFallbackPolicy{
+		ServerErrorOutcome: DisallowAllOutcome,
+	}
+
+	var config RobotsTXTRegisterConfig
+	option := WithRobotsTXTFallbackPolicy(fallbackPolicy)
+	option(&config)
+
+	assert.Equal(test, fallbackPolicy, config.fallbackPolicy)
+}
+
+func TestWithRobotsTXTSizeLimit(test *testing.T) {
+	var config RobotsTXTRegisterConfig
+	option := WithRobotsTXTSizeLimit(ioutils.SizeLimit{MaximalSize: 23})
+	option(&config)
+
+	assert.Equal(test, ioutils.SizeLimit{MaximalSize: 23}, config.sizeLimit)
+}
+
+func TestWithRobotsTXTCacheOptions(test *testing.T) {
+	var config RobotsTXTRegisterConfig
+	option := WithRobotsTXTCacheOptions(WithTTL(time.Hour), WithCapacity(23))
+	option(&config)
+
+	assert.Equal(
+		test,
+		BasicRegisterConfig{ttl: time.Hour, capacity: 23},
+		newBasicRegisterConfig(config.cacheOptions),
+	)
+}
+
+func Test_newRobotsTXTRegisterConfig(test *testing.T) {
+	got := newRobotsTXTRegisterConfig([]RobotsTXTRegisterOption{
+		WithRobotsTXTCacheOptions(WithErrorTTL(time.Hour)),
+	})
+
+	assert.Equal(test, RobotsTXTFallbackPolicy{}, got.fallbackPolicy)
+	assert.Equal(test, DefaultRobotsTXTSizeLimit, got.sizeLimit)
+	assert.Equal(
+		test,
+		BasicRegisterConfig{errorTTL: time.Hour},
+		newBasicRegisterConfig(got.cacheOptions),
+	)
+}
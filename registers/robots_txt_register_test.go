@@ -3,16 +3,19 @@ package registers
 import (
 	"context"
 	"io/ioutil"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
 	"testing"
 	"testing/iotest"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"github.com/stretchr/testify/require"
-	"github.com/temoto/robotstxt"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
+	robotstxtutils "github.com/thewizardplusplus/go-crawler/robots-txt-utils"
 	httputils "github.com/thewizardplusplus/go-http-utils"
 )
 
@@ -22,7 +25,100 @@ func TestNewRobotsTXTRegister(test *testing.T) {
 
 	mock.AssertExpectationsForObjects(test, httpClient)
 	assert.Equal(test, httpClient, got.httpClient)
+	assert.Equal(test, RobotsTXTFallbackPolicy{}, got.fallbackPolicy)
+	assert.Equal(test, DefaultRobotsTXTSizeLimit, got.sizeLimit)
 	assert.Equal(test, new(sync.Map), got.robotsTXTRegister.registeredValues)
+	if assert.NotNil(test, got.robotsTXTRegister.registeredErrors) {
+		assert.Equal(
+			test,
+			BasicRegisterConfig{ttl: DefaultRobotsTXTErrorTTL},
+			got.robotsTXTRegister.registeredErrors.config,
+		)
+	}
+}
+
+func TestNewRobotsTXTRegister_withOptions(test *testing.T) {
+	for _, data := range []struct {
+		name               string
+		options            []RobotsTXTRegisterOption
+		wantFallbackPolicy RobotsTXTFallbackPolicy
+		wantSizeLimit      ioutils.SizeLimit
+		wantErrorCache     assert.ValueAssertionFunc
+	}{
+		{
+			name: "with the fallback policy",
+			options: []RobotsTXTRegisterOption{
+				WithRobotsTXTFallbackPolicy(RobotsTXTFallbackPolicy{
+					ServerErrorOutcome: DisallowAllOutcome,
+				}),
+			},
+			wantFallbackPolicy: RobotsTXTFallbackPolicy{
+				ServerErrorOutcome: DisallowAllOutcome,
+			},
+			wantSizeLimit:  DefaultRobotsTXTSizeLimit,
+			wantErrorCache: assert.NotNil,
+		},
+		{
+			name: "with the size limit",
+			options: []RobotsTXTRegisterOption{
+				WithRobotsTXTSizeLimit(ioutils.SizeLimit{MaximalSize: 23}),
+			},
+			wantFallbackPolicy: RobotsTXTFallbackPolicy{},
+			wantSizeLimit:      ioutils.SizeLimit{MaximalSize: 23},
+			wantErrorCache:     assert.NotNil,
+		},
+		{
+			name: "without a size limit",
+			options: []RobotsTXTRegisterOption{
+				WithRobotsTXTSizeLimit(ioutils.SizeLimit{MaximalSize: -1}),
+			},
+			wantFallbackPolicy: RobotsTXTFallbackPolicy{},
+			wantSizeLimit:      ioutils.SizeLimit{MaximalSize: -1},
+			wantErrorCache:     assert.NotNil,
+		},
+		{
+			name: "without the error caching",
+			options: []RobotsTXTRegisterOption{
+				WithRobotsTXTCacheOptions(WithErrorTTL(0)),
+			},
+			wantFallbackPolicy: RobotsTXTFallbackPolicy{},
+			wantSizeLimit:      DefaultRobotsTXTSizeLimit,
+			wantErrorCache:     assert.Nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			httpClient := new(MockHTTPClient)
+			got := NewRobotsTXTRegister(httpClient, data.options...)
+
+			mock.AssertExpectationsForObjects(test, httpClient)
+			assert.Equal(test, httpClient, got.httpClient)
+			assert.Equal(test, data.wantFallbackPolicy, got.fallbackPolicy)
+			assert.Equal(test, data.wantSizeLimit, got.sizeLimit)
+			data.wantErrorCache(test, got.robotsTXTRegister.registeredErrors)
+		})
+	}
+}
+
+func TestNewRobotsTXTRegister_withCacheOptions(test *testing.T) {
+	httpClient := new(MockHTTPClient)
+	got := NewRobotsTXTRegister(
+		httpClient,
+		WithRobotsTXTCacheOptions(WithTTL(time.Hour), WithCapacity(23)),
+	)
+
+	mock.AssertExpectationsForObjects(test, httpClient)
+	assert.Equal(test, httpClient, got.httpClient)
+	if assert.NotNil(test, got.robotsTXTRegister.boundedValues) {
+		assert.Equal(
+			test,
+			BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 23,
+				errorTTL: DefaultRobotsTXTErrorTTL,
+			},
+			got.robotsTXTRegister.boundedValues.config,
+		)
+	}
 }
 
 func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
@@ -36,11 +132,11 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 	}
 
 	for _, data := range []struct {
-		name              string
-		fields            fields
-		args              args
-		wantRobotsTXTData *robotstxt.RobotsData
-		wantErr           assert.ErrorAssertionFunc
+		name        string
+		fields      fields
+		args        args
+		wantRuleSet robotstxtutils.RuleSet
+		wantErr     assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success with an unregistered robots.txt link",
@@ -67,25 +163,23 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 
 					return httpClient
 				}(),
-				robotsTXTRegister: BasicRegister{registeredValues: new(sync.Map)},
+				robotsTXTRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:  context.Background(),
 				link: "http://example.com/test",
 			},
-			wantRobotsTXTData: func() *robotstxt.RobotsData {
-				robotsTXTData, err := robotstxt.FromString(`
+			wantRuleSet: robotstxtutils.ParseRuleSet([]byte(`
 					User-agent: *
 					Disallow: /
 					Allow: /$
 					Allow: /sitemap.xml$
 					Allow: /post/
 					Allow: /storage/app/media/
-				`)
-				require.NoError(test, err)
-
-				return robotsTXTData
-			}(),
+				`)),
 			wantErr: assert.NoError,
 		},
 		{
@@ -93,19 +187,22 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 			fields: fields{
 				httpClient: new(MockHTTPClient),
 				robotsTXTRegister: BasicRegister{
+					registeringCalls: newCallGroup(),
 					registeredValues: func() *sync.Map {
-						robotsTXTData, err := robotstxt.FromString(`
+						ruleSet := robotstxtutils.ParseRuleSet([]byte(`
 							User-agent: *
 							Disallow: /
 							Allow: /$
 							Allow: /sitemap.xml$
 							Allow: /post/
 							Allow: /storage/app/media/
-						`)
-						require.NoError(test, err)
+						`))
 
 						registeredRobotsTXT := new(sync.Map)
-						registeredRobotsTXT.Store("http://example.com/robots.txt", robotsTXTData)
+						registeredRobotsTXT.Store(
+							"http://example.com/robots.txt",
+							RobotsTXTResult{RuleSet: ruleSet},
+						)
 
 						return registeredRobotsTXT
 					}(),
@@ -115,33 +212,31 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 				ctx:  context.Background(),
 				link: "http://example.com/test",
 			},
-			wantRobotsTXTData: func() *robotstxt.RobotsData {
-				robotsTXTData, err := robotstxt.FromString(`
+			wantRuleSet: robotstxtutils.ParseRuleSet([]byte(`
 					User-agent: *
 					Disallow: /
 					Allow: /$
 					Allow: /sitemap.xml$
 					Allow: /post/
 					Allow: /storage/app/media/
-				`)
-				require.NoError(test, err)
-
-				return robotsTXTData
-			}(),
+				`)),
 			wantErr: assert.NoError,
 		},
 		{
 			name: "error with making of a robots.txt link",
 			fields: fields{
-				httpClient:        new(MockHTTPClient),
-				robotsTXTRegister: BasicRegister{registeredValues: new(sync.Map)},
+				httpClient: new(MockHTTPClient),
+				robotsTXTRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:  context.Background(),
 				link: ":",
 			},
-			wantRobotsTXTData: nil,
-			wantErr:           assert.Error,
+			wantRuleSet: robotstxtutils.RuleSet{},
+			wantErr:     assert.Error,
 		},
 		{
 			name: "error with loading of a robots.txt data",
@@ -156,14 +251,17 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 
 					return httpClient
 				}(),
-				robotsTXTRegister: BasicRegister{registeredValues: new(sync.Map)},
+				robotsTXTRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:  context.Background(),
 				link: "http://example.com/test",
 			},
-			wantRobotsTXTData: nil,
-			wantErr:           assert.Error,
+			wantRuleSet: robotstxtutils.RuleSet{},
+			wantErr:     assert.Error,
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
@@ -171,11 +269,222 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 				httpClient:        data.fields.httpClient,
 				robotsTXTRegister: data.fields.robotsTXTRegister,
 			}
-			gotRobotsTXTData, gotErr :=
+			gotRuleSet, gotErr :=
 				register.RegisterRobotsTXT(data.args.ctx, data.args.link)
 
 			mock.AssertExpectationsForObjects(test, data.fields.httpClient)
-			assert.Equal(test, data.wantRobotsTXTData, gotRobotsTXTData)
+			assert.Equal(test, data.wantRuleSet, gotRuleSet)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestRobotsTXTRegister_RegisterRobotsTXTResult(test *testing.T) {
+	type fields struct {
+		httpClient     httputils.HTTPClient
+		fallbackPolicy RobotsTXTFallbackPolicy
+	}
+	type args struct {
+		ctx  context.Context
+		link string
+	}
+
+	for _, data := range []struct {
+		name       string
+		fields     fields
+		args       args
+		wantResult RobotsTXTResult
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success without a failure",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body:       ioutil.NopCloser(strings.NewReader("User-agent: *")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				fallbackPolicy: RobotsTXTFallbackPolicy{},
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/test",
+			},
+			wantResult: RobotsTXTResult{
+				RuleSet: robotstxtutils.RuleSet{
+					Groups: []robotstxtutils.Group{{UserAgents: []string{"*"}}},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a client error and the default outcome",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusNotFound,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				fallbackPolicy: RobotsTXTFallbackPolicy{},
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/test",
+			},
+			wantResult: RobotsTXTResult{
+				RuleSet: robotstxtutils.AllowAllRuleSet(),
+				Failure: &RobotsTXTFailure{
+					Kind: ClientErrorFailure,
+					Err:  errors.New("response status is 404"),
+				},
+				Outcome: AllowAllOutcome,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a server error and the disallowing of all links",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				fallbackPolicy: RobotsTXTFallbackPolicy{
+					ServerErrorOutcome: DisallowAllOutcome,
+				},
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/test",
+			},
+			wantResult: RobotsTXTResult{
+				RuleSet: robotstxtutils.DisallowAllRuleSet(),
+				Failure: &RobotsTXTFailure{
+					Kind: ServerErrorFailure,
+					Err:  errors.New("response status is 503"),
+				},
+				Outcome: DisallowAllOutcome,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "error with a server error and the default outcome",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusServiceUnavailable,
+						Body:       ioutil.NopCloser(strings.NewReader("")),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				fallbackPolicy: RobotsTXTFallbackPolicy{},
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/test",
+			},
+			wantResult: RobotsTXTResult{},
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				failure, ok := errors.Cause(err).(RobotsTXTFailure)
+				return assert.True(test, ok, msgAndArgs...) &&
+					assert.Equal(test, ServerErrorFailure, failure.Kind, msgAndArgs...)
+			},
+		},
+		{
+			name: "error with a timeout and the default outcome",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					httpClient := new(MockHTTPClient)
+					httpClient.
+						On("Do", request).
+						Return(nil, &net.DNSError{IsTimeout: true})
+
+					return httpClient
+				}(),
+				fallbackPolicy: RobotsTXTFallbackPolicy{},
+			},
+			args: args{
+				ctx:  context.Background(),
+				link: "http://example.com/test",
+			},
+			wantResult: RobotsTXTResult{},
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				failure, ok := errors.Cause(err).(RobotsTXTFailure)
+				return assert.True(test, ok, msgAndArgs...) &&
+					assert.Equal(test, TimeoutFailure, failure.Kind, msgAndArgs...)
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := NewRobotsTXTRegister(
+				data.fields.httpClient,
+				WithRobotsTXTFallbackPolicy(data.fields.fallbackPolicy),
+			)
+			gotResult, gotErr :=
+				register.RegisterRobotsTXTResult(data.args.ctx, data.args.link)
+
+			mock.AssertExpectationsForObjects(test, data.fields.httpClient)
+			if data.wantResult.Failure != nil && assert.NotNil(test, gotResult.Failure) {
+				assert.Equal(test, data.wantResult.Failure.Kind, gotResult.Failure.Kind)
+				assert.EqualError(
+					test,
+					gotResult.Failure.Err,
+					data.wantResult.Failure.Err.Error(),
+				)
+
+				data.wantResult.Failure, gotResult.Failure = nil, nil
+			}
+			assert.Equal(test, data.wantResult, gotResult)
 			data.wantErr(test, gotErr)
 		})
 	}
@@ -184,6 +493,7 @@ func TestRobotsTXTRegister_RegisterRobotsTXT(test *testing.T) {
 func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 	type fields struct {
 		httpClient httputils.HTTPClient
+		sizeLimit  ioutils.SizeLimit
 	}
 	type args struct {
 		ctx           context.Context
@@ -191,11 +501,11 @@ func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 	}
 
 	for _, data := range []struct {
-		name              string
-		fields            fields
-		args              args
-		wantRobotsTXTData *robotstxt.RobotsData
-		wantErr           assert.ErrorAssertionFunc
+		name       string
+		fields     fields
+		args       args
+		wantResult RobotsTXTResult
+		wantErr    assert.ErrorAssertionFunc
 	}{
 		{
 			name: "success",
@@ -227,18 +537,52 @@ func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 				ctx:           context.Background(),
 				robotsTXTLink: "http://example.com/robots.txt",
 			},
-			wantRobotsTXTData: func() *robotstxt.RobotsData {
-				robotsTXTData, err := robotstxt.FromString(`
+			wantResult: func() RobotsTXTResult {
+				robotsTXT := `
 					User-agent: *
 					Disallow: /
 					Allow: /$
 					Allow: /sitemap.xml$
 					Allow: /post/
 					Allow: /storage/app/media/
-				`)
-				require.NoError(test, err)
+				`
+				return RobotsTXTResult{
+					RuleSet: robotstxtutils.ParseRuleSet([]byte(robotsTXT)),
+				}
+			}(),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with truncating of the response",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(
+							"User-agent: *\nDisallow: /\nAllow: /post/\n",
+						)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
 
-				return robotsTXTData
+					return httpClient
+				}(),
+				sizeLimit: ioutils.SizeLimit{MaximalSize: 26, Truncate: true},
+			},
+			args: args{
+				ctx:           context.Background(),
+				robotsTXTLink: "http://example.com/robots.txt",
+			},
+			wantResult: func() RobotsTXTResult {
+				robotsTXT := "User-agent: *\nDisallow: /\n"
+				return RobotsTXTResult{
+					RuleSet: robotstxtutils.ParseRuleSet([]byte(robotsTXT)),
+				}
 			}(),
 			wantErr: assert.NoError,
 		},
@@ -251,8 +595,8 @@ func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 				ctx:           context.Background(),
 				robotsTXTLink: ":",
 			},
-			wantRobotsTXTData: nil,
-			wantErr:           assert.Error,
+			wantResult: RobotsTXTResult{},
+			wantErr:    assert.Error,
 		},
 		{
 			name: "error with request sending",
@@ -272,8 +616,48 @@ func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 				ctx:           context.Background(),
 				robotsTXTLink: "http://example.com/robots.txt",
 			},
-			wantRobotsTXTData: nil,
-			wantErr:           assert.Error,
+			wantResult: RobotsTXTResult{},
+			wantErr:    assert.Error,
+		},
+		{
+			name: "error with a too large response",
+			fields: fields{
+				httpClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/robots.txt", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(
+							"User-agent: *\nDisallow: /\nAllow: /post/\n",
+						)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				sizeLimit: ioutils.SizeLimit{MaximalSize: 26},
+			},
+			args: args{
+				ctx:           context.Background(),
+				robotsTXTLink: "http://example.com/robots.txt",
+			},
+			wantResult: RobotsTXTResult{},
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				return assert.Equal(
+					test,
+					ioutils.TooLargeError{MaximalSize: 26},
+					errors.Cause(err),
+					msgAndArgs...,
+				)
+			},
 		},
 		{
 			name: "error with response parsing",
@@ -305,19 +689,20 @@ func TestRobotsTXTRegister_loadRobotsTXTData(test *testing.T) {
 				ctx:           context.Background(),
 				robotsTXTLink: "http://example.com/robots.txt",
 			},
-			wantRobotsTXTData: nil,
-			wantErr:           assert.Error,
+			wantResult: RobotsTXTResult{},
+			wantErr:    assert.Error,
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			register := RobotsTXTRegister{
 				httpClient: data.fields.httpClient,
+				sizeLimit:  data.fields.sizeLimit,
 			}
-			gotRobotsTXTData, gotErr :=
+			gotResult, gotErr :=
 				register.loadRobotsTXTData(data.args.ctx, data.args.robotsTXTLink)
 
 			mock.AssertExpectationsForObjects(test, data.fields.httpClient)
-			assert.Equal(test, data.wantRobotsTXTData, gotRobotsTXTData)
+			assert.Equal(test, data.wantResult, gotResult)
 			data.wantErr(test, gotErr)
 		})
 	}
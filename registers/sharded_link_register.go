@@ -0,0 +1,73 @@
+package registers
+
+import (
+	"hash/fnv"
+	"sync"
+
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+type linkShard struct {
+	locker          sync.Mutex
+	registeredLinks map[string]struct{}
+}
+
+// ShardedLinkRegister ...
+//
+// It splits the registered links into the shards by their hashes,
+// so the concurrent registering of different links rarely contends.
+type ShardedLinkRegister struct {
+	sanitizeLink urlutils.LinkSanitizer
+
+	shards []*linkShard
+}
+
+// NewShardedLinkRegister ...
+//
+// The shard count less than one is treated as one.
+func NewShardedLinkRegister(
+	sanitizeLink urlutils.LinkSanitizer,
+	shardCount int,
+) ShardedLinkRegister {
+	if shardCount < 1 {
+		shardCount = 1
+	}
+
+	var shards []*linkShard
+	for index := 0; index < shardCount; index++ {
+		shard := &linkShard{registeredLinks: make(map[string]struct{})}
+		shards = append(shards, shard)
+	}
+
+	return ShardedLinkRegister{
+		sanitizeLink: sanitizeLink,
+
+		shards: shards,
+	}
+}
+
+// RegisterLink ...
+func (register ShardedLinkRegister) RegisterLink(link string) (
+	wasRegistered bool,
+	err error,
+) {
+	link, err = sanitizeLink(register.sanitizeLink, link)
+	if err != nil {
+		return false, err
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(link)) // nolint: errcheck, gosec
+
+	shardIndex := int(hash.Sum32() % uint32(len(register.shards)))
+	shard := register.shards[shardIndex]
+	shard.locker.Lock()
+	defer shard.locker.Unlock()
+
+	if _, ok := shard.registeredLinks[link]; ok {
+		return false, nil
+	}
+
+	shard.registeredLinks[link] = struct{}{}
+	return true, nil
+}
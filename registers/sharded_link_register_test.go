@@ -0,0 +1,54 @@
+package registers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+func TestNewShardedLinkRegister(test *testing.T) {
+	for _, data := range []struct {
+		name           string
+		shardCount     int
+		wantShardCount int
+	}{
+		{
+			name:           "with the positive shard count",
+			shardCount:     23,
+			wantShardCount: 23,
+		},
+		{
+			name:           "with the non-positive shard count",
+			shardCount:     0,
+			wantShardCount: 1,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := NewShardedLinkRegister(urlutils.SanitizeLink, data.shardCount)
+
+			assert.Equal(test, urlutils.SanitizeLink, got.sanitizeLink)
+			assert.Len(test, got.shards, data.wantShardCount)
+		})
+	}
+}
+
+func TestShardedLinkRegister_RegisterLink(test *testing.T) {
+	register := NewShardedLinkRegister(urlutils.SanitizeLink, 23)
+	for _, data := range []struct {
+		link              string
+		wantWasRegistered assert.BoolAssertionFunc
+		wantErr           assert.ErrorAssertionFunc
+	}{
+		{"http://example.com/1", assert.True, assert.NoError},
+		{"http://example.com/2", assert.True, assert.NoError},
+		{"http://example.com/1", assert.False, assert.NoError},
+		{"http://example.com/test/../2", assert.False, assert.NoError},
+		{":", assert.False, assert.Error},
+	} {
+		wasRegistered, err := register.RegisterLink(data.link)
+
+		data.wantWasRegistered(test, wasRegistered, data.link)
+		data.wantErr(test, err, data.link)
+	}
+}
@@ -9,7 +9,7 @@ import (
 
 // HierarchicalGenerator ...
 type HierarchicalGenerator struct {
-	SanitizeLink urlutils.LinkSanitizing
+	SanitizeLink urlutils.LinkSanitizer // optional
 	MaximalDepth int
 }
 
@@ -3,16 +3,19 @@ package sitemap
 import (
 	"compress/gzip"
 	"context"
-	"io/ioutil"
 	"net/http"
 
 	"github.com/pkg/errors"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
 	httputils "github.com/thewizardplusplus/go-http-utils"
 )
 
 // Loader ...
 type Loader struct {
 	HTTPClient httputils.HTTPClient
+	// a zero value means no limit of the response size;
+	// the limit is applied to the decompressed data
+	SizeLimit ioutils.SizeLimit
 }
 
 // LoadLink ...
@@ -43,7 +46,7 @@ func (loader Loader) LoadLink(link string, options interface{}) (
 		defer responseReader.Close() // nolint: errcheck
 	}
 
-	responseData, err := ioutil.ReadAll(responseReader)
+	responseData, err := ioutils.ReadAll(responseReader, loader.SizeLimit)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to read the response")
 	}
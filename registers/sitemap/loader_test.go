@@ -10,14 +10,17 @@ import (
 	"testing"
 	"testing/iotest"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	ioutils "github.com/thewizardplusplus/go-crawler/io-utils"
 	httputils "github.com/thewizardplusplus/go-http-utils"
 )
 
 func TestLoader_LoadLink(test *testing.T) {
 	type fields struct {
 		HTTPClient httputils.HTTPClient
+		SizeLimit  ioutils.SizeLimit
 	}
 	type args struct {
 		link    string
@@ -115,6 +118,39 @@ func TestLoader_LoadLink(test *testing.T) {
 			),
 			wantErr: assert.NoError,
 		},
+		{
+			name: "success with truncating of the response",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/sitemap.xml", nil)
+					request = request.WithContext(context.Background())
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Body: ioutil.NopCloser(strings.NewReader(
+							`<?xml version="1.0" encoding="UTF-8" ?>` +
+								`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+								"<url><loc>http://example.com/1</loc></url>" +
+								"<url><loc>http://example.com/2</loc></url>" +
+								"</urlset>",
+						)),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				SizeLimit: ioutils.SizeLimit{MaximalSize: 39, Truncate: true},
+			},
+			args: args{
+				link:    "http://example.com/sitemap.xml",
+				options: context.Background(),
+			},
+			wantResponseData: []byte(`<?xml version="1.0" encoding="UTF-8" ?>`),
+			wantErr:          assert.NoError,
+		},
 		{
 			name: "error with the request creating",
 			fields: fields{
@@ -213,10 +249,59 @@ func TestLoader_LoadLink(test *testing.T) {
 			wantResponseData: nil,
 			wantErr:          assert.Error,
 		},
+		{
+			name: "error with a too large decompressed response",
+			fields: fields{
+				HTTPClient: func() httputils.HTTPClient {
+					request, _ :=
+						http.NewRequest(http.MethodGet, "http://example.com/sitemap.xml", nil)
+					request = request.WithContext(context.Background())
+
+					var buffer bytes.Buffer
+					compressingWriter := gzip.NewWriter(&buffer)
+					_, err := compressingWriter.Write([]byte(
+						`<?xml version="1.0" encoding="UTF-8" ?>` +
+							`<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">` +
+							"<url><loc>http://example.com/1</loc></url>" +
+							"<url><loc>http://example.com/2</loc></url>" +
+							"</urlset>",
+					))
+					require.NoError(test, err)
+					err = compressingWriter.Close()
+					require.NoError(test, err)
+
+					response := &http.Response{
+						StatusCode: http.StatusOK,
+						Header:     http.Header{"Content-Encoding": {"gzip"}},
+						Body:       ioutil.NopCloser(&buffer),
+					}
+
+					httpClient := new(MockHTTPClient)
+					httpClient.On("Do", request).Return(response, nil)
+
+					return httpClient
+				}(),
+				SizeLimit: ioutils.SizeLimit{MaximalSize: 39},
+			},
+			args: args{
+				link:    "http://example.com/sitemap.xml",
+				options: context.Background(),
+			},
+			wantResponseData: nil,
+			wantErr: func(
+				test assert.TestingT,
+				err error,
+				msgAndArgs ...interface{},
+			) bool {
+				wantErr := ioutils.TooLargeError{MaximalSize: 39}
+				return assert.Equal(test, wantErr, errors.Cause(err), msgAndArgs...)
+			},
+		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
 			loader := Loader{
 				HTTPClient: data.fields.HTTPClient,
+				SizeLimit:  data.fields.SizeLimit,
 			}
 			gotResponseData, gotErr := loader.LoadLink(data.args.link, data.args.options)
 
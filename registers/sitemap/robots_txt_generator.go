@@ -21,11 +21,10 @@ func (generator RobotsTXTGenerator) ExtractLinks(
 	[]string,
 	error,
 ) {
-	robotsTXTData, err :=
-		generator.RobotsTXTRegister.RegisterRobotsTXT(ctx, baseLink)
+	ruleSet, err := generator.RobotsTXTRegister.RegisterRobotsTXT(ctx, baseLink)
 	if err != nil {
 		return nil, errors.Wrap(err, "unable to register the robots.txt link")
 	}
 
-	return robotsTXTData.Sitemaps, nil
+	return ruleSet.Sitemaps, nil
 }
@@ -0,0 +1,157 @@
+package registers
+
+import (
+	"encoding/xml"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+// SitemapExtensionLink ...
+type SitemapExtensionLink struct {
+	Kind models.SitemapLinkKind
+	Link string
+	// it's set only for the alternate links
+	Language string
+}
+
+// SitemapExtensions ...
+type SitemapExtensions struct {
+	Links []SitemapExtensionLink
+	// zero if the news extension isn't used
+	News models.SitemapNews
+}
+
+type extendedURLSet struct {
+	URLs []extendedURL `xml:"url"`
+}
+
+type extendedURL struct {
+	Loc    string `xml:"loc"`
+	Images []struct {
+		Loc string `xml:"http://www.google.com/schemas/sitemap-image/1.1 loc"`
+	} `xml:"http://www.google.com/schemas/sitemap-image/1.1 image"`
+	Videos []struct {
+		ThumbnailLoc string `xml:"http://www.google.com/schemas/sitemap-video/1.1 thumbnail_loc"` // nolint: lll
+		ContentLoc   string `xml:"http://www.google.com/schemas/sitemap-video/1.1 content_loc"`   // nolint: lll
+		PlayerLoc    string `xml:"http://www.google.com/schemas/sitemap-video/1.1 player_loc"`    // nolint: lll
+	} `xml:"http://www.google.com/schemas/sitemap-video/1.1 video"`
+	News []struct {
+		Publication struct {
+			Name     string `xml:"http://www.google.com/schemas/sitemap-news/0.9 name"`
+			Language string `xml:"http://www.google.com/schemas/sitemap-news/0.9 language"` // nolint: lll
+		} `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication"`
+		PublicationDate string `xml:"http://www.google.com/schemas/sitemap-news/0.9 publication_date"` // nolint: lll
+		Title           string `xml:"http://www.google.com/schemas/sitemap-news/0.9 title"`            // nolint: lll
+	} `xml:"http://www.google.com/schemas/sitemap-news/0.9 news"`
+	AlternateLinks []struct {
+		Rel      string `xml:"rel,attr"`
+		Language string `xml:"hreflang,attr"`
+		Href     string `xml:"href,attr"`
+	} `xml:"http://www.w3.org/1999/xhtml link"`
+}
+
+// ParseSitemapTime ...
+//
+// It supports the formats of the W3C Datetime specification,
+// as it's required by the sitemap.xml protocol and its extensions.
+func ParseSitemapTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{
+		time.RFC3339Nano,
+		"2006-01-02T15:04Z07:00",
+		"2006-01-02",
+		"2006-01",
+		"2006",
+	} {
+		parsedValue, err := time.Parse(layout, value)
+		if err == nil {
+			return parsedValue, nil
+		}
+	}
+
+	return time.Time{}, errors.Errorf("unsupported time format of %q", value)
+}
+
+// ParseSitemapExtensions ...
+//
+// It supports the image, video, news and xhtml:link (hreflang) extensions.
+// The result is grouped by the page links; the pages without extensions
+// are skipped. For a Sitemap index file, the result is empty.
+func ParseSitemapExtensions(data []byte) (map[string]SitemapExtensions, error) {
+	var urlSet extendedURLSet
+	if err := xml.Unmarshal(data, &urlSet); err != nil {
+		return nil, errors.Wrap(err, "unable to parse the sitemap.xml file")
+	}
+
+	extensionsByPages := make(map[string]SitemapExtensions)
+	for _, url := range urlSet.URLs {
+		pageLink := strings.TrimSpace(url.Loc)
+		if pageLink == "" {
+			continue
+		}
+
+		extensions := makeSitemapExtensions(url)
+		if len(extensions.Links) == 0 && extensions.News == (models.SitemapNews{}) {
+			continue
+		}
+
+		extensionsByPages[pageLink] = extensions
+	}
+
+	return extensionsByPages, nil
+}
+
+func makeSitemapExtensions(url extendedURL) SitemapExtensions {
+	var extensions SitemapExtensions
+	addLink := func(kind models.SitemapLinkKind, link string, language string) {
+		link = strings.TrimSpace(link)
+		if link == "" {
+			return
+		}
+
+		extensions.Links = append(extensions.Links, SitemapExtensionLink{
+			Kind:     kind,
+			Link:     link,
+			Language: language,
+		})
+	}
+
+	for _, image := range url.Images {
+		addLink(models.SitemapImageLink, image.Loc, "")
+	}
+	for _, video := range url.Videos {
+		addLink(models.SitemapImageLink, video.ThumbnailLoc, "")
+		addLink(models.SitemapVideoLink, video.ContentLoc, "")
+		addLink(models.SitemapVideoLink, video.PlayerLoc, "")
+	}
+	for _, alternateLink := range url.AlternateLinks {
+		if alternateLink.Rel != "alternate" {
+			continue
+		}
+
+		language := strings.TrimSpace(alternateLink.Language)
+		addLink(models.SitemapAlternateLink, alternateLink.Href, language)
+	}
+
+	// the protocol allows only one news entry per page
+	if len(url.News) != 0 {
+		news := url.News[0]
+		// an invalid publication date is treated as an unspecified one
+		publicationDate, _ := ParseSitemapTime(news.PublicationDate) // nolint: gosec
+		extensions.News = models.SitemapNews{
+			PublicationName:     strings.TrimSpace(news.Publication.Name),
+			PublicationLanguage: strings.TrimSpace(news.Publication.Language),
+			Title:               strings.TrimSpace(news.Title),
+			PublicationDate:     publicationDate,
+		}
+	}
+
+	return extensions
+}
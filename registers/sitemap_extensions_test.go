@@ -0,0 +1,218 @@
+package registers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thewizardplusplus/go-crawler/models"
+)
+
+func TestParseSitemapTime(test *testing.T) {
+	type args struct {
+		value string
+	}
+
+	for _, data := range []struct {
+		name     string
+		args     args
+		wantTime time.Time
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "success with an empty value",
+			args:     args{value: ""},
+			wantTime: time.Time{},
+			wantErr:  assert.NoError,
+		},
+		{
+			name: "success with a complete date plus time",
+			args: args{value: "2006-01-02T15:04:05.5+07:00"},
+			wantTime: time.Date(
+				2006, time.January, 2, 15, 4, 5, 5e8,
+				time.FixedZone("", 7*60*60),
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with a complete date plus hours and minutes",
+			args: args{value: "2006-01-02T15:04Z"},
+			wantTime: time.Date(
+				2006, time.January, 2, 15, 4, 0, 0,
+				time.UTC,
+			),
+			wantErr: assert.NoError,
+		},
+		{
+			name:     "success with a complete date",
+			args:     args{value: " 2006-01-02 "},
+			wantTime: time.Date(2006, time.January, 2, 0, 0, 0, 0, time.UTC),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "success with a year and month",
+			args:     args{value: "2006-01"},
+			wantTime: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "success with a year",
+			args:     args{value: "2006"},
+			wantTime: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "error",
+			args:     args{value: "incorrect"},
+			wantTime: time.Time{},
+			wantErr:  assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotTime, gotErr := ParseSitemapTime(data.args.value)
+
+			assert.True(
+				test,
+				data.wantTime.Equal(gotTime),
+				"want %s, got %s",
+				data.wantTime,
+				gotTime,
+			)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestParseSitemapExtensions(test *testing.T) {
+	type args struct {
+		data []byte
+	}
+
+	for _, data := range []struct {
+		name                  string
+		args                  args
+		wantExtensionsByPages map[string]SitemapExtensions
+		wantErr               assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with extensions",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<urlset
+						xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+						xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"
+						xmlns:video="http://www.google.com/schemas/sitemap-video/1.1"
+						xmlns:news="http://www.google.com/schemas/sitemap-news/0.9"
+						xmlns:xhtml="http://www.w3.org/1999/xhtml"
+					>
+						<url>
+							<loc>http://example.com/1</loc>
+							<image:image>
+								<image:loc>http://example.com/1.png</image:loc>
+							</image:image>
+							<video:video>
+								<video:thumbnail_loc>http://example.com/1.jpg</video:thumbnail_loc>
+								<video:content_loc>http://example.com/1.mp4</video:content_loc>
+								<video:player_loc>http://example.com/player?1</video:player_loc>
+							</video:video>
+						</url>
+						<url>
+							<loc>http://example.com/2</loc>
+							<xhtml:link rel="alternate" hreflang="de" href="http://example.com/de/2" />
+							<xhtml:link rel="canonical" href="http://example.com/2" />
+							<news:news>
+								<news:publication>
+									<news:name>Example</news:name>
+									<news:language>en</news:language>
+								</news:publication>
+								<news:publication_date>2006-01-02</news:publication_date>
+								<news:title>Test</news:title>
+							</news:news>
+						</url>
+						<url>
+							<loc>http://example.com/3</loc>
+						</url>
+					</urlset>
+				`),
+			},
+			wantExtensionsByPages: map[string]SitemapExtensions{
+				"http://example.com/1": {
+					Links: []SitemapExtensionLink{
+						{Kind: models.SitemapImageLink, Link: "http://example.com/1.png"},
+						{Kind: models.SitemapImageLink, Link: "http://example.com/1.jpg"},
+						{Kind: models.SitemapVideoLink, Link: "http://example.com/1.mp4"},
+						{Kind: models.SitemapVideoLink, Link: "http://example.com/player?1"},
+					},
+				},
+				"http://example.com/2": {
+					Links: []SitemapExtensionLink{
+						{
+							Kind:     models.SitemapAlternateLink,
+							Link:     "http://example.com/de/2",
+							Language: "de",
+						},
+					},
+					News: models.SitemapNews{
+						PublicationName:     "Example",
+						PublicationLanguage: "en",
+						Title:               "Test",
+						PublicationDate: time.Date(
+							2006, time.January, 2, 0, 0, 0, 0,
+							time.UTC,
+						),
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with elements from the unknown namespaces",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+						<url>
+							<loc>http://example.com/1</loc>
+							<image>
+								<loc>http://example.com/1.png</loc>
+							</image>
+						</url>
+					</urlset>
+				`),
+			},
+			wantExtensionsByPages: map[string]SitemapExtensions{},
+			wantErr:               assert.NoError,
+		},
+		{
+			name: "success with a Sitemap index",
+			args: args{
+				data: []byte(`
+					<?xml version="1.0" encoding="UTF-8" ?>
+					<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+						<sitemap>
+							<loc>http://example.com/sitemap_1.xml</loc>
+						</sitemap>
+					</sitemapindex>
+				`),
+			},
+			wantExtensionsByPages: map[string]SitemapExtensions{},
+			wantErr:               assert.NoError,
+		},
+		{
+			name: "error",
+			args: args{
+				data: []byte("<urlset"),
+			},
+			wantExtensionsByPages: nil,
+			wantErr:               assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotExtensionsByPages, gotErr := ParseSitemapExtensions(data.args.data)
+
+			assert.Equal(test, data.wantExtensionsByPages, gotExtensionsByPages)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
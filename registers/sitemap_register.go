@@ -2,6 +2,8 @@ package registers
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
 	"sync"
 	"time"
 
@@ -13,30 +15,47 @@ import (
 
 // SitemapRegister ...
 type SitemapRegister struct {
-	linkGenerator models.LinkExtractor
-	logger        log.Logger
-
-	sitemapRegister BasicRegister
+	loadingInterval time.Duration
+	linkGenerator   models.LinkExtractor
+	logger          log.Logger
+	// it parses the sitemap.xml extensions of the loaded data
+	linkLoader func(link string, options interface{}) ([]byte, error)
+
+	sitemapRegister   BasicRegister
+	extensionRegister BasicRegister
 }
 
 // NewSitemapRegister ...
+//
+// The link loader receives a context as the options, so the sitemap.Loader
+// from the registers/sitemap package may be used for it. If it's nil,
+// the http.DefaultClient is used without a size limit. Unlike the global
+// loader of the sitemap package, the link loader and the loading interval
+// are used only by this register. The sitemap.xml extensions are parsed
+// with any link loader.
 func NewSitemapRegister(
 	loadingInterval time.Duration,
 	linkGenerator models.LinkExtractor,
 	logger log.Logger,
 	linkLoader func(link string, options interface{}) ([]byte, error),
+	options ...SitemapRegisterOption,
 ) SitemapRegister {
-	sitemap.SetInterval(loadingInterval)
-	if linkLoader != nil {
-		sitemap.SetFetch(linkLoader)
+	config := newSitemapRegisterConfig(options)
+	register := SitemapRegister{
+		loadingInterval: loadingInterval,
+		linkGenerator:   linkGenerator,
+		logger:          logger,
+
+		sitemapRegister:   NewBasicRegister(config.cacheOptions...),
+		extensionRegister: NewBasicRegister(config.extensionCacheOptions...),
 	}
 
-	return SitemapRegister{
-		linkGenerator: linkGenerator,
-		logger:        logger,
-
-		sitemapRegister: NewBasicRegister(),
+	if linkLoader == nil {
+		linkLoader = loadSitemapLink
 	}
+	register.linkLoader = register.wrapLinkLoader(linkLoader)
+
+	return register
 }
 
 // RegisterSitemap ...
@@ -76,6 +95,45 @@ func (register SitemapRegister) RegisterSitemap(
 	return totalSitemapData, nil
 }
 
+// LookupExtensions ...
+//
+// It returns the sitemap.xml extensions of the specified page link
+// from the already loaded sitemap.xml files.
+func (register SitemapRegister) LookupExtensions(pageLink string) (
+	extensions SitemapExtensions,
+	ok bool,
+) {
+	value, ok := register.extensionRegister.loadValue(pageLink)
+	if !ok {
+		return SitemapExtensions{}, false
+	}
+
+	return value.(SitemapExtensions), true
+}
+
+func (register SitemapRegister) wrapLinkLoader(
+	linkLoader func(link string, options interface{}) ([]byte, error),
+) func(link string, options interface{}) ([]byte, error) {
+	return func(link string, options interface{}) ([]byte, error) {
+		data, err := linkLoader(link, options)
+		if err != nil {
+			return nil, err
+		}
+
+		extensionsByPages, err := ParseSitemapExtensions(data)
+		if err != nil {
+			// the error will be processed on parsing of the main data
+			return data, nil
+		}
+
+		for pageLink, extensions := range extensionsByPages {
+			register.extensionRegister.storeValue(pageLink, extensions)
+		}
+
+		return data, nil
+	}
+}
+
 func (register SitemapRegister) loadSitemapData(
 	ctx context.Context,
 	sitemapLink string,
@@ -84,7 +142,7 @@ func (register SitemapRegister) loadSitemapData(
 		ctx,
 		sitemapLink,
 		func(ctx context.Context, sitemapLink interface{}) (interface{}, error) {
-			sitemapData, err := sitemap.Get(sitemapLink.(string), ctx)
+			sitemapData, err := register.getSitemap(ctx, sitemapLink.(string))
 			if err != nil {
 				register.logger.Logf("unable to load Sitemap link %q: %s", sitemapLink, err)
 			}
@@ -95,3 +153,77 @@ func (register SitemapRegister) loadSitemapData(
 
 	return sitemapData.(sitemap.Sitemap)
 }
+
+// it's based on the sitemap.Get() function, but uses the link loader
+// and the loading interval of the register; like that function,
+// it returns the already loaded data together with an error
+func (register SitemapRegister) getSitemap(
+	ctx context.Context,
+	sitemapLink string,
+) (
+	sitemap.Sitemap,
+	error,
+) {
+	data, err := register.linkLoader(sitemapLink, ctx)
+	if err != nil {
+		return sitemap.Sitemap{}, errors.Wrap(err, "unable to load the data")
+	}
+
+	sitemapIndex, indexErr := sitemap.ParseIndex(data)
+	sitemapData, err := sitemap.Parse(data)
+	if indexErr != nil && err != nil {
+		return sitemap.Sitemap{}, errors.Wrap(indexErr, "unable to parse the data")
+	}
+	if indexErr != nil || len(sitemapIndex.Sitemap) == 0 {
+		return sitemapData, nil
+	}
+
+	for _, part := range sitemapIndex.Sitemap {
+		select {
+		case <-time.After(register.loadingInterval):
+		case <-ctx.Done():
+			return sitemapData, errors.Wrap(ctx.Err(), "unable to wait")
+		}
+
+		partData, err := register.linkLoader(part.Loc, ctx)
+		if err != nil {
+			return sitemapData,
+				errors.Wrapf(err, "unable to load the part %q", part.Loc)
+		}
+
+		partSitemapData, err := sitemap.Parse(partData)
+		if err != nil {
+			return sitemapData,
+				errors.Wrapf(err, "unable to parse the part %q", part.Loc)
+		}
+
+		sitemapData.URL = append(sitemapData.URL, partSitemapData.URL...)
+	}
+
+	return sitemapData, nil
+}
+
+// it's similar to the default link loader of the sitemap package,
+// but it takes into account the context passed as the options
+func loadSitemapLink(link string, options interface{}) ([]byte, error) {
+	request, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to create the request")
+	}
+	if ctx, ok := options.(context.Context); ok {
+		request = request.WithContext(ctx)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to send the request")
+	}
+	defer response.Body.Close() // nolint: errcheck
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read the response")
+	}
+
+	return data, nil
+}
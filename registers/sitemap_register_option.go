@@ -0,0 +1,62 @@
+package registers
+
+// the limit of the URLs in a single sitemap.xml file by the protocol
+const maximalSitemapLinkCount = 50000
+
+// SitemapRegisterConfig ...
+type SitemapRegisterConfig struct {
+	cacheOptions          []BasicRegisterOption
+	extensionCacheOptions []BasicRegisterOption
+}
+
+// SitemapRegisterOption ...
+type SitemapRegisterOption func(config *SitemapRegisterConfig)
+
+// WithSitemapCacheOptions ...
+//
+// The options are applied to the cache of the sitemap.xml files.
+func WithSitemapCacheOptions(
+	options ...BasicRegisterOption,
+) SitemapRegisterOption {
+	return func(config *SitemapRegisterConfig) {
+		config.cacheOptions = append(config.cacheOptions, options...)
+	}
+}
+
+// WithSitemapExtensionCacheOptions ...
+//
+// The options are applied to the cache of the sitemap.xml extensions,
+// which is grouped by the page links. By default, it has the same TTL
+// as the cache of the sitemap.xml files and its capacity is scaled
+// by the maximal count of the page links in a single sitemap.xml file
+// (50,000 by the protocol), so it fits the extensions of all the cached
+// sitemap.xml files, unless they are the sitemap index files. The options
+// are applied after the default ones, so they override them.
+func WithSitemapExtensionCacheOptions(
+	options ...BasicRegisterOption,
+) SitemapRegisterOption {
+	return func(config *SitemapRegisterConfig) {
+		config.extensionCacheOptions =
+			append(config.extensionCacheOptions, options...)
+	}
+}
+
+func newSitemapRegisterConfig(
+	options []SitemapRegisterOption,
+) SitemapRegisterConfig {
+	var config SitemapRegisterConfig
+	for _, option := range options {
+		option(&config)
+	}
+
+	cacheConfig := newBasicRegisterConfig(config.cacheOptions)
+	config.extensionCacheOptions = append(
+		[]BasicRegisterOption{
+			WithTTL(cacheConfig.ttl),
+			WithCapacity(cacheConfig.capacity * maximalSitemapLinkCount),
+		},
+		config.extensionCacheOptions...,
+	)
+
+	return config
+}
@@ -0,0 +1,86 @@
+package registers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithSitemapCacheOptions(test *testing.T) {
+	var config SitemapRegisterConfig
+	option := WithSitemapCacheOptions(WithTTL(time.Hour), WithCapacity(23))
+	option(&config)
+
+	assert.Equal(
+		test,
+		BasicRegisterConfig{ttl: time.Hour, capacity: 23},
+		newBasicRegisterConfig(config.cacheOptions),
+	)
+}
+
+func TestWithSitemapExtensionCacheOptions(test *testing.T) {
+	var config SitemapRegisterConfig
+	option := WithSitemapExtensionCacheOptions(WithCapacity(23))
+	option(&config)
+
+	assert.Equal(
+		test,
+		BasicRegisterConfig{capacity: 23},
+		newBasicRegisterConfig(config.extensionCacheOptions),
+	)
+}
+
+func Test_newSitemapRegisterConfig(test *testing.T) {
+	for _, data := range []struct {
+		name                        string
+		options                     []SitemapRegisterOption
+		wantCacheConfig             BasicRegisterConfig
+		wantExtensionRegisterConfig BasicRegisterConfig
+	}{
+		{
+			name:                        "without options",
+			options:                     nil,
+			wantCacheConfig:             BasicRegisterConfig{},
+			wantExtensionRegisterConfig: BasicRegisterConfig{},
+		},
+		{
+			name: "with the cache options",
+			options: []SitemapRegisterOption{
+				WithSitemapCacheOptions(WithTTL(time.Hour), WithCapacity(23)),
+			},
+			wantCacheConfig: BasicRegisterConfig{ttl: time.Hour, capacity: 23},
+			wantExtensionRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 23 * maximalSitemapLinkCount,
+			},
+		},
+		{
+			name: "with the extension cache options",
+			options: []SitemapRegisterOption{
+				WithSitemapExtensionCacheOptions(WithTTL(time.Minute)),
+				WithSitemapCacheOptions(WithTTL(time.Hour), WithCapacity(23)),
+			},
+			wantCacheConfig: BasicRegisterConfig{ttl: time.Hour, capacity: 23},
+			wantExtensionRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Minute,
+				capacity: 23 * maximalSitemapLinkCount,
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := newSitemapRegisterConfig(data.options)
+
+			assert.Equal(
+				test,
+				data.wantCacheConfig,
+				newBasicRegisterConfig(got.cacheOptions),
+			)
+			assert.Equal(
+				test,
+				data.wantExtensionRegisterConfig,
+				newBasicRegisterConfig(got.extensionCacheOptions),
+			)
+		})
+	}
+}
@@ -3,6 +3,9 @@ package registers
 import (
 	"context"
 	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"sync"
 	"testing"
@@ -42,7 +45,7 @@ func TestNewSitemapRegister(test *testing.T) {
 			},
 			wantLinkGenerator:   new(MockLinkExtractor),
 			wantLogger:          new(MockLogger),
-			wantSitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+			wantSitemapRegister: NewBasicRegister(),
 		},
 		{
 			name: "without a link loader",
@@ -54,7 +57,7 @@ func TestNewSitemapRegister(test *testing.T) {
 			},
 			wantLinkGenerator:   new(MockLinkExtractor),
 			wantLogger:          new(MockLogger),
-			wantSitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+			wantSitemapRegister: NewBasicRegister(),
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
@@ -77,9 +80,84 @@ func TestNewSitemapRegister(test *testing.T) {
 			if data.args.linkLoader != nil {
 				mock.AssertExpectationsForObjects(test, data.args.linkLoader)
 			}
+			assert.Equal(test, data.args.loadingInterval, register.loadingInterval)
 			assert.Equal(test, data.wantLinkGenerator, register.linkGenerator)
 			assert.Equal(test, data.wantLogger, register.logger)
+			assert.NotNil(test, register.linkLoader)
 			assert.Equal(test, data.wantSitemapRegister, register.sitemapRegister)
+			assert.Equal(
+				test,
+				NewBasicRegister(),
+				register.extensionRegister,
+			)
+		})
+	}
+}
+
+func TestNewSitemapRegister_withOptions(test *testing.T) {
+	for _, data := range []struct {
+		name                        string
+		options                     []SitemapRegisterOption
+		wantSitemapRegisterConfig   BasicRegisterConfig
+		wantExtensionRegisterConfig BasicRegisterConfig
+	}{
+		{
+			name: "with the cache options",
+			options: []SitemapRegisterOption{
+				WithSitemapCacheOptions(WithTTL(time.Hour), WithCapacity(23)),
+			},
+			wantSitemapRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 23,
+			},
+			wantExtensionRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 23 * maximalSitemapLinkCount,
+			},
+		},
+		{
+			name: "with the extension cache options",
+			options: []SitemapRegisterOption{
+				WithSitemapCacheOptions(WithTTL(time.Hour), WithCapacity(23)),
+				WithSitemapExtensionCacheOptions(WithCapacity(42)),
+			},
+			wantSitemapRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 23,
+			},
+			wantExtensionRegisterConfig: BasicRegisterConfig{
+				ttl:      time.Hour,
+				capacity: 42,
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			linkGenerator := new(MockLinkExtractor)
+			logger := new(MockLogger)
+			linkLoader := new(MockLinkLoader)
+			register := NewSitemapRegister(
+				5*time.Second,
+				linkGenerator,
+				logger,
+				linkLoader.LoadLink,
+				data.options...,
+			)
+
+			mock.AssertExpectationsForObjects(test, linkGenerator, logger, linkLoader)
+			if assert.NotNil(test, register.sitemapRegister.boundedValues) {
+				assert.Equal(
+					test,
+					data.wantSitemapRegisterConfig,
+					register.sitemapRegister.boundedValues.config,
+				)
+			}
+			if assert.NotNil(test, register.extensionRegister.boundedValues) {
+				assert.Equal(
+					test,
+					data.wantExtensionRegisterConfig,
+					register.extensionRegister.boundedValues.config,
+				)
+			}
 		})
 	}
 }
@@ -153,7 +231,10 @@ func TestSitemapRegister_RegisterSitemap(test *testing.T) {
 
 					return linkLoader
 				}(),
-				sitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+				sitemapRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:      context.Background(),
@@ -181,8 +262,11 @@ func TestSitemapRegister_RegisterSitemap(test *testing.T) {
 
 					return linkGenerator
 				}(),
-				linkLoader:      new(MockLinkLoader),
-				sitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+				linkLoader: new(MockLinkLoader),
+				sitemapRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:      context.Background(),
@@ -194,10 +278,9 @@ func TestSitemapRegister_RegisterSitemap(test *testing.T) {
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
-			sitemap.SetFetch(data.fields.linkLoader.LoadLink)
-
 			register := SitemapRegister{
 				linkGenerator:   data.fields.linkGenerator,
+				linkLoader:      data.fields.linkLoader.LoadLink,
 				sitemapRegister: data.fields.sitemapRegister,
 			}
 			gotSitemapData, gotErr :=
@@ -255,8 +338,11 @@ func TestSitemapRegister_loadSitemapData(test *testing.T) {
 
 					return linkLoader
 				}(),
-				logger:          new(MockLogger),
-				sitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+				logger: new(MockLogger),
+				sitemapRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:         context.Background(),
@@ -279,6 +365,7 @@ func TestSitemapRegister_loadSitemapData(test *testing.T) {
 				linkLoader: new(MockLinkLoader),
 				logger:     new(MockLogger),
 				sitemapRegister: BasicRegister{
+					registeringCalls: newCallGroup(),
 					registeredValues: func() *sync.Map {
 						sitemapData := sitemap.Sitemap{
 							XMLName: xml.Name{
@@ -340,7 +427,10 @@ func TestSitemapRegister_loadSitemapData(test *testing.T) {
 
 					return logger
 				}(),
-				sitemapRegister: BasicRegister{registeredValues: new(sync.Map)},
+				sitemapRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
 			},
 			args: args{
 				ctx:         context.Background(),
@@ -350,10 +440,9 @@ func TestSitemapRegister_loadSitemapData(test *testing.T) {
 		},
 	} {
 		test.Run(data.name, func(test *testing.T) {
-			sitemap.SetFetch(data.fields.linkLoader.LoadLink)
-
 			register := SitemapRegister{
 				logger:          data.fields.logger,
+				linkLoader:      data.fields.linkLoader.LoadLink,
 				sitemapRegister: data.fields.sitemapRegister,
 			}
 			gotSitemapData :=
@@ -364,3 +453,430 @@ func TestSitemapRegister_loadSitemapData(test *testing.T) {
 		})
 	}
 }
+
+func TestSitemapRegister_withExtensions(test *testing.T) {
+	linkGenerator := new(MockLinkExtractor)
+	linkGenerator.
+		On("ExtractLinks", context.Background(), 23, "http://example.com/test").
+		Return([]string{"http://example.com/sitemap.xml"}, nil)
+
+	const response = `
+		<?xml version="1.0" encoding="UTF-8" ?>
+		<urlset
+			xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"
+			xmlns:image="http://www.google.com/schemas/sitemap-image/1.1"
+		>
+			<url>
+				<loc>http://example.com/1</loc>
+				<image:image>
+					<image:loc>http://example.com/1.png</image:loc>
+				</image:image>
+			</url>
+			<url>
+				<loc>http://example.com/2</loc>
+			</url>
+		</urlset>
+	`
+
+	linkLoader := new(MockLinkLoader)
+	linkLoader.
+		On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+		Return([]byte(response), nil)
+
+	logger := new(MockLogger)
+	register :=
+		NewSitemapRegister(5*time.Second, linkGenerator, logger, linkLoader.LoadLink)
+	gotSitemapData, gotErr :=
+		register.RegisterSitemap(context.Background(), 23, "http://example.com/test")
+
+	mock.AssertExpectationsForObjects(test, linkGenerator, logger, linkLoader)
+	assert.Equal(test, []sitemap.URL{
+		{Loc: "http://example.com/1"},
+		{Loc: "http://example.com/2"},
+	}, gotSitemapData.URL)
+	assert.NoError(test, gotErr)
+
+	gotExtensions, gotOK := register.LookupExtensions("http://example.com/1")
+	assert.Equal(test, SitemapExtensions{
+		Links: []SitemapExtensionLink{
+			{Kind: models.SitemapImageLink, Link: "http://example.com/1.png"},
+		},
+	}, gotExtensions)
+	assert.True(test, gotOK)
+
+	gotExtensions, gotOK = register.LookupExtensions("http://example.com/2")
+	assert.Equal(test, SitemapExtensions{}, gotExtensions)
+	assert.False(test, gotOK)
+}
+
+func TestSitemapRegister_wrapLinkLoader(test *testing.T) {
+	for _, data := range []struct {
+		name           string
+		linkLoader     LinkLoader
+		wantData       []byte
+		wantExtensions map[string]SitemapExtensions
+		wantErr        assert.ErrorAssertionFunc
+	}{
+		{
+			name: "success with the correct data",
+			linkLoader: func() LinkLoader {
+				const response = `
+					<urlset xmlns:xhtml="http://www.w3.org/1999/xhtml">
+						<url>
+							<loc>http://example.com/1</loc>
+							<xhtml:link rel="alternate" hreflang="de" href="http://example.com/de/1" />
+						</url>
+					</urlset>
+				`
+
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte(response), nil)
+
+				return linkLoader
+			}(),
+			wantData: []byte(`
+					<urlset xmlns:xhtml="http://www.w3.org/1999/xhtml">
+						<url>
+							<loc>http://example.com/1</loc>
+							<xhtml:link rel="alternate" hreflang="de" href="http://example.com/de/1" />
+						</url>
+					</urlset>
+				`),
+			wantExtensions: map[string]SitemapExtensions{
+				"http://example.com/1": {
+					Links: []SitemapExtensionLink{
+						{
+							Kind:     models.SitemapAlternateLink,
+							Link:     "http://example.com/de/1",
+							Language: "de",
+						},
+					},
+				},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "success with the incorrect data",
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte("<urlset"), nil)
+
+				return linkLoader
+			}(),
+			wantData:       []byte("<urlset"),
+			wantExtensions: map[string]SitemapExtensions{},
+			wantErr:        assert.NoError,
+		},
+		{
+			name: "error",
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return(nil, iotest.ErrTimeout)
+
+				return linkLoader
+			}(),
+			wantData:       nil,
+			wantExtensions: map[string]SitemapExtensions{},
+			wantErr:        assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := SitemapRegister{
+				extensionRegister: BasicRegister{
+					registeredValues: new(sync.Map),
+					registeringCalls: newCallGroup(),
+				},
+			}
+			linkLoader := register.wrapLinkLoader(data.linkLoader.LoadLink)
+			gotData, gotErr :=
+				linkLoader("http://example.com/sitemap.xml", context.Background())
+
+			gotExtensions := make(map[string]SitemapExtensions)
+			register.extensionRegister.registeredValues.Range(
+				func(key interface{}, value interface{}) bool {
+					gotExtensions[key.(string)] = value.(SitemapExtensions)
+					return true
+				},
+			)
+
+			mock.AssertExpectationsForObjects(test, data.linkLoader)
+			assert.Equal(test, data.wantData, gotData)
+			assert.Equal(test, data.wantExtensions, gotExtensions)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestSitemapRegister_getSitemap(test *testing.T) {
+	const sitemapIndex = `
+		<?xml version="1.0" encoding="UTF-8" ?>
+		<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+			<sitemap>
+				<loc>http://example.com/sitemap_1.xml</loc>
+			</sitemap>
+			<sitemap>
+				<loc>http://example.com/sitemap_2.xml</loc>
+			</sitemap>
+		</sitemapindex>
+	`
+	const sitemapPartOne = `
+		<?xml version="1.0" encoding="UTF-8" ?>
+		<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+			<url>
+				<loc>http://example.com/1</loc>
+			</url>
+		</urlset>
+	`
+	const sitemapPartTwo = `
+		<?xml version="1.0" encoding="UTF-8" ?>
+		<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+			<url>
+				<loc>http://example.com/2</loc>
+			</url>
+		</urlset>
+	`
+
+	type args struct {
+		ctx         context.Context
+		sitemapLink string
+	}
+
+	for _, data := range []struct {
+		name            string
+		loadingInterval time.Duration
+		linkLoader      LinkLoader
+		args            args
+		wantURL         []sitemap.URL
+		wantErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name:            "success with a sitemap.xml file",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte(sitemapPartOne), nil)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: []sitemap.URL{{Loc: "http://example.com/1"}},
+			wantErr: assert.NoError,
+		},
+		{
+			name:            "success with a sitemap index file",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte(sitemapIndex), nil)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap_1.xml", context.Background()).
+					Return([]byte(sitemapPartOne), nil)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap_2.xml", context.Background()).
+					Return([]byte(sitemapPartTwo), nil)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: []sitemap.URL{
+				{Loc: "http://example.com/1"},
+				{Loc: "http://example.com/2"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:            "error with loading of the data",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return(nil, iotest.ErrTimeout)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:            "error with parsing of the data",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte("<urlset"), nil)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:            "error with loading of a part",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte(sitemapIndex), nil)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap_1.xml", context.Background()).
+					Return([]byte(sitemapPartOne), nil)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap_2.xml", context.Background()).
+					Return(nil, iotest.ErrTimeout)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: []sitemap.URL{{Loc: "http://example.com/1"}},
+			wantErr: assert.Error,
+		},
+		{
+			name:            "error with parsing of a part",
+			loadingInterval: time.Nanosecond,
+			linkLoader: func() LinkLoader {
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", context.Background()).
+					Return([]byte(sitemapIndex), nil)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap_1.xml", context.Background()).
+					Return([]byte("<urlset"), nil)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx:         context.Background(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: nil,
+			wantErr: assert.Error,
+		},
+		{
+			name:            "error with the context cancellation",
+			loadingInterval: time.Hour,
+			linkLoader: func() LinkLoader {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				linkLoader := new(MockLinkLoader)
+				linkLoader.
+					On("LoadLink", "http://example.com/sitemap.xml", ctx).
+					Return([]byte(sitemapIndex), nil)
+
+				return linkLoader
+			}(),
+			args: args{
+				ctx: func() context.Context {
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+
+					return ctx
+				}(),
+				sitemapLink: "http://example.com/sitemap.xml",
+			},
+			wantURL: nil,
+			wantErr: assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			register := SitemapRegister{
+				loadingInterval: data.loadingInterval,
+				linkLoader:      data.linkLoader.LoadLink,
+			}
+			gotSitemapData, gotErr :=
+				register.getSitemap(data.args.ctx, data.args.sitemapLink)
+
+			mock.AssertExpectationsForObjects(test, data.linkLoader)
+			assert.Equal(test, data.wantURL, gotSitemapData.URL)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func Test_loadSitemapLink(test *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(
+		func(writer http.ResponseWriter, request *http.Request) {
+			fmt.Fprint(writer, "<urlset />") // nolint: errcheck
+		},
+	))
+	defer server.Close()
+
+	for _, data := range []struct {
+		name     string
+		link     string
+		options  interface{}
+		wantData []byte
+		wantErr  assert.ErrorAssertionFunc
+	}{
+		{
+			name:     "success with a context",
+			link:     server.URL + "/sitemap.xml",
+			options:  context.Background(),
+			wantData: []byte("<urlset />"),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "success without a context",
+			link:     server.URL + "/sitemap.xml",
+			options:  nil,
+			wantData: []byte("<urlset />"),
+			wantErr:  assert.NoError,
+		},
+		{
+			name:     "error with request creating",
+			link:     ":",
+			options:  context.Background(),
+			wantData: nil,
+			wantErr:  assert.Error,
+		},
+		{
+			name: "error with request sending",
+			link: server.URL + "/sitemap.xml",
+			options: func() context.Context {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				return ctx
+			}(),
+			wantData: nil,
+			wantErr:  assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotData, gotErr := loadSitemapLink(data.link, data.options)
+
+			assert.Equal(test, data.wantData, gotData)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package robotstxtutils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// the examples are taken from RFC 9309
+// (https://www.rfc-editor.org/rfc/rfc9309.html)
+func TestRuleSet_conformance(test *testing.T) {
+	// RFC 9309, section 5.1
+	const simpleExample = `
+		User-Agent: *
+		Disallow: *.gif$
+		Disallow: /example/
+		Allow: /publications/
+
+		User-Agent: foobot
+		Disallow:/
+		Allow:/example/page.html
+		Allow:/example/allowed.gif
+
+		User-Agent: barbot
+		User-Agent: bazbot
+		Disallow: /example/page.html
+
+		User-Agent: quxbot
+
+		EOF
+	`
+	// RFC 9309, section 5.2
+	const longestMatchExample = `
+		User-Agent: foobot
+		Allow: /example/page/
+		Disallow: /example/page/disallowed.gif
+	`
+	// RFC 9309, section 2.2.3
+	const specialCharactersExample = `
+		User-Agent: *
+		Disallow: /this/path/exactly$ # a comment
+		Disallow: /that/*/exactly
+		Disallow: /search?q=
+	`
+	// RFC 9309, section 2.2.2
+	const percentEncodingExample = `
+		User-Agent: *
+		Disallow: /foo/bar?baz=quz
+		Disallow: /foo/bar?baz=https%3A%2F%2Ffoo.bar
+		Disallow: /foo/bar/ツ
+		Disallow: /foo/baz/%E3%83%84
+		Disallow: /foo/bar/%62%61%7A
+	`
+
+	for _, data := range []struct {
+		name      string
+		robotsTXT string
+		userAgent string
+		link      string
+		want      assert.BoolAssertionFunc
+	}{
+		// RFC 9309, section 5.1
+		{
+			name:      "simple example/common group/disallowed pattern",
+			robotsTXT: simpleExample,
+			userAgent: "otherbot",
+			link:      "http://example.com/images/image.gif",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/common group/pattern with a query",
+			robotsTXT: simpleExample,
+			userAgent: "otherbot",
+			link:      "http://example.com/images/image.gif?size=large",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/common group/disallowed path",
+			robotsTXT: simpleExample,
+			userAgent: "otherbot",
+			link:      "http://example.com/example/page.html",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/common group/allowed path",
+			robotsTXT: simpleExample,
+			userAgent: "otherbot",
+			link:      "http://example.com/publications/",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/common group/unmatched path",
+			robotsTXT: simpleExample,
+			userAgent: "otherbot",
+			link:      "http://example.com/other",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/own group/allowed path",
+			robotsTXT: simpleExample,
+			userAgent: "foobot",
+			link:      "http://example.com/example/page.html",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/own group/allowed pattern",
+			robotsTXT: simpleExample,
+			userAgent: "foobot",
+			link:      "http://example.com/example/allowed.gif",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/own group/disallowed path",
+			robotsTXT: simpleExample,
+			userAgent: "foobot",
+			link:      "http://example.com/publications/",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/own group/case-insensitive user agent",
+			robotsTXT: simpleExample,
+			userAgent: "FooBot/1.0",
+			link:      "http://example.com/other",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/group with several user agents/first one",
+			robotsTXT: simpleExample,
+			userAgent: "barbot",
+			link:      "http://example.com/example/page.html",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/group with several user agents/second one",
+			robotsTXT: simpleExample,
+			userAgent: "bazbot",
+			link:      "http://example.com/example/page.html",
+			want:      assert.False,
+		},
+		{
+			name:      "simple example/group with several user agents/other path",
+			robotsTXT: simpleExample,
+			userAgent: "bazbot",
+			link:      "http://example.com/example/image.gif",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/empty group",
+			robotsTXT: simpleExample,
+			userAgent: "quxbot",
+			link:      "http://example.com/example/image.gif",
+			want:      assert.True,
+		},
+		{
+			name:      "simple example/robots.txt file",
+			robotsTXT: simpleExample,
+			userAgent: "foobot",
+			link:      "http://example.com/robots.txt",
+			want:      assert.True,
+		},
+
+		// RFC 9309, section 5.2
+		{
+			name:      "longest match example/allowed path",
+			robotsTXT: longestMatchExample,
+			userAgent: "foobot",
+			link:      "http://example.com/example/page/",
+			want:      assert.True,
+		},
+		{
+			name:      "longest match example/disallowed path",
+			robotsTXT: longestMatchExample,
+			userAgent: "foobot",
+			link:      "http://example.com/example/page/disallowed.gif",
+			want:      assert.False,
+		},
+
+		// RFC 9309, section 2.2.3
+		{
+			name:      "special characters example/end of the path/exact path",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/this/path/exactly",
+			want:      assert.False,
+		},
+		{
+			name:      "special characters example/end of the path/longer path",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/this/path/exactly/more",
+			want:      assert.True,
+		},
+		{
+			name:      "special characters example/wildcard/one segment",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/that/path/exactly",
+			want:      assert.False,
+		},
+		{
+			name:      "special characters example/wildcard/several segments",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/that/long/path/exactly",
+			want:      assert.False,
+		},
+		{
+			name:      "special characters example/wildcard/unmatched path",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/that/exactly",
+			want:      assert.True,
+		},
+		{
+			name:      "special characters example/query/matched query",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/search?q=test",
+			want:      assert.False,
+		},
+		{
+			name:      "special characters example/query/unmatched query",
+			robotsTXT: specialCharactersExample,
+			userAgent: "foobot",
+			link:      "http://example.com/search?page=2",
+			want:      assert.True,
+		},
+
+		// RFC 9309, section 2.2.2
+		{
+			name:      "percent-encoding example/query",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/bar?baz=quz",
+			want:      assert.False,
+		},
+		{
+			name:      "percent-encoding example/encoded reserved characters",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/bar?baz=https%3a%2f%2ffoo.bar",
+			want:      assert.False,
+		},
+		{
+			name:      "percent-encoding example/non-encoded reserved characters",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/bar?baz=https://foo.bar",
+			want:      assert.True,
+		},
+		{
+			name:      "percent-encoding example/non-ASCII characters in the rule",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/bar/%E3%83%84",
+			want:      assert.False,
+		},
+		{
+			name:      "percent-encoding example/non-ASCII characters in the link",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/baz/ツ",
+			want:      assert.False,
+		},
+		{
+			name:      "percent-encoding example/encoded unreserved characters",
+			robotsTXT: percentEncodingExample,
+			userAgent: "foobot",
+			link:      "http://example.com/foo/bar/baz",
+			want:      assert.False,
+		},
+
+		// RFC 9309, section 2.2.2
+		{
+			name: "equivalent allow and disallow rules",
+			robotsTXT: `
+				User-Agent: *
+				Disallow: /page
+				Allow: /page
+			`,
+			userAgent: "foobot",
+			link:      "http://example.com/page",
+			want:      assert.True,
+		},
+		// RFC 9309, section 2.2.1
+		{
+			name: "combining of the groups",
+			robotsTXT: `
+				User-Agent: foobot
+				Disallow: /one
+
+				User-Agent: barbot
+				Disallow: /two
+
+				User-Agent: foobot
+				Disallow: /three
+			`,
+			userAgent: "foobot",
+			link:      "http://example.com/three",
+			want:      assert.False,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			parsedLink, err := url.Parse(data.link)
+			require.NoError(test, err)
+
+			ruleSet := ParseRuleSet([]byte(data.robotsTXT))
+			got := ruleSet.TestPath(data.userAgent, MakeMatchingPath(parsedLink))
+
+			data.want(test, got)
+		})
+	}
+}
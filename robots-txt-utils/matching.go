@@ -0,0 +1,123 @@
+package robotstxtutils
+
+import (
+	"net/url"
+	"strings"
+
+	urlutils "github.com/thewizardplusplus/go-crawler/url-utils"
+)
+
+const robotsTXTPath = "/robots.txt"
+
+// MakeMatchingPath ...
+//
+// It returns the path plus the query of the link,
+// as RFC 9309 requires for the matching.
+func MakeMatchingPath(link *url.URL) string {
+	path := link.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if link.RawQuery != "" || link.ForceQuery {
+		path += "?" + link.RawQuery
+	}
+
+	return path
+}
+
+// NormalizePath ...
+//
+// It percent-encodes the octets outside the US-ASCII range and normalizes
+// the percent-encoding via the urlutils.NormalizePercentEncoding() function,
+// so the paths from the robots.txt file and from the links become comparable.
+func NormalizePath(path string) string {
+	const hexDigits = "0123456789ABCDEF"
+
+	var encodedPath strings.Builder
+	encodedPath.Grow(len(path))
+	for index := 0; index < len(path); index++ {
+		symbol := path[index]
+		if symbol >= 0x80 {
+			encodedPath.WriteByte('%')
+			encodedPath.WriteByte(hexDigits[symbol>>4])
+			encodedPath.WriteByte(hexDigits[symbol&0x0f])
+
+			continue
+		}
+
+		encodedPath.WriteByte(symbol)
+	}
+
+	return urlutils.NormalizePercentEncoding(encodedPath.String())
+}
+
+// MatchRule ...
+//
+// The "*" character in the rule path designates zero or more instances
+// of any character; the "$" character at the end of the rule path designates
+// the end of the matched path. Otherwise, the rule path is matched
+// as a prefix of the path. The path should be normalized.
+func MatchRule(rule Rule, path string) bool {
+	pattern := rule.Path
+	if strings.HasSuffix(pattern, "$") {
+		pattern = strings.TrimSuffix(pattern, "$")
+	} else {
+		pattern += "*"
+	}
+
+	return matchWildcards(pattern, path)
+}
+
+// TestRules ...
+//
+// The most specific rule, i.e., the matched one with the longest path,
+// is used. If the equivalent allow and disallow rules are matched,
+// the allow one is used. If no rules are matched, the path is allowed.
+// The /robots.txt path is always allowed.
+func TestRules(rules []Rule, path string) bool {
+	path = NormalizePath(path)
+	if path == robotsTXTPath {
+		return true
+	}
+
+	isAllowed, matchedLength := true, -1
+	for _, rule := range rules {
+		if !MatchRule(rule, path) {
+			continue
+		}
+
+		length := len(rule.Path)
+		if length > matchedLength || (length == matchedLength && rule.Allow) {
+			isAllowed, matchedLength = rule.Allow, length
+		}
+	}
+
+	return isAllowed
+}
+
+// it's the classic wildcard matching with backtracking to the last star only
+func matchWildcards(pattern string, text string) bool {
+	patternIndex, textIndex := 0, 0
+	starIndex, starTextIndex := -1, 0
+	for textIndex < len(text) {
+		switch {
+		case patternIndex < len(pattern) && pattern[patternIndex] == '*':
+			starIndex, starTextIndex = patternIndex, textIndex
+			patternIndex++
+		case patternIndex < len(pattern) && pattern[patternIndex] == text[textIndex]:
+			patternIndex++
+			textIndex++
+		case starIndex != -1:
+			patternIndex = starIndex + 1
+			starTextIndex++
+			textIndex = starTextIndex
+		default:
+			return false
+		}
+	}
+	for patternIndex < len(pattern) && pattern[patternIndex] == '*' {
+		patternIndex++
+	}
+
+	return patternIndex == len(pattern)
+}
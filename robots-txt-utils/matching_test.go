@@ -0,0 +1,305 @@
+package robotstxtutils
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMakeMatchingPath(test *testing.T) {
+	type args struct {
+		link string
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "with an empty path",
+			args: args{
+				link: "http://example.com",
+			},
+			want: "/",
+		},
+		{
+			name: "with a path",
+			args: args{
+				link: "http://example.com/one/two",
+			},
+			want: "/one/two",
+		},
+		{
+			name: "with a query",
+			args: args{
+				link: "http://example.com/one/two?key=value#fragment",
+			},
+			want: "/one/two?key=value",
+		},
+		{
+			name: "with an empty query",
+			args: args{
+				link: "http://example.com/one/two?",
+			},
+			want: "/one/two?",
+		},
+		{
+			name: "with an encoded path",
+			args: args{
+				link: "http://example.com/one%2Ftwo",
+			},
+			want: "/one%2Ftwo",
+		},
+		{
+			name: "with non-ASCII characters",
+			args: args{
+				link: "http://example.com/one/ツ",
+			},
+			want: "/one/%E3%83%84",
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			parsedLink, err := url.Parse(data.args.link)
+			require.NoError(test, err)
+
+			got := MakeMatchingPath(parsedLink)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestNormalizePath(test *testing.T) {
+	type args struct {
+		path string
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without special characters",
+			args: args{
+				path: "/one/two?key=value",
+			},
+			want: "/one/two?key=value",
+		},
+		{
+			name: "with non-ASCII characters",
+			args: args{
+				path: "/one/ツ",
+			},
+			want: "/one/%E3%83%84",
+		},
+		{
+			name: "with encoded unreserved characters",
+			args: args{
+				path: "/%7eone/%62%61%7A",
+			},
+			want: "/~one/baz",
+		},
+		{
+			name: "with encoded reserved characters",
+			args: args{
+				path: "/one?key=https%3a%2F%2Fexample.com",
+			},
+			want: "/one?key=https%3A%2F%2Fexample.com",
+		},
+		{
+			name: "with incorrect encoding",
+			args: args{
+				path: "/one/%zz/%7",
+			},
+			want: "/one/%zz/%7",
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := NormalizePath(data.args.path)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestMatchRule(test *testing.T) {
+	type args struct {
+		rule Rule
+		path string
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want assert.BoolAssertionFunc
+	}{
+		{
+			name: "prefix/matched",
+			args: args{
+				rule: Rule{Path: "/one"},
+				path: "/one/two",
+			},
+			want: assert.True,
+		},
+		{
+			name: "prefix/unmatched",
+			args: args{
+				rule: Rule{Path: "/one"},
+				path: "/two/one",
+			},
+			want: assert.False,
+		},
+		{
+			name: "end of the path/matched",
+			args: args{
+				rule: Rule{Path: "/one$"},
+				path: "/one",
+			},
+			want: assert.True,
+		},
+		{
+			name: "end of the path/unmatched",
+			args: args{
+				rule: Rule{Path: "/one$"},
+				path: "/one/two",
+			},
+			want: assert.False,
+		},
+		{
+			name: "wildcard/matched",
+			args: args{
+				rule: Rule{Path: "/one/*/three"},
+				path: "/one/two/three/four",
+			},
+			want: assert.True,
+		},
+		{
+			name: "wildcard/matched with backtracking",
+			args: args{
+				rule: Rule{Path: "/*.php$"},
+				path: "/one.php/two.php",
+			},
+			want: assert.True,
+		},
+		{
+			name: "wildcard/unmatched",
+			args: args{
+				rule: Rule{Path: "/*.php$"},
+				path: "/one.php?key=value",
+			},
+			want: assert.False,
+		},
+		{
+			name: "wildcard/not at the start of the path",
+			args: args{
+				rule: Rule{Path: "/one*"},
+				path: "/two/one",
+			},
+			want: assert.False,
+		},
+		{
+			name: "several wildcards",
+			args: args{
+				rule: Rule{Path: "**/two**"},
+				path: "/one/two",
+			},
+			want: assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := MatchRule(data.args.rule, data.args.path)
+
+			data.want(test, got)
+		})
+	}
+}
+
+func TestTestRules(test *testing.T) {
+	type args struct {
+		rules []Rule
+		path  string
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want assert.BoolAssertionFunc
+	}{
+		{
+			name: "without rules",
+			args: args{
+				rules: nil,
+				path:  "/one",
+			},
+			want: assert.True,
+		},
+		{
+			name: "without matched rules",
+			args: args{
+				rules: []Rule{{Path: "/two", Allow: false}},
+				path:  "/one",
+			},
+			want: assert.True,
+		},
+		{
+			name: "with the longest disallow rule",
+			args: args{
+				rules: []Rule{
+					{Path: "/one/two", Allow: false},
+					{Path: "/one", Allow: true},
+				},
+				path: "/one/two",
+			},
+			want: assert.False,
+		},
+		{
+			name: "with the longest allow rule",
+			args: args{
+				rules: []Rule{
+					{Path: "/one", Allow: false},
+					{Path: "/one/*", Allow: true},
+				},
+				path: "/one/two",
+			},
+			want: assert.True,
+		},
+		{
+			name: "with the equivalent rules",
+			args: args{
+				rules: []Rule{
+					{Path: "/one", Allow: false},
+					{Path: "/one", Allow: true},
+					{Path: "/one", Allow: false},
+				},
+				path: "/one",
+			},
+			want: assert.True,
+		},
+		{
+			name: "with a non-normalized path",
+			args: args{
+				rules: []Rule{{Path: "/~one", Allow: false}},
+				path:  "/%7Eone",
+			},
+			want: assert.False,
+		},
+		{
+			name: "with the robots.txt file",
+			args: args{
+				rules: []Rule{{Path: "/", Allow: false}},
+				path:  "/robots.txt",
+			},
+			want: assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := TestRules(data.args.rules, data.args.path)
+
+			data.want(test, got)
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package robotstxtutils
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule ...
+type Rule struct {
+	// it's normalized by the NormalizePath() function
+	Path  string
+	Allow bool
+}
+
+// Group ...
+type Group struct {
+	// they are lowercased product tokens
+	UserAgents []string
+	Rules      []Rule
+	// it's the non-standard directive; zero means its absence
+	CrawlDelay time.Duration
+}
+
+// RuleSet ...
+//
+// It holds the rules of a robots.txt file according to RFC 9309.
+type RuleSet struct {
+	Groups   []Group
+	Sitemaps []string
+}
+
+// AllowAllRuleSet ...
+func AllowAllRuleSet() RuleSet {
+	return RuleSet{}
+}
+
+// DisallowAllRuleSet ...
+func DisallowAllRuleSet() RuleSet {
+	return RuleSet{
+		Groups: []Group{
+			{
+				UserAgents: []string{"*"},
+				Rules:      []Rule{{Path: "/", Allow: false}},
+			},
+		},
+	}
+}
+
+// ParseRuleSet ...
+//
+// Besides the user-agent, allow and disallow records, it supports
+// the non-standard crawl-delay (in seconds) and sitemap ones. The sitemap
+// records don't belong to groups, unlike the other ones. The rest of records
+// is ignored, as well as the malformed lines and the rules outside of groups.
+func ParseRuleSet(data []byte) RuleSet {
+	var ruleSet RuleSet
+	var group *Group
+	var hasRules bool
+	// RFC 9309 allows the CR, the LF and the CR LF line endings;
+	// the empty lines are insignificant
+	lines := strings.FieldsFunc(string(data), func(symbol rune) bool {
+		return symbol == '\r' || symbol == '\n'
+	})
+	for _, line := range lines {
+		if index := strings.IndexByte(line, '#'); index != -1 {
+			line = line[:index]
+		}
+
+		separatorIndex := strings.IndexByte(line, ':')
+		if separatorIndex == -1 {
+			continue
+		}
+
+		key := strings.ToLower(strings.TrimSpace(line[:separatorIndex]))
+		value := strings.TrimSpace(line[separatorIndex+1:])
+		switch key {
+		case "user-agent":
+			// the user-agent line after the rules starts a new group
+			if group == nil || hasRules {
+				ruleSet.Groups = append(ruleSet.Groups, Group{})
+				group = &ruleSet.Groups[len(ruleSet.Groups)-1]
+				hasRules = false
+			}
+
+			group.UserAgents = append(group.UserAgents, ExtractProductToken(value))
+		case "allow", "disallow":
+			if group == nil {
+				continue
+			}
+
+			hasRules = true
+			// an empty path means no rule
+			if value == "" {
+				continue
+			}
+
+			if !strings.HasPrefix(value, "/") && !strings.HasPrefix(value, "*") {
+				value = "/" + value
+			}
+
+			group.Rules = append(group.Rules, Rule{
+				Path:  NormalizePath(value),
+				Allow: key == "allow",
+			})
+		case "crawl-delay":
+			if group == nil {
+				continue
+			}
+
+			// the malformed and the negative values are ignored
+			seconds, err := strconv.ParseFloat(value, 64)
+			if err != nil || seconds < 0 {
+				continue
+			}
+
+			group.CrawlDelay = time.Duration(seconds * float64(time.Second))
+		case "sitemap":
+			if value == "" {
+				continue
+			}
+
+			ruleSet.Sitemaps = append(ruleSet.Sitemaps, value)
+		}
+	}
+
+	return ruleSet
+}
+
+// FindRules ...
+//
+// It combines the rules of all groups that match the product token
+// of the user agent case-insensitively. If there are no such groups,
+// the rules of the groups with the "*" user agent are used.
+func (ruleSet RuleSet) FindRules(userAgent string) []Rule {
+	var rules []Rule
+	for _, group := range ruleSet.findGroups(userAgent) {
+		rules = append(rules, group.Rules...)
+	}
+
+	return rules
+}
+
+// FindCrawlDelay ...
+//
+// It matches the groups in the same way as the FindRules() method
+// and returns the maximal crawl delay among them.
+func (ruleSet RuleSet) FindCrawlDelay(userAgent string) time.Duration {
+	var crawlDelay time.Duration
+	for _, group := range ruleSet.findGroups(userAgent) {
+		if group.CrawlDelay > crawlDelay {
+			crawlDelay = group.CrawlDelay
+		}
+	}
+
+	return crawlDelay
+}
+
+// TestPath ...
+//
+// The path should include the query; see the MakeMatchingPath() function.
+func (ruleSet RuleSet) TestPath(userAgent string, path string) bool {
+	return TestRules(ruleSet.FindRules(userAgent), path)
+}
+
+func (ruleSet RuleSet) findGroups(userAgent string) []Group {
+	productToken := ExtractProductToken(userAgent)
+
+	var groups, commonGroups []Group
+	for _, group := range ruleSet.Groups {
+		for _, groupUserAgent := range group.UserAgents {
+			if groupUserAgent == "*" {
+				commonGroups = append(commonGroups, group)
+			} else if groupUserAgent == productToken {
+				groups = append(groups, group)
+			}
+		}
+	}
+	if len(groups) == 0 {
+		return commonGroups
+	}
+
+	return groups
+}
+
+// ExtractProductToken ...
+//
+// It returns the lowercased product token of the user agent,
+// e.g., "foobot" for "FooBot/1.0".
+func ExtractProductToken(userAgent string) string {
+	productToken := strings.TrimSpace(userAgent)
+	if index := strings.IndexAny(productToken, "/ \t"); index != -1 {
+		productToken = productToken[:index]
+	}
+
+	return strings.ToLower(productToken)
+}
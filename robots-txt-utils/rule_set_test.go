@@ -0,0 +1,382 @@
+package robotstxtutils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRuleSet(test *testing.T) {
+	type args struct {
+		data []byte
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want RuleSet
+	}{
+		{
+			name: "empty",
+			args: args{
+				data: nil,
+			},
+			want: RuleSet{},
+		},
+		{
+			name: "with groups",
+			args: args{
+				data: []byte(
+					"User-agent: FooBot/1.0 # a comment\r\n" +
+						"user-agent: BarBot\r\n" +
+						"Disallow: /one\r\n" +
+						"Crawl-delay: 5\r\n" +
+						"Allow: two\r\n" +
+						"\r\n" +
+						"User-agent: *\r" +
+						"Disallow:\r" +
+						"Disallow: /%7Ethree/%e3%83%84\r",
+				),
+			},
+			want: RuleSet{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot", "barbot"},
+						Rules: []Rule{
+							{Path: "/one", Allow: false},
+							{Path: "/two", Allow: true},
+						},
+						CrawlDelay: 5 * time.Second,
+					},
+					{
+						UserAgents: []string{"*"},
+						Rules: []Rule{
+							{Path: "/~three/%E3%83%84", Allow: false},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "with the non-standard records",
+			args: args{
+				data: []byte(
+					"Sitemap: http://example.com/sitemap_1.xml\n" +
+						"User-agent: foobot\n" +
+						"Crawl-delay: 0.5\n" +
+						"Disallow: /one\n" +
+						"\n" +
+						"User-agent: barbot\n" +
+						"Crawl-delay: incorrect\n" +
+						"Crawl-delay: -5\n" +
+						"Sitemap: http://example.com/sitemap_2.xml\n" +
+						"Sitemap:\n" +
+						"Disallow: /two\n",
+				),
+			},
+			want: RuleSet{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						Rules:      []Rule{{Path: "/one", Allow: false}},
+						CrawlDelay: 500 * time.Millisecond,
+					},
+					{
+						UserAgents: []string{"barbot"},
+						Rules:      []Rule{{Path: "/two", Allow: false}},
+					},
+				},
+				Sitemaps: []string{
+					"http://example.com/sitemap_1.xml",
+					"http://example.com/sitemap_2.xml",
+				},
+			},
+		},
+		{
+			name: "with rules outside of groups",
+			args: args{
+				data: []byte(`
+					Disallow: /one
+					Sitemap: http://example.com/sitemap.xml
+
+					User-agent: *
+					Disallow: /two
+					Malformed line
+				`),
+			},
+			want: RuleSet{
+				Groups: []Group{
+					{
+						UserAgents: []string{"*"},
+						Rules:      []Rule{{Path: "/two", Allow: false}},
+					},
+				},
+				Sitemaps: []string{"http://example.com/sitemap.xml"},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := ParseRuleSet(data.args.data)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestRuleSet_FindRules(test *testing.T) {
+	type fields struct {
+		Groups []Group
+	}
+	type args struct {
+		userAgent string
+	}
+
+	for _, data := range []struct {
+		name   string
+		fields fields
+		args   args
+		want   []Rule
+	}{
+		{
+			name: "with the matched groups",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						Rules:      []Rule{{Path: "/one", Allow: false}},
+					},
+					{
+						UserAgents: []string{"*"},
+						Rules:      []Rule{{Path: "/two", Allow: false}},
+					},
+					{
+						UserAgents: []string{"barbot", "foobot"},
+						Rules:      []Rule{{Path: "/three", Allow: true}},
+					},
+				},
+			},
+			args: args{
+				userAgent: "FooBot/1.0",
+			},
+			want: []Rule{
+				{Path: "/one", Allow: false},
+				{Path: "/three", Allow: true},
+			},
+		},
+		{
+			name: "with the common groups",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						Rules:      []Rule{{Path: "/one", Allow: false}},
+					},
+					{
+						UserAgents: []string{"*"},
+						Rules:      []Rule{{Path: "/two", Allow: false}},
+					},
+				},
+			},
+			args: args{
+				userAgent: "barbot",
+			},
+			want: []Rule{{Path: "/two", Allow: false}},
+		},
+		{
+			name: "with the user agent as a prefix",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foo"},
+						Rules:      []Rule{{Path: "/one", Allow: false}},
+					},
+				},
+			},
+			args: args{
+				userAgent: "foobot",
+			},
+			want: nil,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			ruleSet := RuleSet{
+				Groups: data.fields.Groups,
+			}
+			got := ruleSet.FindRules(data.args.userAgent)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestRuleSet_FindCrawlDelay(test *testing.T) {
+	type fields struct {
+		Groups []Group
+	}
+	type args struct {
+		userAgent string
+	}
+
+	for _, data := range []struct {
+		name   string
+		fields fields
+		args   args
+		want   time.Duration
+	}{
+		{
+			name: "with the matched groups",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						CrawlDelay: 5 * time.Second,
+					},
+					{
+						UserAgents: []string{"*"},
+						CrawlDelay: 23 * time.Second,
+					},
+					{
+						UserAgents: []string{"barbot", "foobot"},
+						CrawlDelay: 10 * time.Second,
+					},
+				},
+			},
+			args: args{
+				userAgent: "FooBot/1.0",
+			},
+			want: 10 * time.Second,
+		},
+		{
+			name: "with the common groups",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						CrawlDelay: 5 * time.Second,
+					},
+					{
+						UserAgents: []string{"*"},
+						CrawlDelay: 23 * time.Second,
+					},
+				},
+			},
+			args: args{
+				userAgent: "barbot",
+			},
+			want: 23 * time.Second,
+		},
+		{
+			name: "without the matched groups",
+			fields: fields{
+				Groups: []Group{
+					{
+						UserAgents: []string{"foobot"},
+						CrawlDelay: 5 * time.Second,
+					},
+				},
+			},
+			args: args{
+				userAgent: "barbot",
+			},
+			want: 0,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			ruleSet := RuleSet{
+				Groups: data.fields.Groups,
+			}
+			got := ruleSet.FindCrawlDelay(data.args.userAgent)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestRuleSet_TestPath(test *testing.T) {
+	type args struct {
+		userAgent string
+		path      string
+	}
+
+	for _, data := range []struct {
+		name    string
+		ruleSet RuleSet
+		args    args
+		want    assert.BoolAssertionFunc
+	}{
+		{
+			name:    "allowing of all paths",
+			ruleSet: AllowAllRuleSet(),
+			args: args{
+				userAgent: "foobot",
+				path:      "/test",
+			},
+			want: assert.True,
+		},
+		{
+			name:    "disallowing of all paths",
+			ruleSet: DisallowAllRuleSet(),
+			args: args{
+				userAgent: "foobot",
+				path:      "/test",
+			},
+			want: assert.False,
+		},
+		{
+			name:    "disallowing of all paths/robots.txt file",
+			ruleSet: DisallowAllRuleSet(),
+			args: args{
+				userAgent: "foobot",
+				path:      "/robots.txt",
+			},
+			want: assert.True,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := data.ruleSet.TestPath(data.args.userAgent, data.args.path)
+
+			data.want(test, got)
+		})
+	}
+}
+
+func TestExtractProductToken(test *testing.T) {
+	type args struct {
+		userAgent string
+	}
+
+	for _, data := range []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "product token",
+			args: args{
+				userAgent: "FooBot",
+			},
+			want: "foobot",
+		},
+		{
+			name: "product token with a version",
+			args: args{
+				userAgent: "FooBot/1.0 (+http://example.com/)",
+			},
+			want: "foobot",
+		},
+		{
+			name: "product token with spaces",
+			args: args{
+				userAgent: "  FooBot (+http://example.com/)",
+			},
+			want: "foobot",
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			got := ExtractProductToken(data.args.userAgent)
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package stats
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Report ...
+type Report struct {
+	ExtractedPageCount          int
+	FailedPageCount             int
+	SkippedPageCount            int
+	ExtractedLinkCount          int
+	HandledLinkCount            int
+	CheckedLinkCount            int
+	RejectedLinkCount           int
+	RejectedLinkCountsByChecker map[string]int
+	Duration                    time.Duration
+}
+
+// Collector ...
+//
+// A nil collector is valid: it discards the counts and returns
+// the empty report.
+type Collector struct {
+	// these fields should be first in the structure
+	// to guarantee the 64-bit alignment required by the atomic operations
+	extractedPageCount int64
+	failedPageCount    int64
+	skippedPageCount   int64
+	extractedLinkCount int64
+	handledLinkCount   int64
+	checkedLinkCount   int64
+	rejectedLinkCount  int64
+
+	rejectedLinkCountsByChecker sync.Map // map[checkerName]*int64
+}
+
+// NewCollector ...
+func NewCollector() *Collector {
+	return new(Collector)
+}
+
+// AddExtractedPage ...
+func (collector *Collector) AddExtractedPage() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.extractedPageCount, 1)
+}
+
+// AddFailedPage ...
+func (collector *Collector) AddFailedPage() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.failedPageCount, 1)
+}
+
+// AddSkippedPage ...
+func (collector *Collector) AddSkippedPage() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.skippedPageCount, 1)
+}
+
+// AddExtractedLinks ...
+func (collector *Collector) AddExtractedLinks(count int) {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.extractedLinkCount, int64(count))
+}
+
+// AddHandledLink ...
+func (collector *Collector) AddHandledLink() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.handledLinkCount, 1)
+}
+
+// AddCheckedLink ...
+func (collector *Collector) AddCheckedLink() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.checkedLinkCount, 1)
+}
+
+// AddRejectedLink ...
+func (collector *Collector) AddRejectedLink() {
+	if collector == nil {
+		return
+	}
+
+	atomic.AddInt64(&collector.rejectedLinkCount, 1)
+}
+
+// AddRejectedLinkByChecker ...
+func (collector *Collector) AddRejectedLinkByChecker(checkerName string) {
+	if collector == nil {
+		return
+	}
+
+	count, _ :=
+		collector.rejectedLinkCountsByChecker.LoadOrStore(checkerName, new(int64))
+	atomic.AddInt64(count.(*int64), 1)
+}
+
+// Report ...
+//
+// The returned report has the zero duration,
+// because the collector doesn't know when the crawling was started.
+func (collector *Collector) Report() Report {
+	if collector == nil {
+		return Report{}
+	}
+
+	var rejectedLinkCountsByChecker map[string]int
+	collector.rejectedLinkCountsByChecker.Range(
+		func(checkerName interface{}, count interface{}) bool {
+			if rejectedLinkCountsByChecker == nil {
+				rejectedLinkCountsByChecker = make(map[string]int)
+			}
+
+			rejectedLinkCountsByChecker[checkerName.(string)] =
+				int(atomic.LoadInt64(count.(*int64)))
+			return true
+		},
+	)
+
+	return Report{
+		ExtractedPageCount: loadCount(&collector.extractedPageCount),
+		FailedPageCount:    loadCount(&collector.failedPageCount),
+		SkippedPageCount:   loadCount(&collector.skippedPageCount),
+		ExtractedLinkCount: loadCount(&collector.extractedLinkCount),
+		HandledLinkCount:   loadCount(&collector.handledLinkCount),
+		CheckedLinkCount:   loadCount(&collector.checkedLinkCount),
+		RejectedLinkCount:  loadCount(&collector.rejectedLinkCount),
+
+		RejectedLinkCountsByChecker: rejectedLinkCountsByChecker,
+	}
+}
+
+func loadCount(count *int64) int {
+	return int(atomic.LoadInt64(count))
+}
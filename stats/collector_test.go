@@ -0,0 +1,100 @@
+package stats
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(test *testing.T) {
+	for _, data := range []struct {
+		name    string
+		collect func(collector *Collector)
+		want    Report
+	}{
+		{
+			name:    "empty",
+			collect: func(collector *Collector) {},
+			want:    Report{},
+		},
+		{
+			name: "sequential",
+			collect: func(collector *Collector) {
+				collector.AddExtractedPage()
+				collector.AddExtractedPage()
+				collector.AddFailedPage()
+				collector.AddSkippedPage()
+				collector.AddExtractedLinks(3)
+				collector.AddHandledLink()
+				collector.AddHandledLink()
+				collector.AddCheckedLink()
+				collector.AddRejectedLink()
+				collector.AddRejectedLinkByChecker("one")
+				collector.AddRejectedLinkByChecker("two")
+				collector.AddRejectedLinkByChecker("two")
+			},
+			want: Report{
+				ExtractedPageCount: 2,
+				FailedPageCount:    1,
+				SkippedPageCount:   1,
+				ExtractedLinkCount: 3,
+				HandledLinkCount:   2,
+				CheckedLinkCount:   1,
+				RejectedLinkCount:  1,
+				RejectedLinkCountsByChecker: map[string]int{
+					"one": 1,
+					"two": 2,
+				},
+			},
+		},
+		{
+			name: "concurrent",
+			collect: func(collector *Collector) {
+				var waiter sync.WaitGroup
+				waiter.Add(10)
+
+				for threadID := 0; threadID < 10; threadID++ {
+					go func() {
+						defer waiter.Done()
+
+						collector.AddExtractedPage()
+						collector.AddExtractedLinks(2)
+						collector.AddRejectedLinkByChecker("checker")
+					}()
+				}
+
+				waiter.Wait()
+			},
+			want: Report{
+				ExtractedPageCount: 10,
+				ExtractedLinkCount: 20,
+				RejectedLinkCountsByChecker: map[string]int{
+					"checker": 10,
+				},
+			},
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			collector := NewCollector()
+			data.collect(collector)
+			got := collector.Report()
+
+			assert.Equal(test, data.want, got)
+		})
+	}
+}
+
+func TestCollector_nil(test *testing.T) {
+	var collector *Collector
+	collector.AddExtractedPage()
+	collector.AddFailedPage()
+	collector.AddSkippedPage()
+	collector.AddExtractedLinks(3)
+	collector.AddHandledLink()
+	collector.AddCheckedLink()
+	collector.AddRejectedLink()
+	collector.AddRejectedLinkByChecker("one")
+
+	assert.Equal(test, Report{}, collector.Report())
+}
@@ -22,12 +22,9 @@ func GenerateHierarchicalLinks( // nolint: gocyclo
 		option(&config)
 	}
 
-	if config.sanitizeBaseLink == SanitizeLink {
-		var err error
-		baseLink, err = ApplyLinkSanitizing(baseLink)
-		if err != nil {
-			return nil, errors.Wrap(err, "unable to sanitize the base link")
-		}
+	baseLink, err := ApplyLinkSanitizer(config.sanitizeBaseLink, baseLink)
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to sanitize the base link")
 	}
 
 	parsedBaseLink, err := url.Parse(baseLink)
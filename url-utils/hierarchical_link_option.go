@@ -2,7 +2,7 @@ package urlutils
 
 // HierarchicalLinkConfig ...
 type HierarchicalLinkConfig struct {
-	sanitizeBaseLink      LinkSanitizing
+	sanitizeBaseLink      LinkSanitizer
 	maximalHierarchyDepth int
 }
 
@@ -10,7 +10,7 @@ type HierarchicalLinkConfig struct {
 type HierarchicalLinkOption func(config *HierarchicalLinkConfig)
 
 // SanitizeBaseLink ...
-func SanitizeBaseLink(sanitize LinkSanitizing) HierarchicalLinkOption {
+func SanitizeBaseLink(sanitize LinkSanitizer) HierarchicalLinkOption {
 	return func(config *HierarchicalLinkConfig) {
 		config.sanitizeBaseLink = sanitize
 	}
@@ -0,0 +1,216 @@
+package urlutils
+
+import (
+	"net"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/idna"
+)
+
+var defaultPorts = map[string]string{ // nolint: gochecknoglobals
+	"http":  "80",
+	"https": "443",
+}
+
+// LinkNormalizer ...
+//
+// It implements the LinkSanitizer interface. Besides the cleaning of the path,
+// it lowercases the scheme and the host, decodes the IDNs, drops the default
+// ports and the fragment, sorts the query parameters, removes the tracking
+// ones, and normalizes the percent-encoding.
+type LinkNormalizer struct {
+	trackingParameters []string
+	keepFragment       bool
+	keepParameterOrder bool
+}
+
+// NewLinkNormalizer ...
+func NewLinkNormalizer(options ...LinkNormalizerOption) LinkNormalizer {
+	var normalizer LinkNormalizer
+	for _, option := range options {
+		option(&normalizer)
+	}
+
+	return normalizer
+}
+
+// SanitizeLink ...
+func (normalizer LinkNormalizer) SanitizeLink(link string) (string, error) {
+	parsedLink, err := url.Parse(link)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to parse the link")
+	}
+
+	scheme := strings.ToLower(parsedLink.Scheme)
+	if parsedLink.Opaque != "" {
+		parsedLink.Scheme = scheme
+		return parsedLink.String(), nil
+	}
+
+	host, err := normalizeHost(scheme, parsedLink.Host)
+	if err != nil {
+		return "", errors.Wrap(err, "unable to normalize the host")
+	}
+
+	linkPath := normalizePath(parsedLink.EscapedPath())
+	if linkPath == "" && host != "" {
+		linkPath = "/"
+	}
+
+	var builder strings.Builder
+	if scheme != "" {
+		builder.WriteString(scheme + ":")
+	}
+	if host != "" || parsedLink.User != nil || (scheme != "" && linkPath != "") {
+		builder.WriteString("//")
+		if parsedLink.User != nil {
+			builder.WriteString(parsedLink.User.String() + "@")
+		}
+		builder.WriteString(host)
+	}
+	builder.WriteString(linkPath)
+	if query := normalizer.normalizeQuery(parsedLink.RawQuery); query != "" {
+		builder.WriteString("?" + query)
+	}
+	if normalizer.keepFragment && parsedLink.Fragment != "" {
+		fragment := NormalizePercentEncoding(parsedLink.EscapedFragment())
+		builder.WriteString("#" + fragment)
+	}
+
+	return builder.String(), nil
+}
+
+func (normalizer LinkNormalizer) normalizeQuery(query string) string {
+	var parameters []string
+	for _, parameter := range strings.Split(query, "&") {
+		if parameter == "" {
+			continue
+		}
+
+		parameter = NormalizePercentEncoding(parameter)
+		if normalizer.isTrackingParameter(parameter) {
+			continue
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	if !normalizer.keepParameterOrder {
+		sort.SliceStable(parameters, func(i int, j int) bool {
+			return parameterName(parameters[i]) < parameterName(parameters[j])
+		})
+	}
+
+	return strings.Join(parameters, "&")
+}
+
+func (normalizer LinkNormalizer) isTrackingParameter(parameter string) bool {
+	name := parameterName(parameter)
+	if unescapedName, err := url.QueryUnescape(name); err == nil {
+		name = unescapedName
+	}
+
+	for _, pattern := range normalizer.trackingParameters {
+		// an incorrect pattern matches nothing
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+func normalizeHost(scheme string, host string) (string, error) {
+	hostname, port := host, ""
+	if splittedHostname, splittedPort, err := net.SplitHostPort(host); err == nil {
+		hostname, port = splittedHostname, splittedPort
+	} else if strings.HasSuffix(host, ":") {
+		hostname = strings.TrimSuffix(host, ":")
+	}
+	hostname = strings.ToLower(strings.Trim(hostname, "[]"))
+
+	labels := strings.Split(hostname, ".")
+	for index, label := range labels {
+		if !strings.HasPrefix(label, "xn--") {
+			continue
+		}
+
+		decodedLabel, err := idna.ToUnicode(label)
+		if err != nil {
+			return "", errors.Wrapf(err, "unable to decode the IDN label %q", label)
+		}
+
+		labels[index] = decodedLabel
+	}
+	hostname = strings.Join(labels, ".")
+
+	if strings.Contains(hostname, ":") {
+		hostname = "[" + hostname + "]"
+	}
+	if port != "" && port != defaultPorts[scheme] {
+		hostname += ":" + port
+	}
+
+	return hostname, nil
+}
+
+func normalizePath(escapedPath string) string {
+	if escapedPath == "" {
+		return ""
+	}
+
+	normalizedPath := path.Clean(NormalizePercentEncoding(escapedPath))
+	if strings.HasSuffix(escapedPath, "/") && normalizedPath != "/" {
+		normalizedPath += "/"
+	}
+
+	return normalizedPath
+}
+
+// NormalizePercentEncoding ...
+//
+// It decodes the percent-encoded unreserved characters and uppercases
+// the hexadecimal digits of the remaining percent-encodings. The incorrect
+// percent-encodings are kept as is.
+func NormalizePercentEncoding(text string) string {
+	var builder strings.Builder
+	for index := 0; index < len(text); index++ {
+		if text[index] == '%' && index+2 < len(text) {
+			code, err := strconv.ParseUint(text[index+1:index+3], 16, 8)
+			if err == nil {
+				if isUnreservedCharacter(byte(code)) {
+					builder.WriteByte(byte(code))
+				} else {
+					builder.WriteString("%" + strings.ToUpper(text[index+1:index+3]))
+				}
+
+				index += 2
+				continue
+			}
+		}
+
+		builder.WriteByte(text[index])
+	}
+
+	return builder.String()
+}
+
+func isUnreservedCharacter(character byte) bool {
+	return 'a' <= character && character <= 'z' ||
+		'A' <= character && character <= 'Z' ||
+		'0' <= character && character <= '9' ||
+		strings.IndexByte("-._~", character) != -1
+}
+
+func parameterName(parameter string) string {
+	if index := strings.IndexByte(parameter, '='); index != -1 {
+		return parameter[:index]
+	}
+
+	return parameter
+}
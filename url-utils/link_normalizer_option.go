@@ -0,0 +1,28 @@
+package urlutils
+
+// LinkNormalizerOption ...
+type LinkNormalizerOption func(normalizer *LinkNormalizer)
+
+// WithTrackingParameters ...
+//
+// The parameters are specified by the patterns in the path.Match() syntax,
+// e.g. "utm_*".
+func WithTrackingParameters(patterns ...string) LinkNormalizerOption {
+	return func(normalizer *LinkNormalizer) {
+		normalizer.trackingParameters = patterns
+	}
+}
+
+// KeepFragment ...
+func KeepFragment() LinkNormalizerOption {
+	return func(normalizer *LinkNormalizer) {
+		normalizer.keepFragment = true
+	}
+}
+
+// KeepParameterOrder ...
+func KeepParameterOrder() LinkNormalizerOption {
+	return func(normalizer *LinkNormalizer) {
+		normalizer.keepParameterOrder = true
+	}
+}
@@ -0,0 +1,32 @@
+package urlutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithTrackingParameters(test *testing.T) {
+	var normalizer LinkNormalizer
+	option := WithTrackingParameters("utm_*", "fbclid")
+	option(&normalizer)
+
+	wantParameters := []string{"utm_*", "fbclid"}
+	assert.Equal(test, wantParameters, normalizer.trackingParameters)
+}
+
+func TestKeepFragment(test *testing.T) {
+	var normalizer LinkNormalizer
+	option := KeepFragment()
+	option(&normalizer)
+
+	assert.True(test, normalizer.keepFragment)
+}
+
+func TestKeepParameterOrder(test *testing.T) {
+	var normalizer LinkNormalizer
+	option := KeepParameterOrder()
+	option(&normalizer)
+
+	assert.True(test, normalizer.keepParameterOrder)
+}
@@ -7,6 +7,11 @@ import (
 	"github.com/pkg/errors"
 )
 
+// LinkSanitizer ...
+type LinkSanitizer interface {
+	SanitizeLink(link string) (string, error)
+}
+
 // LinkSanitizing ...
 type LinkSanitizing int
 
@@ -16,6 +21,29 @@ const (
 	SanitizeLink
 )
 
+// SanitizeLink ...
+//
+// It implements the LinkSanitizer interface, so the simple sanitizing modes
+// can be used interchangeably with the LinkNormalizer.
+func (sanitizing LinkSanitizing) SanitizeLink(link string) (string, error) {
+	if sanitizing != SanitizeLink {
+		return link, nil
+	}
+
+	return ApplyLinkSanitizing(link)
+}
+
+// ApplyLinkSanitizer ...
+//
+// The nil sanitizer is treated as DoNotSanitizeLink.
+func ApplyLinkSanitizer(sanitizer LinkSanitizer, link string) (string, error) {
+	if sanitizer == nil {
+		return link, nil
+	}
+
+	return sanitizer.SanitizeLink(link)
+}
+
 // ApplyLinkSanitizing ...
 func ApplyLinkSanitizing(link string) (string, error) {
 	parsedLink, err := url.Parse(link)
@@ -6,6 +6,84 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestLinkSanitizing_SanitizeLink(test *testing.T) {
+	for _, data := range []struct {
+		name       string
+		sanitizing LinkSanitizing
+		link       string
+		wantLink   string
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "success without sanitizing",
+			sanitizing: DoNotSanitizeLink,
+			link:       "http://example.com/one/../two",
+			wantLink:   "http://example.com/one/../two",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "success with sanitizing",
+			sanitizing: SanitizeLink,
+			link:       "http://example.com/one/../two",
+			wantLink:   "http://example.com/two",
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "error",
+			sanitizing: SanitizeLink,
+			link:       ":",
+			wantLink:   "",
+			wantErr:    assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotLink, gotErr := data.sanitizing.SanitizeLink(data.link)
+
+			assert.Equal(test, data.wantLink, gotLink)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
+func TestApplyLinkSanitizer(test *testing.T) {
+	for _, data := range []struct {
+		name      string
+		sanitizer LinkSanitizer
+		link      string
+		wantLink  string
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name:      "success without a sanitizer",
+			sanitizer: nil,
+			link:      "HTTP://example.com/one/../two",
+			wantLink:  "HTTP://example.com/one/../two",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "success with a sanitizer",
+			sanitizer: NewLinkNormalizer(),
+			link:      "HTTP://example.com/one/../two",
+			wantLink:  "http://example.com/two",
+			wantErr:   assert.NoError,
+		},
+		{
+			name:      "error",
+			sanitizer: NewLinkNormalizer(),
+			link:      ":",
+			wantLink:  "",
+			wantErr:   assert.Error,
+		},
+	} {
+		test.Run(data.name, func(test *testing.T) {
+			gotLink, gotErr := ApplyLinkSanitizer(data.sanitizer, data.link)
+
+			assert.Equal(test, data.wantLink, gotLink)
+			data.wantErr(test, gotErr)
+		})
+	}
+}
+
 func TestApplyLinkSanitizing(test *testing.T) {
 	type args struct {
 		link string